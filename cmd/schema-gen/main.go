@@ -15,15 +15,20 @@ limitations under the License.
 */
 
 // schema-gen generates a JSON schema from the Go types in internal/api using invopop/jsonschema. The output path is required.
+// With -comments, it also generates a Go file with the comments of the types, so that xprin schema prints the same
+// schema, with its descriptions, without the source files.
 package main
 
 import (
+	"bytes"
 	"encoding/json"
-	"errors"
 	"flag"
 	"fmt"
+	"go/format"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/crossplane-contrib/xprin/internal/api"
 	"github.com/invopop/jsonschema"
@@ -31,6 +36,7 @@ import (
 
 func main() {
 	outPath := flag.String("out", "", "Output path for the generated schema (required)")
+	commentsPath := flag.String("comments", "", "Output path for the generated Go file with the comments of the types (optional)")
 	flag.Parse()
 
 	if *outPath == "" {
@@ -38,25 +44,19 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := run(*outPath); err != nil {
+	if err := run(*outPath, *commentsPath); err != nil {
 		fmt.Fprintf(os.Stderr, "schema-gen: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func run(outPath string) error {
+func run(outPath, commentsPath string) error {
 	r := new(jsonschema.Reflector)
 	if err := r.AddGoComments("github.com/crossplane-contrib/xprin", "internal/api"); err != nil {
 		return fmt.Errorf("reading comments: %w", err)
 	}
 
-	schema := r.Reflect(&api.TestSuiteSpec{})
-	if schema == nil {
-		return errors.New("reflect returned nil")
-	}
-
-	schema.Title = "xprin"
-	schema.Description = "xprin test suite files (xprin.yaml or *_xprin.yaml)"
+	schema := api.ReflectSchema(r)
 
 	data, err := json.Marshal(schema)
 	if err != nil {
@@ -81,5 +81,36 @@ func run(outPath string) error {
 		return fmt.Errorf("write %s: %w", outPath, err)
 	}
 
+	if commentsPath == "" {
+		return nil
+	}
+
+	return writeComments(commentsPath, r.CommentMap)
+}
+
+// writeComments writes the comments of the types in internal/api as the commentMap of package api.
+func writeComments(path string, comments map[string]string) error {
+	var b bytes.Buffer
+
+	b.WriteString("// Code generated by schema-gen. DO NOT EDIT.\n\n")
+	b.WriteString("package api\n\n")
+	b.WriteString("// commentMap holds the Go comments of the types of testsuite files, the descriptions of their JSON schema.\n")
+	b.WriteString("var commentMap = map[string]string{\n")
+
+	for _, key := range slices.Sorted(maps.Keys(comments)) {
+		fmt.Fprintf(&b, "%q: %q,\n", key, comments[key])
+	}
+
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("format comments: %w", err)
+	}
+
+	if err := os.WriteFile(path, src, 0o600); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+
 	return nil
 }
@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestGeneratedFilesAreUpToDate(t *testing.T) {
+	// The comments are read relative to the root of the module, as go generate runs there
+	t.Chdir(filepath.Join("..", ".."))
+
+	dir := t.TempDir()
+	require.NoError(t, run(filepath.Join(dir, "xprin-testsuite.json"), filepath.Join(dir, "zz_generated.comments.go")))
+
+	for generated, committed := range map[string]string{
+		"xprin-testsuite.json":     filepath.Join("data", "xprin-testsuite.json"),
+		"zz_generated.comments.go": filepath.Join("internal", "api", "zz_generated.comments.go"),
+	} {
+		want, err := os.ReadFile(filepath.Join(dir, generated))
+		require.NoError(t, err)
+
+		got, err := os.ReadFile(committed)
+		require.NoError(t, err)
+
+		assert.Equal(t, string(want), string(got), "%s is out of date, run: go generate -tags generate .", committed)
+	}
+}
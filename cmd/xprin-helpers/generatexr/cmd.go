@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package generatexr implements the command for generating XRs (Composite Resources) from the schema of an XRD.
+package generatexr
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane-contrib/xprin/internal/xrgen"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	commonIO "github.com/crossplane/crossplane/v2/cmd/crank/beta/convert/io"
+)
+
+// Cmd arguments and flags for generating XRs from the schema of an XRD.
+type Cmd struct {
+	// Arguments.
+	InputFile string `arg:"" default:"-" help:"The XRD YAML file to generate XRs from. If not specified or '-', stdin will be used." optional:"" predictor:"file" type:"path"`
+
+	// Flags.
+	Modes     []string `enum:"minimal,maximal,boundary,random"                                                                                                     help:"Kinds of XRs to generate: minimal, maximal, boundary or random. Can be repeated. All of them if not specified." name:"mode"     placeholder:"MODE" sep:"none"`
+	Count     int      `default:"5"                                                                                                                                help:"The number of random XRs to generate."                                                                          placeholder:"N"`
+	Seed      uint64   `default:"0"                                                                                                                                help:"The seed of the random XRs. The same seed always generates the same XRs."                                       placeholder:"N"`
+	OutputDir string   `help:"The directory to write one YAML file per generated XR to. If not specified, all XRs are written to stdout as a multi-document YAML." placeholder:"DIR"                                                                                                     short:"o"       type:"path"`
+
+	fs afero.Fs
+}
+
+// Help returns help message for the generate-xr command.
+func (c *Cmd) Help() string {
+	return `
+Generate Crossplane Composite Resources (XRs) from the OpenAPI schema of an XRD, for property-based testing.
+
+This command will:
+- Read the XRD from the provided YAML file
+- Use its referenceable version, or else its first served version
+- Generate XRs for each mode:
+  - minimal: only the required fields set
+  - maximal: every field set, including the optional ones
+  - boundary: one XR per boundary value of a field, on top of the minimal XR (each enum value,
+    minimum and maximum, minLength and maxLength, minItems and maxItems, a value matching the pattern)
+  - random: --count XRs with random values within the schema, reproducible with --seed
+- Apply the defaults of the XRD schema
+
+Examples:
+
+  # Generate all kinds of XRs from xrd.yaml and write them to stdout
+  xprin-helpers generate-xr xrd.yaml
+
+  # Generate only the minimal and maximal XRs
+  xprin-helpers generate-xr xrd.yaml --mode minimal --mode maximal
+
+  # Generate 20 random XRs with seed 42 and write one file per XR to xrs/
+  xprin-helpers generate-xr xrd.yaml --mode random --count 20 --seed 42 -o xrs
+`
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *Cmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// Run runs the generate-xr command.
+func (c *Cmd) Run(k *kong.Context) error {
+	xrdData, err := commonIO.Read(c.fs, c.InputFile)
+	if err != nil {
+		return err
+	}
+
+	xrd, err := xrgen.ParseXRD(xrdData)
+	if err != nil {
+		return err
+	}
+
+	modes := make([]xrgen.Mode, 0, len(c.Modes))
+	for _, mode := range c.Modes {
+		modes = append(modes, xrgen.Mode(mode))
+	}
+
+	examples, err := xrgen.Generate(xrd, xrgen.Options{Modes: modes, Count: c.Count, Seed: c.Seed})
+	if err != nil {
+		return errors.Wrap(err, "failed to generate XRs")
+	}
+
+	if c.OutputDir != "" {
+		return c.writeFiles(k, examples)
+	}
+
+	outputW := bufio.NewWriter(k.Stdout)
+
+	for _, example := range examples {
+		b, err := yaml.Marshal(example.XR.Object)
+		if err != nil {
+			return errors.Wrap(err, "Unable to marshal back to yaml")
+		}
+
+		if _, err := fmt.Fprintf(outputW, "---\n# %s\n%s", example.Name, b); err != nil {
+			return errors.Wrap(err, "Writing YAML document")
+		}
+	}
+
+	if err := outputW.Flush(); err != nil {
+		return errors.Wrap(err, "Flushing output")
+	}
+
+	return nil
+}
+
+// writeFiles writes each generated XR to its own file in the output directory, e.g. 01-minimal.yaml.
+func (c *Cmd) writeFiles(k *kong.Context, examples []xrgen.Example) error {
+	if err := c.fs.MkdirAll(c.OutputDir, 0o750); err != nil {
+		return errors.Wrap(err, "Unable to create output directory")
+	}
+
+	for i, example := range examples {
+		b, err := yaml.Marshal(example.XR.Object)
+		if err != nil {
+			return errors.Wrap(err, "Unable to marshal back to yaml")
+		}
+
+		file := filepath.Join(c.OutputDir, fileName(i, len(examples), example.Name))
+		if err := afero.WriteFile(c.fs, file, b, 0o644); err != nil {
+			return errors.Wrap(err, "Unable to write output file")
+		}
+
+		if _, err := fmt.Fprintln(k.Stdout, file); err != nil {
+			return errors.Wrap(err, "Writing output")
+		}
+	}
+
+	return nil
+}
+
+// nonFileNameChars are the runs of characters that are replaced with a hyphen in file names.
+var nonFileNameChars = regexp.MustCompile(`[^a-z0-9.]+`)
+
+// fileName returns the name of the file of the i-th of n generated XRs, e.g. "03-boundary-spec.size-maximum.yaml",
+// numbered so that the files sort in the order in which the XRs were generated.
+func fileName(i, n int, name string) string {
+	slug := strings.Trim(nonFileNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	return fmt.Sprintf("%0*d-%s.yaml", len(fmt.Sprint(n)), i+1, slug)
+}
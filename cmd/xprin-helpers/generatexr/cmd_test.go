@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generatexr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+const testXRD = `apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: xbuckets.example.com
+spec:
+  group: example.com
+  names:
+    kind: XBucket
+    plural: xbuckets
+  versions:
+  - name: v1
+    served: true
+    referenceable: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [tier]
+            properties:
+              tier:
+                type: string
+                enum: [small, large]
+`
+
+func TestRun(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "xrd.yaml", []byte(testXRD), 0o644))
+
+	var stdout bytes.Buffer
+
+	cmd := &Cmd{InputFile: "xrd.yaml", Modes: []string{"minimal", "boundary"}, fs: fs}
+	require.NoError(t, cmd.Run(&kong.Context{Kong: &kong.Kong{Stdout: &stdout}}))
+
+	assert.Equal(t, `---
+# minimal
+apiVersion: example.com/v1
+kind: XBucket
+metadata:
+  name: example-xbucket
+spec:
+  tier: small
+---
+# boundary spec.tier=small
+apiVersion: example.com/v1
+kind: XBucket
+metadata:
+  name: example-xbucket
+spec:
+  tier: small
+---
+# boundary spec.tier=large
+apiVersion: example.com/v1
+kind: XBucket
+metadata:
+  name: example-xbucket
+spec:
+  tier: large
+`, stdout.String())
+
+	stdout.Reset()
+
+	cmd.OutputDir = "xrs"
+	require.NoError(t, cmd.Run(&kong.Context{Kong: &kong.Kong{Stdout: &stdout}}))
+	assert.Equal(t, "xrs/1-minimal.yaml\nxrs/2-boundary-spec.tier-small.yaml\nxrs/3-boundary-spec.tier-large.yaml\n", stdout.String())
+
+	data, err := afero.ReadFile(fs, "xrs/3-boundary-spec.tier-large.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "tier: large")
+}
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "1-minimal.yaml", fileName(0, 3, "minimal"))
+	assert.Equal(t, "03-boundary-spec.size-maximum.yaml", fileName(2, 12, "boundary spec.size maximum"))
+	assert.Equal(t, "12-random-12.yaml", fileName(11, 12, "random 12"))
+}
@@ -22,15 +22,17 @@ import (
 
 	"github.com/alecthomas/kong"
 	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/claimtoxr"
+	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/generatexr"
 	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/patchxr"
 	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/version"
 )
 
 // CLI represents the command-line interface structure.
 type CLI struct {
-	ConvertClaimToXR claimtoxr.Cmd `cmd:"convert-claim-to-xr" help:"Convert a Crossplane Claim to an XR (Composite Resource)."`
-	PatchXR          patchxr.Cmd   `cmd:""                    help:"Patch a Crossplane XR (Composite Resource) with additional configurations."`
-	Version          version.Cmd   `cmd:""                    help:"Print the version of xprin-helpers"`
+	ConvertClaimToXR claimtoxr.Cmd  `cmd:"convert-claim-to-xr" help:"Convert a Crossplane Claim to an XR (Composite Resource)."`
+	GenerateXR       generatexr.Cmd `cmd:"generate-xr"         help:"Generate XRs (Composite Resources) from the schema of an XRD."`
+	PatchXR          patchxr.Cmd    `cmd:""                    help:"Patch a Crossplane XR (Composite Resource) with additional configurations."`
+	Version          version.Cmd    `cmd:""                    help:"Print the version of xprin-helpers"`
 }
 
 func main() {
@@ -38,7 +40,7 @@ func main() {
 
 	ctx := kong.Parse(&cli,
 		kong.Name("xprin-helpers"),
-		kong.Description("Crossplane helper utilities for converting and patching resources."),
+		kong.Description("Crossplane helper utilities for converting, patching and generating resources."),
 		kong.UsageOnError(),
 		kong.ConfigureHelp(kong.HelpOptions{
 			Summary: true,
@@ -20,6 +20,7 @@ package patchxr
 import (
 	"bufio"
 	"os"
+	"strings"
 
 	"github.com/alecthomas/kong"
 	"github.com/spf13/afero"
@@ -41,10 +42,13 @@ type Cmd struct {
 	OutputFile string `help:"The file to write the patched XR YAML to. If not specified, stdout will be used." placeholder:"PATH" predictor:"file" short:"o" type:"path"`
 
 	// Patching Flags.
-	AddConnectionSecret       bool   `help:"Add writeConnectionSecretToRef to the XR spec. Must be explicitly set to true when using connection-secret-name or connection-secret-namespace."                                        name:"add-connection-secret"`
-	ConnectionSecretName      string `help:"Custom name for the connection secret. If not specified, it generates a random UUID. Requires --add-connection-secret=true."                                                            name:"connection-secret-name"      type:"string"`
-	ConnectionSecretNamespace string `help:"Custom namespace for the connection secret. If not specified, 'default' will be used. Requires --add-connection-secret=true."                                                           name:"connection-secret-namespace" type:"string"`
-	XRD                       string `help:"A YAML file specifying the CompositeResourceDefinition (XRD) that defines the XR's schema and properties. When provided, default values from the XRD schema will be applied to the XR." name:"xrd"                         placeholder:"PATH" predictor:"file" type:"path"`
+	AddConnectionSecret       bool     `help:"Add writeConnectionSecretToRef to the XR spec. Must be explicitly set to true when using connection-secret-name or connection-secret-namespace."                                        name:"add-connection-secret"`
+	ConnectionSecretName      string   `help:"Custom name for the connection secret. If not specified, it generates a random UUID. Requires --add-connection-secret=true."                                                            name:"connection-secret-name"      type:"string"`
+	ConnectionSecretNamespace string   `help:"Custom namespace for the connection secret. If not specified, 'default' will be used. Requires --add-connection-secret=true."                                                           name:"connection-secret-namespace" type:"string"`
+	XRD                       string   `help:"A YAML file specifying the CompositeResourceDefinition (XRD) that defines the XR's schema and properties. When provided, default values from the XRD schema will be applied to the XR." name:"xrd"                         placeholder:"PATH"       predictor:"file" type:"path"`
+	Set                       []string `help:"Set a field of the XR, in PATH=VALUE format. PATH is a Crossplane fieldpath (e.g. spec.parameters.tags[0]) and VALUE is parsed as YAML. Can be repeated."                               name:"set"                         placeholder:"PATH=VALUE" sep:"none"`
+	Merge                     []string `help:"A YAML or JSON file with a JSON merge patch (RFC 7386) to apply to the XR. Can be repeated."                                                                                            name:"merge"                       placeholder:"PATH"       predictor:"file" sep:"none"`
+	JSONPatch                 []string `help:"A YAML or JSON file with a list of JSON patch operations (RFC 6902) to apply to the XR. Can be repeated."                                                                               name:"jsonpatch"                   placeholder:"PATH"       predictor:"file" sep:"none"`
 
 	fs afero.Fs
 }
@@ -58,6 +62,9 @@ This command will:
 1. Read the XR from the provided YAML file
 2. Apply any requested patches to the XR (such as connection secret)
 3. Apply default values from an XRD if provided
+4. Apply field sets, JSON merge patches and JSON patches, in this order
+
+Field patches are applied after the XRD defaults, so they can override defaulted values.
 
 Examples:
 
@@ -76,6 +83,12 @@ Examples:
   # Combine patching flags
   xprin-helpers patch-xr xr.yaml --add-connection-secret --xrd=xrd.yaml
 
+  # Set fields using Crossplane fieldpaths (values are parsed as YAML)
+  xprin-helpers patch-xr xr.yaml --set spec.parameters.region=eu-west-1 --set 'spec.parameters.tags[0]=team-a'
+
+  # Apply a JSON merge patch and a JSON patch from files
+  xprin-helpers patch-xr xr.yaml --merge merge-patch.yaml --jsonpatch json-patch.yaml
+
   # Patch XR from stdin
   cat xr.yaml | xprin-helpers patch-xr - --add-connection-secret
 `
@@ -123,6 +136,10 @@ func (c *Cmd) Run(k *kong.Context) error {
 		}
 	}
 
+	if err := c.applyFieldPatches(xr); err != nil {
+		return err
+	}
+
 	// Add connection secret if requested
 	if c.AddConnectionSecret {
 		if err := AddConnectionSecret(xr, c.ConnectionSecretName, c.ConnectionSecretNamespace); err != nil {
@@ -163,3 +180,61 @@ func (c *Cmd) Run(k *kong.Context) error {
 
 	return nil
 }
+
+// applyFieldPatches applies the --set, --merge and --jsonpatch flags to the XR, in this order.
+func (c *Cmd) applyFieldPatches(xr *unstructured.Unstructured) error {
+	for _, set := range c.Set {
+		path, rawValue, found := strings.Cut(set, "=")
+		if !found || path == "" {
+			return errors.Errorf("invalid --set %q: must be in PATH=VALUE format", set)
+		}
+
+		var value any
+		if err := yaml.Unmarshal([]byte(rawValue), &value); err != nil {
+			return errors.Wrapf(err, "invalid value in --set %q", set)
+		}
+
+		if err := SetField(xr, path, value); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range c.Merge {
+		patch, err := c.readPatchFile(file)
+		if err != nil {
+			return err
+		}
+
+		if err := MergePatch(xr, patch); err != nil {
+			return errors.Wrapf(err, "failed to apply merge patch %s", file)
+		}
+	}
+
+	for _, file := range c.JSONPatch {
+		patch, err := c.readPatchFile(file)
+		if err != nil {
+			return err
+		}
+
+		if err := JSONPatch(xr, patch); err != nil {
+			return errors.Wrapf(err, "failed to apply JSON patch %s", file)
+		}
+	}
+
+	return nil
+}
+
+// readPatchFile reads a YAML or JSON patch file and returns it as JSON.
+func (c *Cmd) readPatchFile(file string) ([]byte, error) {
+	data, err := afero.ReadFile(c.fs, file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read patch file %s", file)
+	}
+
+	patch, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse patch file %s", file)
+	}
+
+	return patch, nil
+}
@@ -19,6 +19,7 @@ package patchxr
 import (
 	"encoding/json"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/google/uuid"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -34,7 +35,7 @@ import (
 
 // hasPatchingFlags determines if any patching flags are provided.
 func (c *Cmd) hasPatchingFlags() bool {
-	return c.XRD != "" || c.AddConnectionSecret
+	return c.XRD != "" || c.AddConnectionSecret || len(c.Set) > 0 || len(c.Merge) > 0 || len(c.JSONPatch) > 0
 }
 
 // DefaultValuesFromXRD sets default values on the XR based on the XRD schema.
@@ -128,3 +129,58 @@ func AddConnectionSecret(xr *unstructured.Unstructured, connectionSecretName, co
 
 	return nil
 }
+
+// SetField sets the value at the given Crossplane fieldpath (e.g. "spec.parameters.tags[0]") on the XR.
+func SetField(xr *unstructured.Unstructured, path string, value any) error {
+	xrPaved, err := fieldpath.PaveObject(xr)
+	if err != nil {
+		return errors.Wrap(err, "failed to pave XR object")
+	}
+
+	if err := xrPaved.SetValue(path, value); err != nil {
+		return errors.Wrapf(err, "failed to set %s", path)
+	}
+
+	xr.Object = xrPaved.UnstructuredContent()
+
+	return nil
+}
+
+// MergePatch applies an RFC 7386 JSON merge patch to the XR.
+func MergePatch(xr *unstructured.Unstructured, patch []byte) error {
+	return applyJSONPatchFunc(xr, func(doc []byte) ([]byte, error) {
+		return jsonpatch.MergePatch(doc, patch)
+	})
+}
+
+// JSONPatch applies an RFC 6902 JSON patch (a list of operations) to the XR.
+func JSONPatch(xr *unstructured.Unstructured, patch []byte) error {
+	ops, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode JSON patch")
+	}
+
+	return applyJSONPatchFunc(xr, ops.Apply)
+}
+
+// applyJSONPatchFunc marshals the XR to JSON, applies the given patch function and stores the result back in the XR.
+func applyJSONPatchFunc(xr *unstructured.Unstructured, apply func(doc []byte) ([]byte, error)) error {
+	doc, err := json.Marshal(xr.Object)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal XR to JSON")
+	}
+
+	patched, err := apply(doc)
+	if err != nil {
+		return errors.Wrap(err, "failed to apply patch")
+	}
+
+	object := map[string]any{}
+	if err := json.Unmarshal(patched, &object); err != nil {
+		return errors.Wrap(err, "failed to unmarshal patched XR")
+	}
+
+	xr.Object = object
+
+	return nil
+}
@@ -89,6 +89,9 @@ func TestHasPatchingFlags(t *testing.T) {
 		addConnectionSecret       bool
 		connectionSecretName      string
 		connectionSecretNamespace string
+		set                       []string
+		merge                     []string
+		jsonPatch                 []string
 		want                      bool
 	}{
 		{
@@ -116,6 +119,21 @@ func TestHasPatchingFlags(t *testing.T) {
 			addConnectionSecret: true,
 			want:                true,
 		},
+		{
+			name: "set flag only",
+			set:  []string{"spec.title=foo"},
+			want: true,
+		},
+		{
+			name:  "merge flag only",
+			merge: []string{"merge.yaml"},
+			want:  true,
+		},
+		{
+			name:      "jsonpatch flag only",
+			jsonPatch: []string{"jsonpatch.yaml"},
+			want:      true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -125,6 +143,9 @@ func TestHasPatchingFlags(t *testing.T) {
 				AddConnectionSecret:       tt.addConnectionSecret,
 				ConnectionSecretName:      tt.connectionSecretName,
 				ConnectionSecretNamespace: tt.connectionSecretNamespace,
+				Set:                       tt.set,
+				Merge:                     tt.merge,
+				JSONPatch:                 tt.jsonPatch,
 			}
 			if got := c.hasPatchingFlags(); got != tt.want {
 				t.Errorf("Cmd.hasPatchingFlags() = %v, want %v", got, tt.want)
@@ -561,3 +582,246 @@ func TestAddConnectionSecret_UUIDGeneration(t *testing.T) {
 		}
 	})
 }
+
+func TestSetField(t *testing.T) {
+	type args struct {
+		path  string
+		value any
+	}
+
+	type want struct {
+		err  bool
+		spec map[string]any
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"SetScalar": {
+			reason: "Should set a scalar field",
+			args:   args{path: "spec.title", value: "my title"},
+			want:   want{spec: map[string]any{"title": "my title"}},
+		},
+		"SetNestedCreatesParents": {
+			reason: "Should create intermediate objects and arrays when setting a nested field",
+			args:   args{path: "spec.config.tags[0]", value: "team-a"},
+			want: want{spec: map[string]any{
+				"config": map[string]any{"tags": []any{"team-a"}},
+			}},
+		},
+		"SetObject": {
+			reason: "Should set an object value",
+			args:   args{path: "spec.config", value: map[string]any{"timeout": 10}},
+			want: want{spec: map[string]any{
+				"config": map[string]any{"timeout": int64(10)},
+			}},
+		},
+		"InvalidPath": {
+			reason: "Should return an error for an invalid fieldpath",
+			args:   args{path: "spec[", value: "x"},
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			xr := testXR.DeepCopy()
+
+			err := SetField(xr, tc.args.path, tc.args.value)
+			if tc.want.err {
+				if err == nil {
+					t.Errorf("\n%s\nSetField(...): expected error, got nil", tc.reason)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("\n%s\nSetField(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want.spec, xr.Object["spec"]); diff != "" {
+				t.Errorf("\n%s\nSetField(...): -want spec, +got spec:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		patch  string
+		want   map[string]any
+		err    bool
+	}{
+		"AddAndRemoveFields": {
+			reason: "Should merge new fields and remove fields set to null",
+			patch:  `{"metadata":{"labels":{"team":"a"}},"spec":{"title":"merged"},"kind":null}`,
+			want: map[string]any{
+				"apiVersion": "example.org/v1alpha1",
+				"metadata": map[string]any{
+					"name":   "test-app",
+					"labels": map[string]any{"team": "a"},
+				},
+				"spec": map[string]any{"title": "merged"},
+			},
+		},
+		"InvalidPatch": {
+			reason: "Should return an error for a patch that is not JSON",
+			patch:  `not json`,
+			err:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			xr := testXR.DeepCopy()
+
+			err := MergePatch(xr, []byte(tc.patch))
+			if tc.err {
+				if err == nil {
+					t.Errorf("\n%s\nMergePatch(...): expected error, got nil", tc.reason)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("\n%s\nMergePatch(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want, xr.Object); diff != "" {
+				t.Errorf("\n%s\nMergePatch(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		patch  string
+		want   map[string]any
+		err    string
+	}{
+		"AddReplaceRemove": {
+			reason: "Should apply add, replace and remove operations in order",
+			patch: `[
+				{"op":"add","path":"/spec/title","value":"added"},
+				{"op":"replace","path":"/spec/title","value":"replaced"},
+				{"op":"add","path":"/metadata/labels","value":{"team":"a"}},
+				{"op":"remove","path":"/metadata/labels/team"}
+			]`,
+			want: map[string]any{
+				"apiVersion": "example.org/v1alpha1",
+				"kind":       "XTestApp",
+				"metadata": map[string]any{
+					"name":   "test-app",
+					"labels": map[string]any{},
+				},
+				"spec": map[string]any{"title": "replaced"},
+			},
+		},
+		"FailedTest": {
+			reason: "Should return an error when a test operation does not match",
+			patch:  `[{"op":"test","path":"/kind","value":"Other"}]`,
+			err:    "failed to apply patch",
+		},
+		"InvalidPatch": {
+			reason: "Should return an error when the patch is not a list of operations",
+			patch:  `{"op":"add"}`,
+			err:    "failed to decode JSON patch",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			xr := testXR.DeepCopy()
+
+			err := JSONPatch(xr, []byte(tc.patch))
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("\n%s\nJSONPatch(...): expected error containing %q, got %v", tc.reason, tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("\n%s\nJSONPatch(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want, xr.Object); diff != "" {
+				t.Errorf("\n%s\nJSONPatch(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestApplyFieldPatches(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/merge.yaml", []byte("spec:\n  config:\n    environment: staging\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := afero.WriteFile(fs, "/jsonpatch.yaml", []byte("- op: replace\n  path: /spec/replicas\n  value: 5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		reason string
+		cmd    Cmd
+		want   map[string]any
+		err    string
+	}{
+		"SetMergeAndJSONPatch": {
+			reason: "Should apply sets, then merge patches, then JSON patches",
+			cmd: Cmd{
+				Set:       []string{"spec.replicas=2", "spec.title=a=b"},
+				Merge:     []string{"/merge.yaml"},
+				JSONPatch: []string{"/jsonpatch.yaml"},
+			},
+			want: map[string]any{
+				"replicas": float64(5),
+				"title":    "a=b",
+				"config":   map[string]any{"environment": "staging"},
+			},
+		},
+		"InvalidSet": {
+			reason: "Should return an error when --set is not in PATH=VALUE format",
+			cmd:    Cmd{Set: []string{"spec.replicas"}},
+			err:    "must be in PATH=VALUE format",
+		},
+		"MissingPatchFile": {
+			reason: "Should return an error when a patch file does not exist",
+			cmd:    Cmd{Merge: []string{"/missing.yaml"}},
+			err:    "failed to read patch file /missing.yaml",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.cmd.fs = fs
+			xr := testXR.DeepCopy()
+
+			err := tc.cmd.applyFieldPatches(xr)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("\n%s\napplyFieldPatches(...): expected error containing %q, got %v", tc.reason, tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("\n%s\napplyFieldPatches(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want, xr.Object["spec"]); diff != "" {
+				t.Errorf("\n%s\napplyFieldPatches(...): -want spec, +got spec:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint provides the lint subcommand for the xprin tool.
+package lint
+
+import (
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/processor"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+)
+
+// Cmd represents the lint subcommand.
+type Cmd struct {
+	Targets []string            `arg:""                                                                                                 help:"One or more test targets: individual files (e.g., 'tests/aws_xprin.yaml'), directories (e.g., 'tests/aws/'), or recursive directories (e.g., 'tests/aws/...'). Files must be named 'xprin.yaml' or '*_xprin.yaml'"`
+	Vars    map[string]string   `help:"Set a template variable available as .Vars.KEY, overriding the testsuite vars. Can be repeated." name:"var"                                                                                                                                                                                                               placeholder:"KEY=VALUE"`
+	Debug   bool                `help:"Show detailed debug information about test discovery"`
+	Config  *internalcfg.Config `kong:"-"`
+	fs      afero.Fs
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *Cmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// Run executes the lint subcommand.
+func (c *Cmd) Run(_ *kong.Context) error {
+	return processor.LintTargets(c.fs, c.Targets, c.newOptions(c.Config))
+}
+
+// newOptions creates a testexecutionUtils.Options struct from a Command and Config.
+func (c *Cmd) newOptions(cfg *internalcfg.Config) *testexecutionUtils.Options {
+	return &testexecutionUtils.Options{
+		Dependencies: cfg.Dependencies,
+		Repositories: cfg.Repositories,
+		Vars:         c.Vars,
+		Debug:        c.Debug,
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"testing"
+
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/stretchr/testify/assert" //nolint:depguard // testify is widely used for testing
+)
+
+func TestNewOptions(t *testing.T) {
+	cfg := &internalcfg.Config{
+		Dependencies: map[string]string{"crossplane": "custom-crossplane-path"},
+		Repositories: map[string]string{"repo1": "path1"},
+	}
+
+	cmd := &Cmd{
+		Vars:  map[string]string{"region": "eu-west-1"},
+		Debug: true,
+	}
+
+	options := cmd.newOptions(cfg)
+
+	assert.Equal(t, cfg.Dependencies, options.Dependencies)
+	assert.Equal(t, cfg.Repositories, options.Repositories)
+	assert.Equal(t, cmd.Vars, options.Vars)
+	assert.True(t, options.Debug)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package list provides the list subcommand for the xprin tool.
+package list
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/processor"
+	"github.com/spf13/afero"
+)
+
+// Cmd represents the list subcommand.
+type Cmd struct {
+	Targets []string `arg:""                                                      help:"One or more test targets: individual files (e.g., 'tests/aws_xprin.yaml'), directories (e.g., 'tests/aws/'), or recursive directories (e.g., 'tests/aws/...'). Files must be named 'xprin.yaml' or '*_xprin.yaml'"`
+	Output  string   `default:"text"                                              enum:"text,json"                                                                                                                                                                                                         help:"Output format: text or json (default text)." name:"output" short:"o"`
+	Debug   bool     `help:"Show detailed debug information about test discovery"`
+	fs      afero.Fs
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *Cmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// Run executes the list subcommand.
+func (c *Cmd) Run(_ *kong.Context) error {
+	listings, err := processor.ListTargets(c.fs, c.Targets, c.Debug)
+	if err != nil {
+		return err
+	}
+
+	if err := processor.PrintListings(os.Stdout, listings, c.Output); err != nil {
+		return err
+	}
+
+	var invalid int
+
+	for _, listing := range listings {
+		if listing.Error != "" {
+			invalid++
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d testsuite files cannot be loaded", invalid, len(listings))
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lsp provides the lsp subcommand for the xprin tool, which runs a language server for testsuite files over
+// stdio.
+package lsp
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/lsp"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+)
+
+// Cmd represents the lsp subcommand.
+type Cmd struct {
+	Render bool                `default:"true"                                                                                         help:"Render the test cases of the open testsuite files when they are opened or saved, to complete the resources of assertions." negatable:""`
+	Vars   map[string]string   `help:"Set a template variable available as .Vars.KEY, overriding the testsuite vars. Can be repeated." name:"var"                                                                                                                       placeholder:"KEY=VALUE"`
+	Debug  bool                `help:"Show detailed debug information about the renders on stderr"`
+	Config *internalcfg.Config `kong:"-"`
+}
+
+// Run executes the lsp subcommand.
+func (c *Cmd) Run(_ *kong.Context) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return lsp.NewServer(c.newOptions(c.Config), c.Render).Serve(ctx, os.Stdin, os.Stdout)
+}
+
+// newOptions creates a testexecutionUtils.Options struct from a Command and Config.
+func (c *Cmd) newOptions(cfg *internalcfg.Config) *testexecutionUtils.Options {
+	var render []string
+
+	if cfg.Subcommands != nil {
+		render = strings.Fields(cfg.Subcommands.Render)
+	}
+
+	return &testexecutionUtils.Options{
+		Dependencies: cfg.Dependencies,
+		Repositories: cfg.Repositories,
+		Vars:         c.Vars,
+		Debug:        c.Debug,
+		Render:       render,
+	}
+}
@@ -21,21 +21,42 @@ import (
 	"errors"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/alecthomas/kong"
 	checkCmd "github.com/crossplane-contrib/xprin/cmd/xprin/check"
 	configCmd "github.com/crossplane-contrib/xprin/cmd/xprin/config"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/lint"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/list"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/lsp"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/render"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/scaffold"
+	"github.com/crossplane-contrib/xprin/cmd/xprin/schema"
 	"github.com/crossplane-contrib/xprin/cmd/xprin/test"
 	"github.com/crossplane-contrib/xprin/cmd/xprin/version"
 	internalConfig "github.com/crossplane-contrib/xprin/internal/config"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 	"github.com/spf13/afero"
 )
 
+// Exit codes, so that CI can tell broken compositions (failed tests) apart from a broken environment (tests that could
+// not be run). Usage errors exit with kong's exit code 80.
+const (
+	exitCodeTestsFailed = 1
+	exitCodeError       = 2
+)
+
 // CLI represents the command-line interface.
 type CLI struct {
-	ConfigFile string        `default:"~/.config/xprin.yaml" help:"Path to xprin config file"            short:"c" type:"path"`
+	ConfigFile string        `default:"~/.config/xprin.yaml" help:"Path to xprin config file"                                          short:"c" type:"path"`
 	Check      checkCmd.Cmd  `cmd:""                         help:"Check dependencies and configuration"`
 	Config     configCmd.Cmd `cmd:""                         help:"Manage xprin configuration"`
+	Init       scaffold.Cmd  `cmd:""                         help:"Generate a starter testsuite file for a Composition and its XRD"`
+	Lint       lint.Cmd      `cmd:""                         help:"Check testsuite files without running them"`
+	List       list.Cmd      `cmd:""                         help:"List testsuite files and their test cases without running them"`
+	LSP        lsp.Cmd       `cmd:""                         help:"Run a language server for testsuite files over stdio"`
+	Render     render.Cmd    `cmd:""                         help:"Render a single test case without running its hooks and assertions"`
+	Schema     schema.Cmd    `cmd:""                         help:"Print the JSON schema of testsuite files"`
 	Test       test.Cmd      `cmd:""                         help:"Run Crossplane tests"`
 	Version    version.Cmd   `cmd:""                         help:"Print the version of xprin"`
 }
@@ -57,12 +78,14 @@ func main() {
 		if errors.Is(err, os.ErrNotExist) {
 			configPath = ""
 
+			// Only the commands that run crossplane need it in PATH
 			cfg, err = internalConfig.Fallback()
-			if err != nil {
-				log.Fatalf("%v", err)
+			if err != nil && runsCrossplane(ctx.Command()) {
+				fatal(err)
 			}
 		} else {
-			log.Fatalf("Failed to load configuration: %v", err)
+			log.Printf("Failed to load configuration: %v", err)
+			os.Exit(exitCodeError)
 		}
 	}
 
@@ -71,11 +94,43 @@ func main() {
 	cli.Check.ConfigPath = configPath
 	cli.Config.Config = cfg
 	cli.Config.ConfigPath = configPath
+	cli.Init.Config = cfg
+	cli.Lint.Config = cfg
+	cli.LSP.Config = cfg
+	cli.Render.Config = cfg
 	cli.Test.Config = cfg
 
 	// Run the selected command
 	err = ctx.Run()
 	if err != nil {
-		log.Fatalf("%v", err)
+		fatal(err)
 	}
 }
+
+// runsCrossplane returns true if a command runs crossplane, i.e. renders test cases.
+func runsCrossplane(command string) bool {
+	name, _, _ := strings.Cut(command, " ")
+
+	switch name {
+	case "test", "render", "init":
+		return true
+	default:
+		return false
+	}
+}
+
+// fatal logs err and exits with exitCodeTestsFailed if tests failed or lint found problems, or exitCodeError if xprin
+// could not run.
+func fatal(err error) {
+	log.Printf("%v", err)
+
+	if errors.Is(err, testexecutionUtils.ErrTestsFailed) && !errors.Is(err, testexecutionUtils.ErrTestsErrored) {
+		os.Exit(exitCodeTestsFailed)
+	}
+
+	if errors.Is(err, testexecutionUtils.ErrLintFailed) {
+		os.Exit(exitCodeTestsFailed)
+	}
+
+	os.Exit(exitCodeError)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+// runXprinEnv makes the test binary run main with the arguments after "--", so that xprin can be run as a process.
+const runXprinEnv = "XPRIN_TEST_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runXprinEnv) != "" {
+		for i, arg := range os.Args {
+			if arg == "--" {
+				os.Args = append([]string{"xprin"}, os.Args[i+1:]...)
+				break
+			}
+		}
+
+		main()
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// runXprin runs xprin with args, without a config file and with an empty PATH, and returns its output and exit code.
+func runXprin(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], append([]string{"-test.run=^$", "--"}, args...)...) //nolint:gosec // runs the test binary itself
+	cmd.Env = []string{runXprinEnv + "=1", "PATH=", "HOME=" + t.TempDir()}
+
+	var output bytes.Buffer
+
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if err != nil {
+		require.ErrorAs(t, err, &exitErr)
+		return output.String(), exitErr.ExitCode()
+	}
+
+	return output.String(), 0
+}
+
+func TestRunsCrossplane(t *testing.T) {
+	t.Run("schema without crossplane in PATH", func(t *testing.T) {
+		output, code := runXprin(t, "schema")
+		assert.Equal(t, 0, code, output)
+		assert.Contains(t, output, `"$schema"`)
+	})
+
+	t.Run("test without crossplane in PATH", func(t *testing.T) {
+		output, code := runXprin(t, "test", ".")
+		assert.Equal(t, exitCodeError, code, output)
+		assert.Contains(t, output, "missing required dependencies from PATH (crossplane)")
+	})
+}
+
+func TestInvalidShardIsUsageError(t *testing.T) {
+	output, code := runXprin(t, "test", "--shard-index", "3", "--shard-total", "3", ".")
+	assert.Equal(t, 80, code, output)
+	assert.Contains(t, output, "invalid shard 3 of 3")
+}
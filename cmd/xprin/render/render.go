@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render provides the render subcommand for the xprin tool, which renders a single test case without running
+// its hooks and assertions.
+package render
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/processor"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+)
+
+// Cmd represents the render subcommand.
+type Cmd struct {
+	Target       string              `arg:""                                                                                                                                           help:"The testsuite file of the test case." type:"existingfile"`
+	Test         string              `help:"ID or name of the test case to render."                                                                                                    placeholder:"ID|NAME"                       required:""             short:"t"`
+	OutputDir    string              `help:"Write the inputs and the rendered resources (rendered.yaml) to this directory instead of printing the rendered resources."                 placeholder:"DIR"                           short:"o"               type:"path"`
+	PrintCommand bool                `help:"Print the equivalent shell commands instead of rendering. The inputs they use are kept in --output-dir, or else in a temporary directory."`
+	Vars         map[string]string   `help:"Set a template variable available as .Vars.KEY, overriding the testsuite vars. Can be repeated."                                           name:"var"                                  placeholder:"KEY=VALUE"`
+	Debug        bool                `help:"Show detailed debug information about the preparation of the inputs"`
+	Config       *internalcfg.Config `kong:"-"`
+	fs           afero.Fs
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *Cmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// Run executes the render subcommand.
+func (c *Cmd) Run(_ *kong.Context) error {
+	testRunner, err := processor.NewTestSuiteRunner(c.fs, c.Target, c.newOptions(c.Config))
+	if err != nil {
+		return err
+	}
+
+	dir := c.OutputDir
+	if dir == "" {
+		dir, err = afero.TempDir(c.fs, "", "xprin-render-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+
+		if !c.PrintCommand {
+			defer func() { _ = c.fs.RemoveAll(dir) }()
+		}
+	}
+
+	prepared, err := testRunner.PrepareRender(c.Test, dir)
+	if err != nil {
+		return err
+	}
+
+	if c.PrintCommand {
+		utils.OutputPrintf("%s", shellScript(prepared))
+		return nil
+	}
+
+	output, err := testRunner.RunRender(context.Background(), prepared)
+	if err != nil {
+		return err
+	}
+
+	if c.OutputDir == "" {
+		utils.OutputPrintf("%s", output)
+		return nil
+	}
+
+	renderedFile := filepath.Join(c.OutputDir, "rendered.yaml")
+	if err := afero.WriteFile(c.fs, renderedFile, output, 0o600); err != nil {
+		return fmt.Errorf("failed to write rendered resources: %w", err)
+	}
+
+	utils.OutputPrintf("Wrote %s\n", renderedFile)
+
+	return nil
+}
+
+// shellScript returns the shell commands of a prepared render, each after a comment saying what it does.
+func shellScript(prepared *runner.PreparedRender) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Test case: %s\n", prepared.TestCase.Name)
+	fmt.Fprintf(&b, "# Inputs: %s\n", prepared.InputsDir)
+
+	for _, command := range prepared.Commands {
+		fmt.Fprintf(&b, "\n# %s\n%s\n", command.Description, command.ShellCommand())
+	}
+
+	return b.String()
+}
+
+// newOptions creates a testexecutionUtils.Options struct from a Command and Config.
+func (c *Cmd) newOptions(cfg *internalcfg.Config) *testexecutionUtils.Options {
+	var render []string
+
+	if cfg.Subcommands != nil {
+		render = strings.Fields(cfg.Subcommands.Render)
+	}
+
+	return &testexecutionUtils.Options{
+		Dependencies: cfg.Dependencies,
+		Repositories: cfg.Repositories,
+		Vars:         c.Vars,
+		Debug:        c.Debug,
+		Render:       render,
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	"github.com/stretchr/testify/assert" //nolint:depguard // testify is widely used for testing
+)
+
+func TestNewOptions(t *testing.T) {
+	cfg := &internalcfg.Config{
+		Dependencies: map[string]string{"crossplane": "custom-crossplane-path"},
+		Repositories: map[string]string{"repo1": "path1"},
+		Subcommands:  &internalcfg.Subcommands{Render: "render --include-full-xr"},
+	}
+
+	cmd := &Cmd{
+		Vars:  map[string]string{"region": "eu-west-1"},
+		Debug: true,
+	}
+
+	options := cmd.newOptions(cfg)
+
+	assert.Equal(t, cfg.Dependencies, options.Dependencies)
+	assert.Equal(t, cfg.Repositories, options.Repositories)
+	assert.Equal(t, cmd.Vars, options.Vars)
+	assert.Equal(t, []string{"render", "--include-full-xr"}, options.Render)
+	assert.True(t, options.Debug)
+}
+
+func TestShellScript(t *testing.T) {
+	prepared := &runner.PreparedRender{
+		InputsDir: "/tmp/render/inputs",
+		Commands: []runner.RenderCommand{
+			{Description: "Convert the Claim to an XR", Args: []string{"xprin-helpers", "convert-claim-to-xr", "/tmp/render/inputs/claim/claim.yaml", "-o", "/tmp/render/inputs/xr.yaml"}},
+			{Description: "Render the test case", Args: []string{"crossplane", "render", "/tmp/render/inputs/xr.yaml", "/tmp/render/inputs/composition/my composition.yaml"}},
+		},
+	}
+	prepared.TestCase.Name = "Render the bucket"
+
+	assert.Equal(t, `# Test case: Render the bucket
+# Inputs: /tmp/render/inputs
+
+# Convert the Claim to an XR
+xprin-helpers convert-claim-to-xr /tmp/render/inputs/claim/claim.yaml -o /tmp/render/inputs/xr.yaml
+
+# Render the test case
+crossplane render /tmp/render/inputs/xr.yaml '/tmp/render/inputs/composition/my composition.yaml'
+`, shellScript(prepared))
+}
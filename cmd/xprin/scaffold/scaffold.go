@@ -0,0 +1,330 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scaffold provides the init subcommand for the xprin tool, which generates a starter testsuite file.
+package scaffold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane-contrib/xprin/internal/api"
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/processor"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/crossplane-contrib/xprin/internal/xrgen"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// goldenDir is the directory of the golden files, relative to the testsuite file.
+const goldenDir = "golden"
+
+// Mockable functions
+//
+//nolint:gochecknoglobals // Global variables for dependency injection in tests
+var processTargetsFunc = processor.ProcessTargets
+
+// Cmd represents the init subcommand.
+type Cmd struct {
+	Composition string              `help:"Path to the Composition to test."                                                                                  placeholder:"PATH"                                                                    required:""        type:"existingfile"`
+	XRD         string              `help:"Path to the XRD of the Composition, used to build the example XR."                                                 name:"xrd"                                                                            placeholder:"PATH" required:""         type:"existingfile"`
+	Functions   string              `help:"Path to the functions file or directory of the Composition."                                                       placeholder:"PATH"                                                                    required:""        type:"path"`
+	Dir         string              `default:"."                                                                                                              help:"Directory to write the testsuite file, the example XR and the golden files to." placeholder:"DIR"  type:"path"`
+	Golden      bool                `help:"Run the test case once and write the rendered resources as dyff golden files, with an assertion for each of them."`
+	Force       bool                `help:"Overwrite the files that already exist."`
+	Debug       bool                `help:"Show detailed debug information when running the test case with --golden"`
+	Config      *internalcfg.Config `kong:"-"`
+	fs          afero.Fs
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *Cmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// goldenFile is a golden file of the starter testsuite, with the resource it is compared to (empty for the whole render).
+type goldenFile struct {
+	Path     string // Relative to the testsuite file
+	Resource string
+	Content  []byte
+}
+
+// Run executes the init subcommand.
+func (c *Cmd) Run(_ *kong.Context) error {
+	xrdData, err := afero.ReadFile(c.fs, c.XRD)
+	if err != nil {
+		return fmt.Errorf("failed to read XRD file: %w", err)
+	}
+
+	xrd, err := xrgen.ParseXRD(xrdData)
+	if err != nil {
+		return err
+	}
+
+	xr, err := xrgen.ExampleXR(xrd)
+	if err != nil {
+		return err
+	}
+
+	name := strings.ToLower(xr.GetKind())
+	testSuiteFile := filepath.Join(c.Dir, name+"_xprin.yaml")
+	xrFile := filepath.Join(c.Dir, name+"-xr.yaml")
+
+	for _, file := range []string{testSuiteFile, xrFile} {
+		if err := c.checkNotExists(file); err != nil {
+			return err
+		}
+	}
+
+	xrData, err := yaml.Marshal(xr.Object)
+	if err != nil {
+		return fmt.Errorf("failed to marshal example XR: %w", err)
+	}
+
+	if err := c.fs.MkdirAll(c.Dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", c.Dir, err)
+	}
+
+	if err := afero.WriteFile(c.fs, xrFile, xrData, 0o600); err != nil {
+		return fmt.Errorf("failed to write example XR: %w", err)
+	}
+
+	utils.OutputPrintf("Created %s\n", xrFile)
+
+	inputs := [][2]string{
+		{"xr", filepath.Base(xrFile)},
+		{"composition", relativeTo(c.Dir, c.Composition)},
+		{"functions", relativeTo(c.Dir, c.Functions)},
+	}
+
+	if err := c.writeTestSuite(testSuiteFile, xr.GetKind(), inputs, nil); err != nil {
+		return err
+	}
+
+	if c.Golden {
+		goldenFiles, err := c.renderGoldenFiles(testSuiteFile)
+		if err != nil {
+			return err
+		}
+
+		for _, golden := range goldenFiles {
+			path := filepath.Join(c.Dir, golden.Path)
+
+			if err := c.checkNotExists(path); err != nil {
+				return err
+			}
+
+			if err := c.fs.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+				return fmt.Errorf("failed to create golden files directory: %w", err)
+			}
+
+			if err := afero.WriteFile(c.fs, path, golden.Content, 0o600); err != nil {
+				return fmt.Errorf("failed to write golden file: %w", err)
+			}
+
+			utils.OutputPrintf("Created %s\n", path)
+		}
+
+		if err := c.writeTestSuite(testSuiteFile, xr.GetKind(), inputs, goldenFiles); err != nil {
+			return err
+		}
+	}
+
+	utils.OutputPrintf("Created %s\n\nRun it with:\n    xprin test %s\n", testSuiteFile, testSuiteFile)
+
+	return nil
+}
+
+// checkNotExists returns an error if a file exists and --force is not set.
+func (c *Cmd) checkNotExists(file string) error {
+	if c.Force {
+		return nil
+	}
+
+	exists, err := afero.Exists(c.fs, file)
+	if err != nil {
+		return fmt.Errorf("failed to check %s: %w", file, err)
+	}
+
+	if exists {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", file)
+	}
+
+	return nil
+}
+
+// writeTestSuite writes the starter testsuite file: a render-only test case of the example XR, with a dyff assertion
+// for each golden file.
+func (c *Cmd) writeTestSuite(file, kind string, inputs [][2]string, goldenFiles []goldenFile) error {
+	var b strings.Builder
+
+	b.WriteString("# Starter testsuite generated by xprin init.\n")
+	b.WriteString("# See https://github.com/crossplane-contrib/xprin/blob/main/docs/testsuite-specification.md\n")
+	fmt.Fprintf(&b, "apiVersion: %s\nkind: %s\n", api.APIVersion, api.Kind)
+	b.WriteString("tests:\n")
+	fmt.Fprintf(&b, "- name: %s\n", scalar("Render "+kind))
+	b.WriteString("  id: render\n")
+	b.WriteString("  inputs:\n")
+
+	for _, input := range inputs {
+		fmt.Fprintf(&b, "    %s: %s\n", input[0], scalar(input[1]))
+	}
+
+	if len(goldenFiles) > 0 {
+		b.WriteString("  assertions:\n")
+		b.WriteString("    dyff:\n")
+
+		for _, golden := range goldenFiles {
+			description := "Rendered resources match the golden file"
+			if golden.Resource != "" {
+				description = golden.Resource + " matches the golden file"
+			}
+
+			fmt.Fprintf(&b, "    - name: %s\n", scalar(description))
+			fmt.Fprintf(&b, "      expected: %s\n", scalar(golden.Path))
+
+			if golden.Resource != "" {
+				fmt.Fprintf(&b, "      resource: %s\n", scalar(golden.Resource))
+			}
+		}
+	}
+
+	if err := afero.WriteFile(c.fs, file, []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write testsuite file: %w", err)
+	}
+
+	return nil
+}
+
+// renderGoldenFiles runs the test case of the starter testsuite file and returns the golden files of its rendered
+// resources.
+func (c *Cmd) renderGoldenFiles(testSuiteFile string) ([]goldenFile, error) {
+	options := c.newOptions(c.Config)
+
+	utils.OutputPrintf("Running %s to write the golden files\n", testSuiteFile)
+
+	if err := processTargetsFunc(context.Background(), c.fs, []string{testSuiteFile}, options); err != nil {
+		return nil, fmt.Errorf("failed to run the test case to write the golden files: %w", err)
+	}
+
+	if len(options.Summary.TestSuites) == 0 || len(options.Summary.TestSuites[0].Results) == 0 {
+		return nil, errors.New("failed to run the test case to write the golden files: no results")
+	}
+
+	return goldenFiles(&options.Summary.TestSuites[0].Results[0])
+}
+
+// goldenFiles returns a golden file for each rendered resource of a test case, or a single one for the whole render
+// if some resources have no name or the same Kind/name.
+func goldenFiles(result *engine.TestCaseResult) ([]goldenFile, error) {
+	files := make([]goldenFile, 0, len(result.RenderedResources))
+	seen := make(map[string]bool)
+
+	for _, resource := range result.RenderedResources {
+		key := resource.GetKind() + "/" + resource.GetName()
+		if resource.GetName() == "" || seen[key] {
+			return []goldenFile{{Path: goldenDir + "/rendered.yaml", Content: result.RawRenderOutput}}, nil
+		}
+
+		seen[key] = true
+
+		content, err := marshalResource(resource)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, goldenFile{
+			Path:     fmt.Sprintf("%s/%s-%s.yaml", goldenDir, strings.ToLower(resource.GetKind()), resource.GetName()),
+			Resource: key,
+			Content:  content,
+		})
+	}
+
+	return files, nil
+}
+
+// marshalResource returns the YAML of a rendered resource.
+func marshalResource(resource *unstructured.Unstructured) ([]byte, error) {
+	data, err := yaml.Marshal(resource.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rendered resource %s/%s: %w", resource.GetKind(), resource.GetName(), err)
+	}
+
+	return data, nil
+}
+
+// newOptions creates the testexecutionUtils.Options to run the starter testsuite file from a Command and Config.
+func (c *Cmd) newOptions(cfg *internalcfg.Config) *testexecutionUtils.Options {
+	var render, validate []string
+
+	if cfg.Subcommands != nil {
+		render = strings.Fields(cfg.Subcommands.Render)
+		validate = strings.Fields(cfg.Subcommands.Validate)
+	}
+
+	return &testexecutionUtils.Options{
+		Dependencies: cfg.Dependencies,
+		Repositories: cfg.Repositories,
+		Debug:        c.Debug,
+		Render:       render,
+		Validate:     validate,
+		Summary:      engine.NewRunSummary(),
+	}
+}
+
+// relativeTo returns a path relative to a directory when the path is in the working directory, so that the testsuite
+// file can be moved with the repository, or else the absolute path.
+func relativeTo(dir, path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	if rel, err := filepath.Rel(".", path); err != nil || strings.HasPrefix(filepath.ToSlash(rel), "../") || rel == ".." {
+		return absPath
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return absPath
+	}
+
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil {
+		return absPath
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// scalar returns a string as a YAML scalar, quoted if needed.
+func scalar(s string) string {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Sprintf("%q", s)
+	}
+
+	return strings.TrimSuffix(string(data), "\n")
+}
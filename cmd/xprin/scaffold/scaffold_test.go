@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"context"
+	"testing"
+
+	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const testXRD = `apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: xnetworks.example.com
+spec:
+  group: example.com
+  names:
+    kind: XNetwork
+    plural: xnetworks
+  versions:
+  - name: v1
+    served: true
+    referenceable: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [region]
+            properties:
+              region:
+                type: string
+`
+
+const testTestSuite = `# Starter testsuite generated by xprin init.
+# See https://github.com/crossplane-contrib/xprin/blob/main/docs/testsuite-specification.md
+apiVersion: xprin.crossplane.io/v1alpha1
+kind: TestSuite
+tests:
+- name: Render XNetwork
+  id: render
+  inputs:
+    xr: xnetwork-xr.yaml
+    composition: /apis/composition.yaml
+    functions: /apis/functions.yaml
+`
+
+func newTestCmd(t *testing.T) *Cmd {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/apis/xrd.yaml", []byte(testXRD), 0o644))
+
+	return &Cmd{
+		Composition: "/apis/composition.yaml",
+		XRD:         "/apis/xrd.yaml",
+		Functions:   "/apis/functions.yaml",
+		Dir:         "/tests",
+		Config:      &internalcfg.Config{},
+		fs:          fs,
+	}
+}
+
+func TestCmd_Run(t *testing.T) {
+	cmd := newTestCmd(t)
+	require.NoError(t, cmd.Run(nil))
+
+	xr, err := afero.ReadFile(cmd.fs, "/tests/xnetwork-xr.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: example.com/v1
+kind: XNetwork
+metadata:
+  name: example-xnetwork
+spec:
+  region: example
+`, string(xr))
+
+	testSuite, err := afero.ReadFile(cmd.fs, "/tests/xnetwork_xprin.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, testTestSuite, string(testSuite))
+
+	// The files are not overwritten without --force
+	err = cmd.Run(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "/tests/xnetwork_xprin.yaml already exists, use --force to overwrite it")
+
+	cmd.Force = true
+	require.NoError(t, cmd.Run(nil))
+}
+
+func TestCmd_Run_Golden(t *testing.T) {
+	original := processTargetsFunc
+	defer func() { processTargetsFunc = original }()
+
+	processTargetsFunc = func(_ context.Context, _ afero.Fs, targets []string, options *testexecutionUtils.Options) error {
+		assert.Equal(t, []string{"/tests/xnetwork_xprin.yaml"}, targets)
+
+		result := engine.NewTestCaseResult("Render XNetwork", "render", false, false, false, false, false)
+		result.RenderedResources = []*unstructured.Unstructured{
+			{Object: map[string]any{"apiVersion": "example.com/v1", "kind": "XNetwork", "metadata": map[string]any{"name": "example-xnetwork"}}},
+			{Object: map[string]any{"apiVersion": "v1", "kind": "ConfigMap", "metadata": map[string]any{"name": "settings"}}},
+		}
+
+		suite := engine.NewTestSuiteResult(targets[0], false)
+		suite.AddResult(result.Complete())
+		options.Summary.AddTestSuite(suite.Complete())
+
+		return nil
+	}
+
+	cmd := newTestCmd(t)
+	cmd.Golden = true
+	require.NoError(t, cmd.Run(nil))
+
+	testSuite, err := afero.ReadFile(cmd.fs, "/tests/xnetwork_xprin.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, testTestSuite+`  assertions:
+    dyff:
+    - name: XNetwork/example-xnetwork matches the golden file
+      expected: golden/xnetwork-example-xnetwork.yaml
+      resource: XNetwork/example-xnetwork
+    - name: ConfigMap/settings matches the golden file
+      expected: golden/configmap-settings.yaml
+      resource: ConfigMap/settings
+`, string(testSuite))
+
+	golden, err := afero.ReadFile(cmd.fs, "/tests/golden/configmap-settings.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n", string(golden))
+}
+
+func TestGoldenFiles_UnnamedResource(t *testing.T) {
+	result := &engine.TestCaseResult{
+		RawRenderOutput: []byte("---\nkind: XNetwork\n---\nkind: ConfigMap\n"),
+		RenderedResources: []*unstructured.Unstructured{
+			{Object: map[string]any{"kind": "XNetwork", "metadata": map[string]any{"name": "example"}}},
+			{Object: map[string]any{"kind": "ConfigMap"}},
+		},
+	}
+
+	files, err := goldenFiles(result)
+	require.NoError(t, err)
+	assert.Equal(t, []goldenFile{{Path: "golden/rendered.yaml", Content: result.RawRenderOutput}}, files)
+}
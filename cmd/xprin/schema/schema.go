@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schema provides the schema subcommand for the xprin tool, which prints the JSON schema of testsuite files.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+)
+
+// Cmd represents the schema subcommand.
+type Cmd struct {
+	Output string `help:"Write the schema to this file instead of printing it." placeholder:"PATH" short:"o" type:"path"`
+	fs     afero.Fs
+}
+
+// AfterApply implements kong.AfterApply.
+func (c *Cmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// Run executes the schema subcommand.
+func (c *Cmd) Run(_ *kong.Context) error {
+	schema, err := api.Schema()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema: %w", err)
+	}
+
+	data = append(data, '\n')
+
+	if c.Output == "" {
+		utils.OutputPrintf("%s", data)
+		return nil
+	}
+
+	if err := afero.WriteFile(c.fs, c.Output, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+
+	return nil
+}
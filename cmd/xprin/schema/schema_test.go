@@ -0,0 +1,42 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestRun(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	cmd := &Cmd{Output: "/out/xprin-testsuite.json", fs: fs}
+
+	require.NoError(t, fs.MkdirAll("/out", 0o755))
+	require.NoError(t, cmd.Run(nil))
+
+	got, err := afero.ReadFile(fs, cmd.Output)
+	require.NoError(t, err)
+
+	want, err := os.ReadFile("../../../data/xprin-testsuite.json")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(want), string(got), "the schema of xprin schema must be the generated one")
+}
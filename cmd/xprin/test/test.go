@@ -18,10 +18,18 @@ limitations under the License.
 package test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/alecthomas/kong"
 	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/engine"
 	"github.com/crossplane-contrib/xprin/internal/testexecution/processor"
 	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 	"github.com/crossplane-contrib/xprin/internal/utils"
@@ -31,14 +39,25 @@ import (
 
 // Cmd represents the test subcommand.
 type Cmd struct {
-	Targets        []string            `arg:""                                                                                      help:"One or more test targets: individual files (e.g., 'tests/aws_xprin.yaml'), directories (e.g., 'tests/aws/'), or recursive directories (e.g., 'tests/aws/...'). Files must be named 'xprin.yaml' or '*_xprin.yaml'"`
-	ShowRender     bool                `help:"Display a list of the rendered resources in Kind/Name format. Requires --verbose."    name:"show-render"`
-	ShowValidate   bool                `help:"Display validation results for each resource. Requires --verbose."                    name:"show-validate"`
-	ShowHooks      bool                `help:"Display the execution hooks for each test case. Requires --verbose."                  name:"show-hooks"`
-	ShowAssertions bool                `help:"Display assertion results for each test case. Requires --verbose."                    name:"show-assertions"`
-	Verbose        bool                `help:"Show verbose test output and results (similar to go test -v)"                         short:"v"`
+	Targets        []string            `arg:""                                                                                                                                                                                             help:"One or more test targets: individual files (e.g., 'tests/aws_xprin.yaml'), directories (e.g., 'tests/aws/'), or recursive directories (e.g., 'tests/aws/...'). Files must be named 'xprin.yaml' or '*_xprin.yaml'"`
+	ShowRender     bool                `help:"Display a list of the rendered resources in Kind/Name format. Requires --verbose."                                                                                                           name:"show-render"`
+	ShowValidate   bool                `help:"Display validation results for each resource. Requires --verbose."                                                                                                                           name:"show-validate"`
+	ShowHooks      bool                `help:"Display the execution hooks for each test case. Requires --verbose."                                                                                                                         name:"show-hooks"`
+	ShowAssertions bool                `help:"Display assertion results for each test case. Requires --verbose."                                                                                                                           name:"show-assertions"`
+	Verbose        bool                `help:"Show verbose test output and results (similar to go test -v)"                                                                                                                                short:"v"`
 	Debug          bool                `help:"Show detailed debug information about test discovery, path resolution, and execution"`
-	Color          string              `default:"auto"                                                                              enum:"on,off,auto"                                                                                                                                                                                                       help:"Specify color usage: on, off, or auto (default auto)." name:"color"`
+	Vars           map[string]string   `help:"Set a template variable available as .Vars.KEY, overriding the testsuite vars. Can be repeated."                                                                                             name:"var"                                                                                                                                                                                                               placeholder:"KEY=VALUE"`
+	Timeout        time.Duration       `help:"Stop the tests that are still running after the given duration (e.g. 10m). 0 for no timeout."                                                                                                name:"timeout"`
+	FailFast       bool                `help:"Stop after the first failed test case across all testsuite files; the remaining test cases are reported as skipped."                                                                         name:"failfast"`
+	MaxFailures    int                 `help:"Stop after N failed test cases across all testsuite files; the remaining test cases are reported as skipped. 0 for no limit."                                                                name:"max-failures"                                                                                                                                                                                                      placeholder:"N"`
+	ArtifactsDir   string              `help:"Persist the inputs and outputs of each test case in this directory, indexed by a manifest.json (e.g. to upload them in CI)."                                                                 name:"artifacts-dir"                                                                                                                                                                                                     placeholder:"PATH"                                           type:"path"`
+	KeepTmp        bool                `help:"Do not remove the temporary directories of the testsuite files and test cases, for debugging."                                                                                               name:"keep-tmp"`
+	ChangedSince   string              `help:"Run only the testsuite files affected by the files changed since a git ref (e.g. origin/main): the testsuite file, its includes, or the inputs, patches and golden files of its test cases." name:"changed-since"                                                                                                                                                                                                     placeholder:"REF"`
+	Watch          bool                `help:"Keep running: watch the testsuite files and the files their test cases read, and re-run the affected test cases after each change, until Ctrl-C. Cannot be used with --changed-since or sharding."`
+	ShardIndex     int                 `help:"Run only the testsuite files of this shard, from 0 to --shard-total - 1."                                                                                                                    name:"shard-index"                                                                                                                                                                                                       placeholder:"I"`
+	ShardTotal     int                 `help:"Split the testsuite files into N shards, e.g. to run them in parallel CI jobs. 0 for no sharding."                                                                                           name:"shard-total"                                                                                                                                                                                                       placeholder:"N"`
+	ShardDurations string              `help:"Balance the shards by the durations of the testsuite files in a previous run, read from its manifest.json (--artifacts-dir) or a JUnit XML report."                                          name:"shard-durations"                                                                                                                                                                                                   placeholder:"PATH"                                           type:"path"`
+	Color          string              `default:"auto"                                                                                                                                                                                     enum:"on,off,auto"                                                                                                                                                                                                       help:"Specify color usage: on, off, or auto (default auto)." name:"color"`
 	Config         *internalcfg.Config `kong:"-"`
 	fs             afero.Fs
 }
@@ -49,6 +68,35 @@ func (c *Cmd) AfterApply() error {
 	return nil
 }
 
+// Validate rejects the combinations of flags that cannot be run. kong reports its errors as usage errors.
+func (c *Cmd) Validate() error {
+	if c.MaxFailures < 0 {
+		return fmt.Errorf("--max-failures must be 0 or more, got %d", c.MaxFailures)
+	}
+
+	if c.FailFast && c.MaxFailures != 0 {
+		return errors.New("--failfast and --max-failures cannot be used together: --failfast is --max-failures 1")
+	}
+
+	if c.Watch && c.ChangedSince != "" {
+		return errors.New("--watch and --changed-since cannot be used together: --watch re-runs the test cases affected by each change")
+	}
+
+	if c.Watch && (c.ShardTotal != 0 || c.ShardIndex != 0 || c.ShardDurations != "") {
+		return errors.New("--watch cannot be used with --shard-total, --shard-index or --shard-durations")
+	}
+
+	if c.ShardTotal == 0 && (c.ShardIndex != 0 || c.ShardDurations != "") {
+		return errors.New("--shard-index and --shard-durations require --shard-total")
+	}
+
+	if c.ShardTotal < 0 || c.ShardIndex < 0 || (c.ShardTotal > 0 && c.ShardIndex >= c.ShardTotal) {
+		return fmt.Errorf("invalid shard %d of %d: --shard-index must be between 0 and --shard-total - 1", c.ShardIndex, c.ShardTotal)
+	}
+
+	return nil
+}
+
 // Run executes the test subcommand.
 func (c *Cmd) Run(_ *kong.Context) error {
 	// Warn if render flag is used without -v
@@ -78,10 +126,81 @@ func (c *Cmd) Run(_ *kong.Context) error {
 		bunt.SetColorSettings(bunt.AUTO, bunt.AUTO)
 	}
 
+	shard, err := c.shard()
+	if err != nil {
+		return err
+	}
+
 	options := c.newOptions(c.Config)
+	targets := c.Targets
+
+	if c.ChangedSince != "" {
+		targets, err = processor.SelectChangedTestSuiteFiles(c.fs, targets, c.ChangedSince, options)
+		if err != nil {
+			return err
+		}
+	}
+
+	if shard != nil {
+		targets, err = processor.ShardTestSuiteFiles(c.fs, targets, shard, c.Debug)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	if c.Watch {
+		return processor.WatchTargets(ctx, c.fs, targets, func() *testexecutionUtils.Options { return c.newOptions(c.Config) })
+	}
 
 	// Process targets and run tests
-	return processor.ProcessTargets(c.fs, c.Targets, options)
+	return processor.ProcessTargets(ctx, c.fs, targets, options)
+}
+
+// shard returns the shard of the testsuite files to run, or nil if the tests are not sharded. The shard flags are
+// checked by Validate.
+func (c *Cmd) shard() (*processor.Shard, error) {
+	if c.ShardTotal == 0 {
+		return nil, nil
+	}
+
+	shard := &processor.Shard{Index: c.ShardIndex, Total: c.ShardTotal}
+
+	if c.ShardDurations != "" {
+		durations, err := processor.LoadShardDurations(c.fs, c.ShardDurations)
+		if err != nil {
+			return nil, err
+		}
+
+		shard.Durations = durations
+	}
+
+	return shard, nil
+}
+
+// interruptContext returns a context that is canceled with testexecutionUtils.ErrInterrupted on the first Ctrl-C or SIGTERM,
+// so that the running commands are stopped and the temporary directories are cleaned up. A second Ctrl-C exits immediately.
+func interruptContext() (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+			signal.Stop(signals)
+			cancel(testexecutionUtils.ErrInterrupted)
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel(nil)
+	}
 }
 
 // newOptions creates a testexecutionUtils.Options struct from a Command and Config.
@@ -96,6 +215,7 @@ func (c *Cmd) newOptions(cfg *internalcfg.Config) *testexecutionUtils.Options {
 	return &testexecutionUtils.Options{
 		Dependencies:   cfg.Dependencies,
 		Repositories:   cfg.Repositories,
+		Vars:           c.Vars,
 		ShowRender:     c.ShowRender,
 		ShowValidate:   c.ShowValidate,
 		ShowHooks:      c.ShowHooks,
@@ -105,5 +225,19 @@ func (c *Cmd) newOptions(cfg *internalcfg.Config) *testexecutionUtils.Options {
 		Color:          bunt.UseColors(),
 		Render:         render,
 		Validate:       validate,
+		Timeout:        c.Timeout,
+		FailureBudget:  testexecutionUtils.NewFailureBudget(c.maxFailures()),
+		Summary:        engine.NewRunSummary(),
+		Artifacts:      testexecutionUtils.NewArtifacts(c.ArtifactsDir),
+		KeepTmp:        c.KeepTmp,
 	}
 }
+
+// maxFailures returns the number of failed test cases after which the run stops, 0 for no limit. --failfast is --max-failures 1.
+func (c *Cmd) maxFailures() int {
+	if c.FailFast {
+		return 1
+	}
+
+	return c.MaxFailures
+}
@@ -19,11 +19,16 @@ package test
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/alecthomas/kong"
 	internalcfg "github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/processor"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
-	"github.com/stretchr/testify/assert" //nolint:depguard // testify is widely used for testing
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
 )
 
 // TestNewOperation tests that NewOperation correctly initializes an Operation struct with the given config.
@@ -107,6 +112,28 @@ func TestRun_NoWarningWithVerbose(t *testing.T) {
 	assert.NotContains(t, output, "--show-assertions requires -v", "Should not show warning when assertions flag is used with verbose")
 }
 
+func TestRun_WarningWithoutAPIVersion(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/tests/unversioned_xprin.yaml", []byte("tests:\n- name: later\n  skip: not yet\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/tests/with_header_xprin.yaml",
+		[]byte("apiVersion: xprin.crossplane.io/v1alpha1\nkind: TestSuite\ntests:\n- name: later\n  skip: not yet\n"), 0o644))
+
+	cmd := &Cmd{
+		Targets: []string{"/tests/...", "/tests/unversioned_xprin.yaml"},
+		Config:  &internalcfg.Config{Subcommands: &internalcfg.Subcommands{}},
+		fs:      fs,
+	}
+
+	output := unittestsUtils.CaptureStderr(func() {
+		assert.NoError(t, cmd.Run(&kong.Context{}))
+	})
+
+	warning := "WARNING: unversioned_xprin.yaml: no apiVersion and kind, which will be required in a future version of xprin: " +
+		"add 'apiVersion: xprin.crossplane.io/v1alpha1' and 'kind: TestSuite'"
+	assert.Equal(t, 1, strings.Count(output, warning), output)
+	assert.NotContains(t, output, "with_header_xprin.yaml")
+}
+
 func TestNewOptions(t *testing.T) {
 	// Setup a config with specific render, validate and dependency values
 	cfg := &internalcfg.Config{
@@ -132,6 +159,11 @@ func TestNewOptions(t *testing.T) {
 		ShowAssertions: true,
 		Verbose:        true,
 		Debug:          false,
+		Vars:           map[string]string{"region": "eu-west-1"},
+		Timeout:        10 * time.Minute,
+		MaxFailures:    5,
+		ArtifactsDir:   "/tmp/artifacts",
+		KeepTmp:        true,
 	}
 
 	// Create options using the newOptions method
@@ -150,6 +182,105 @@ func TestNewOptions(t *testing.T) {
 	assert.Equal(t, cmd.ShowAssertions, options.ShowAssertions)
 	assert.Equal(t, cmd.Verbose, options.Verbose)
 	assert.Equal(t, cmd.Debug, options.Debug)
+	assert.Equal(t, cmd.Vars, options.Vars)
+	assert.Equal(t, cmd.Timeout, options.Timeout)
+	assert.Equal(t, testexecutionUtils.NewFailureBudget(5), options.FailureBudget)
+	assert.NotNil(t, options.Summary)
+	assert.Equal(t, "/tmp/artifacts", options.Artifacts.Dir)
+	assert.True(t, options.KeepTmp)
+}
+
+func TestMaxFailures(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  Cmd
+		want int
+	}{
+		{name: "no limit", cmd: Cmd{}, want: 0},
+		{name: "max failures", cmd: Cmd{MaxFailures: 3}, want: 3},
+		{name: "failfast", cmd: Cmd{FailFast: true}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cmd.maxFailures())
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     Cmd
+		wantErr string
+	}{
+		{name: "no limit", cmd: Cmd{}},
+		{name: "max failures", cmd: Cmd{MaxFailures: 3}},
+		{name: "failfast", cmd: Cmd{FailFast: true}},
+		{name: "negative max failures", cmd: Cmd{MaxFailures: -1}, wantErr: "--max-failures must be 0 or more, got -1"},
+		{name: "failfast with max failures", cmd: Cmd{FailFast: true, MaxFailures: 3}, wantErr: "--failfast and --max-failures cannot be used together"},
+		{name: "watch", cmd: Cmd{Watch: true}},
+		{name: "watch with changed since", cmd: Cmd{Watch: true, ChangedSince: "origin/main"}, wantErr: "--watch and --changed-since cannot be used together"},
+		{name: "watch with shards", cmd: Cmd{Watch: true, ShardTotal: 2}, wantErr: "--watch cannot be used with --shard-total"},
+		{name: "watch with shard durations", cmd: Cmd{Watch: true, ShardDurations: "manifest.json"}, wantErr: "--watch cannot be used with --shard-total"},
+		{name: "shard", cmd: Cmd{ShardIndex: 2, ShardTotal: 3, ShardDurations: "manifest.json"}},
+		{name: "shard index without total", cmd: Cmd{ShardIndex: 1}, wantErr: "--shard-index and --shard-durations require --shard-total"},
+		{name: "shard durations without total", cmd: Cmd{ShardDurations: "manifest.json"}, wantErr: "require --shard-total"},
+		{name: "shard index out of range", cmd: Cmd{ShardIndex: 3, ShardTotal: 3}, wantErr: "invalid shard 3 of 3"},
+		{name: "negative shard index", cmd: Cmd{ShardIndex: -1, ShardTotal: 3}, wantErr: "invalid shard -1 of 3"},
+		{name: "negative shard total", cmd: Cmd{ShardTotal: -2}, wantErr: "invalid shard 0 of -2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestShard(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "manifest.json", []byte(`{"testsuites": [{"file": "a_xprin.yaml", "duration": 2.5}]}`), 0o644))
+
+	tests := []struct {
+		name    string
+		cmd     Cmd
+		want    *processor.Shard
+		wantErr string
+	}{
+		{name: "no sharding", cmd: Cmd{}},
+		{name: "shard", cmd: Cmd{ShardIndex: 1, ShardTotal: 3}, want: &processor.Shard{Index: 1, Total: 3}},
+		{
+			name: "shard with durations",
+			cmd:  Cmd{ShardTotal: 2, ShardDurations: "manifest.json"},
+			want: &processor.Shard{Total: 2, Durations: map[string]time.Duration{"a_xprin.yaml": 2500 * time.Millisecond}},
+		},
+		{name: "missing durations report", cmd: Cmd{ShardTotal: 2, ShardDurations: "missing.json"}, wantErr: "failed to read durations report"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cmd.fs = fs
+
+			shard, err := tt.cmd.shard()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, shard)
+		})
+	}
 }
 
 // Test that NewOptions handles nil Subcommands gracefully.
@@ -17,6 +17,6 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-//go:generate go run github.com/crossplane-contrib/xprin/cmd/schema-gen -out data/xprin-testsuite.json
+//go:generate go run github.com/crossplane-contrib/xprin/cmd/schema-gen -out data/xprin-testsuite.json -comments internal/api/zz_generated.comments.go
 
 package generate
@@ -20,33 +20,74 @@ package api
 import (
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
+	"time"
 )
 
 // TestSuiteSpec represents the structure of a testsuite YAML file used by xprin.
 type TestSuiteSpec struct {
-	Common Common     `json:"common,omitempty"` // Common config for all tests (Optional)
-	Tests  []TestCase `json:"tests"`            // List of test cases (Required)
-}
+	TypeMeta `json:",inline"`
+
+	Include       []Include             `json:"include,omitempty"`        // Fragment files whose common config and sets are included (Optional)
+	AssertionSets map[string]Assertions `json:"assertion-sets,omitempty"` // Named assertion sets that can be referenced from common and test cases (Optional)
+	HookSets      map[string]Hooks      `json:"hook-sets,omitempty"`      // Named hook sets that can be referenced from common and test cases (Optional)
+	Vars          map[string]string     `json:"vars,omitempty"`           // Variables available in templates as .Vars, overridden by --var (Optional)
+	Env           []string              `json:"env,omitempty"`            // Names of the environment variables available in templates as .Env (Optional)
+	Timeout       string                `json:"timeout,omitempty"`        // Timeout for all testcases of the testsuite file together, as a duration (e.g. "10m") (Optional)
+	Common        Common                `json:"common,omitempty"`         // Common config for all tests (Optional)
+	Tests         []TestCase            `json:"tests"`                    // List of test cases (Required)
+}
+
+// MergeStrategy controls how the lists and maps of a test case section are merged with the same section in common.
+type MergeStrategy string
+
+const (
+	// MergeStrategyReplace uses the common values only when the test case does not set them (default).
+	MergeStrategyReplace MergeStrategy = "replace"
+	// MergeStrategyAppend appends the test case list items to the common ones and merges maps, with test case keys taking precedence.
+	MergeStrategyAppend MergeStrategy = "append"
+)
 
 // Patches represents XR patching configuration.
 type Patches struct {
-	XRD                       string `json:"xrd,omitempty"`                         // Path to the XR's or Claim's XRD (Optional)
-	ConnectionSecret          *bool  `json:"connection-secret,omitempty"`           // When true, create a connection secret for the XR (Optional)
-	ConnectionSecretName      string `json:"connection-secret-name,omitempty"`      // Name of the connection secret (Optional)
-	ConnectionSecretNamespace string `json:"connection-secret-namespace,omitempty"` // Namespace of the connection secret (Optional)
+	XRD                       string               `json:"xrd,omitempty"`                                                               // Path to the XR's or Claim's XRD (Optional)
+	ConnectionSecret          *bool                `json:"connection-secret,omitempty"`                                                 // When true, create a connection secret for the XR (Optional)
+	ConnectionSecretName      string               `json:"connection-secret-name,omitempty"`                                            // Name of the connection secret (Optional)
+	ConnectionSecretNamespace string               `json:"connection-secret-namespace,omitempty"`                                       // Namespace of the connection secret (Optional)
+	Set                       []FieldSet           `json:"set,omitempty"`                                                               // Fields to set on the XR using Crossplane fieldpaths (Optional)
+	Merge                     []map[string]any     `json:"merge,omitempty"`                                                             // JSON merge patches (RFC 7386) to apply to the XR (Optional)
+	JSONPatch                 []JSONPatchOperation `json:"jsonpatch,omitempty"`                                                         // JSON patch operations (RFC 6902) to apply to the XR (Optional)
+	MergeStrategy             MergeStrategy        `json:"merge-strategy,omitempty"              jsonschema:"enum=replace,enum=append"` // How set, merge and jsonpatch are merged with common (Optional, default: replace)
+}
+
+// FieldSet represents a single field of the XR to set.
+type FieldSet struct {
+	Path  string `json:"path"`  // Crossplane fieldpath of the field to set (e.g. "spec.parameters.tags[0]") (Required)
+	Value any    `json:"value"` // Value to set the field to (Required)
+}
+
+// JSONPatchOperation represents a single RFC 6902 JSON patch operation.
+type JSONPatchOperation struct {
+	Op    string `json:"op"              jsonschema:"enum=add,enum=remove,enum=replace,enum=move,enum=copy,enum=test"` // Operation to perform (Required)
+	Path  string `json:"path"`                                                                                         // JSON pointer to the target location (e.g. "/spec/parameters/region") (Required)
+	From  string `json:"from,omitempty"`                                                                               // JSON pointer to the source location for move and copy (Optional)
+	Value any    `json:"value,omitempty"`                                                                              // Value for add, replace and test (Optional)
 }
 
 // Hooks represents the execution hooks configuration.
 type Hooks struct {
-	PreTest  []Hook `json:"pre-test,omitempty"`  // Hooks that are executed before the testcase (Optional)
-	PostTest []Hook `json:"post-test,omitempty"` // Hooks that are executed after the testcase (Optional)
+	PreTest       []Hook        `json:"pre-test,omitempty"`                                             // Hooks that are executed before the testcase (Optional)
+	PostTest      []Hook        `json:"post-test,omitempty"`                                            // Hooks that are executed after the testcase (Optional)
+	Sets          []string      `json:"sets,omitempty"`                                                 // Names of hook sets whose hooks run before these ones (Optional)
+	MergeStrategy MergeStrategy `json:"merge-strategy,omitempty" jsonschema:"enum=replace,enum=append"` // How hooks are merged with common (Optional, default: replace)
 }
 
 // Hook represents a single executable step with optional metadata.
 type Hook struct {
-	Name string `json:"name,omitempty"` // Descriptive name for the hook (Optional)
-	Run  string `json:"run"`            // Command to run (Required)
+	Name    string `json:"name,omitempty"`    // Descriptive name for the hook (Optional)
+	Run     string `json:"run"`               // Command to run (Required)
+	Timeout string `json:"timeout,omitempty"` // Timeout for the hook, as a duration (e.g. "30s") (Optional)
 }
 
 // AssertionXprin represents a single xprin assertion (single-resource or Count).
@@ -68,9 +109,11 @@ type AssertionGoldenFile struct {
 
 // Assertions represents assertions grouped by execution engine.
 type Assertions struct {
-	Xprin []AssertionXprin      `json:"xprin,omitempty"` // xprin assertions (in-process) (Optional)
-	Diff  []AssertionGoldenFile `json:"diff,omitempty"`  // diff assertions (go-native compare to golden file) (Optional)
-	Dyff  []AssertionGoldenFile `json:"dyff,omitempty"`  // dyff assertions (dyff between expected and actual) (Optional)
+	Xprin         []AssertionXprin      `json:"xprin,omitempty"`                                                // xprin assertions (in-process) (Optional)
+	Diff          []AssertionGoldenFile `json:"diff,omitempty"`                                                 // diff assertions (go-native compare to golden file) (Optional)
+	Dyff          []AssertionGoldenFile `json:"dyff,omitempty"`                                                 // dyff assertions (dyff between expected and actual) (Optional)
+	Sets          []string              `json:"sets,omitempty"`                                                 // Names of assertion sets whose assertions are added to these ones (Optional)
+	MergeStrategy MergeStrategy         `json:"merge-strategy,omitempty" jsonschema:"enum=replace,enum=append"` // How assertions are merged with common (Optional, default: replace)
 }
 
 // Common represents the common configuration for a testsuite file.
@@ -85,6 +128,11 @@ type Common struct {
 type TestCase struct {
 	Name       string     `json:"name"`                 // Descriptive name for the testcase (Required)
 	ID         string     `json:"id,omitempty"`         // Unique identifier for the testcase (Optional)
+	Extends    string     `json:"extends,omitempty"`    // ID of a testcase to inherit inputs, patches, hooks and assertions from (Optional)
+	Needs      []string   `json:"needs,omitempty"`      // IDs of testcases that must pass before this testcase runs (Optional)
+	Skip       string     `json:"skip,omitempty"`       // Reason for skipping the testcase; the testcase does not run when set (Optional)
+	XFail      string     `json:"xfail,omitempty"`      // Reason for expecting the testcase to fail; the testcase passes when it fails and fails when it passes (Optional)
+	Timeout    string     `json:"timeout,omitempty"`    // Timeout for the testcase including its hooks, as a duration (e.g. "2m") (Optional)
 	Inputs     Inputs     `json:"inputs,omitempty"`     // Inputs of a testcase (Required unless specified in the common inputs)
 	Patches    Patches    `json:"patches,omitempty"`    // XR patching configuration (Optional)
 	Hooks      Hooks      `json:"hooks,omitempty"`      // Execution hooks (Optional)
@@ -92,17 +140,44 @@ type TestCase struct {
 }
 
 // Inputs represents the inputs for a test case or common configuration.
+// Claim, XR, ObservedResources, ExtraResources and ContextFiles can also be given inline as YAML objects, see InlineInputs.
 type Inputs struct {
-	Claim               string            `json:"claim,omitempty"`                // Path to Claim file (one of Claim or XR must be set, either in the test case or in the common inputs)
-	XR                  string            `json:"xr,omitempty"`                   // Path to XR file (one of Claim or XR must be set, either in the test case or in the common inputs)
-	Composition         string            `json:"composition,omitempty"`          // Path to composition file (Required unless specified in the common inputs)
-	Functions           string            `json:"functions,omitempty"`            // Path to functions file or directory (Required unless specified in the common inputs)
-	CRDs                []string          `json:"crds,omitempty"`                 // Paths to CRD files (Optional)
-	ContextFiles        map[string]string `json:"context-files,omitempty"`        // Map of context keys to file paths (Optional)
-	ContextValues       map[string]string `json:"context-values,omitempty"`       // Map of context keys to inline values (Optional)
-	ObservedResources   string            `json:"observed-resources,omitempty"`   // Path to observed resources file (Optional)
-	ExtraResources      string            `json:"extra-resources,omitempty"`      // Path to extra resources file (Optional)
-	FunctionCredentials string            `json:"function-credentials,omitempty"` // Path to function credentials file (Optional)
+	Claim               string            `json:"claim,omitempty"                jsonschema:"oneof_type=string;object"`       // Path to Claim file or inline Claim (one of Claim or XR must be set, either in the test case or in the common inputs)
+	XR                  string            `json:"xr,omitempty"                   jsonschema:"oneof_type=string;object"`       // Path to XR file or inline XR (one of Claim or XR must be set, either in the test case or in the common inputs)
+	XRFromXRD           *XRFromXRD        `json:"xr-from-xrd,omitempty"`                                                      // XRs generated from the schema of an XRD, one test case per XR (Optional, instead of Claim or XR)
+	Composition         string            `json:"composition,omitempty"`                                                      // Path to composition file (Required unless specified in the common inputs)
+	Functions           string            `json:"functions,omitempty"`                                                        // Path to functions file or directory (Required unless specified in the common inputs)
+	CRDs                []string          `json:"crds,omitempty"`                                                             // Paths to CRD files (Optional)
+	ContextFiles        map[string]string `json:"context-files,omitempty"`                                                    // Map of context keys to file paths or inline objects (Optional)
+	ContextValues       map[string]string `json:"context-values,omitempty"`                                                   // Map of context keys to inline values (Optional)
+	ObservedResources   string            `json:"observed-resources,omitempty"   jsonschema:"oneof_type=string;object;array"` // Path to observed resources file or inline resources (Optional)
+	ExtraResources      string            `json:"extra-resources,omitempty"      jsonschema:"oneof_type=string;object;array"` // Path to extra resources file or inline resources (Optional)
+	FunctionCredentials string            `json:"function-credentials,omitempty"`                                             // Path to function credentials file (Optional)
+	MergeStrategy       MergeStrategy     `json:"merge-strategy,omitempty"       jsonschema:"enum=replace,enum=append"`       // How crds, context-files and context-values are merged with common (Optional, default: replace)
+	Inline              InlineInputs      `json:"-"`                                                                          // Inputs given inline instead of as paths
+}
+
+// XRGenerationMode is a kind of XRs generated from the schema of an XRD.
+type XRGenerationMode string
+
+const (
+	// XRGenerationModeMinimal generates an XR with only the required fields set.
+	XRGenerationModeMinimal XRGenerationMode = "minimal"
+	// XRGenerationModeMaximal generates an XR with every field set, including the optional ones.
+	XRGenerationModeMaximal XRGenerationMode = "maximal"
+	// XRGenerationModeBoundary generates an XR per boundary value of a field: enum values, minimum and maximum, lengths and patterns.
+	XRGenerationModeBoundary XRGenerationMode = "boundary"
+	// XRGenerationModeRandom generates Count XRs with random values within the schema, reproducible from Seed.
+	XRGenerationModeRandom XRGenerationMode = "random"
+)
+
+// XRFromXRD represents XRs generated from the OpenAPI schema of an XRD. The test case runs once per generated XR,
+// with the same inputs, patches, hooks and assertions.
+type XRFromXRD struct {
+	XRD   string             `json:"xrd"`                                                                              // Path to the XRD (Required)
+	Modes []XRGenerationMode `json:"modes,omitempty" jsonschema:"enum=minimal,enum=maximal,enum=boundary,enum=random"` // Kinds of XRs to generate (Optional, default: all of them)
+	Count int                `json:"count,omitempty"`                                                                  // Number of random XRs (Optional, default: 5)
+	Seed  uint64             `json:"seed,omitempty"`                                                                   // Seed of the random XRs (Optional, default: 0)
 }
 
 // HasConnectionSecret returns true if ConnectionSecret is explicitly set to true.
@@ -110,12 +185,18 @@ func (p *Patches) HasConnectionSecret() bool {
 	return p.ConnectionSecret != nil && *p.ConnectionSecret
 }
 
+// HasFieldPatches returns true if any field sets, merge patches or JSON patches are set.
+func (p *Patches) HasFieldPatches() bool {
+	return len(p.Set) > 0 || len(p.Merge) > 0 || len(p.JSONPatch) > 0
+}
+
 // HasPatches returns true if any patches are set.
 func (p *Patches) HasPatches() bool {
 	return p.XRD != "" ||
 		p.HasConnectionSecret() ||
 		p.ConnectionSecretName != "" ||
-		p.ConnectionSecretNamespace != ""
+		p.ConnectionSecretNamespace != "" ||
+		p.HasFieldPatches()
 }
 
 // CheckConnectionSecret validates connection secret configuration:
@@ -151,6 +232,30 @@ func (h *Hooks) HasHooks() bool {
 	return h.HasPreTestHooks() || h.HasPostTestHooks()
 }
 
+// TimeoutDuration returns the timeout of the hook, or 0 if it has none. The timeout must be valid (see CheckValidTestSuiteFile).
+func (h *Hook) TimeoutDuration() time.Duration {
+	timeout, _ := ParseTimeout(h.Timeout)
+	return timeout
+}
+
+// ParseTimeout parses a timeout given as a duration (e.g. "30s" or "1m30s"). An empty timeout means no timeout and returns 0.
+func ParseTimeout(timeout string) (time.Duration, error) {
+	if timeout == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+
+	return duration, nil
+}
+
 // HasAssertionsXprin returns true if any xprin assertions are set.
 func (a *Assertions) HasAssertionsXprin() bool {
 	return len(a.Xprin) > 0
@@ -171,12 +276,57 @@ func (a *Assertions) HasAssertions() bool {
 	return a.HasAssertionsXprin() || a.HasAssertionsDiff() || a.HasAssertionsDyff()
 }
 
+// TestSuiteError is an error about a value of a testsuite file.
+type TestSuiteError struct {
+	Path []any // Keys and list indexes of the value in the testsuite file, e.g. "tests", 0, "id"
+	Err  error
+}
+
+// TestSuiteErrorf returns a TestSuiteError about the value at path, formatted as with fmt.Errorf.
+func TestSuiteErrorf(path []any, format string, args ...any) *TestSuiteError {
+	return &TestSuiteError{Path: path, Err: fmt.Errorf(format, args...)}
+}
+
+func (e *TestSuiteError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TestSuiteError) Unwrap() error {
+	return e.Err
+}
+
+// TestSuiteErrors is the list of all errors of a testsuite file.
+type TestSuiteErrors []*TestSuiteError
+
+func (e TestSuiteErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return fmt.Sprintf("invalid testsuite file:\n- %s", strings.Join(messages, "\n- "))
+}
+
+// at returns path followed by keys, without sharing the underlying array of path.
+func at(path []any, keys ...any) []any {
+	return append(slices.Clip(path), keys...)
+}
+
 // CheckValidTestSuiteFile checks:
 // - if test case names are non-empty
 // - if test case IDs are unique (only for tests that have IDs)
-// and returns a list of all validation errors found.
+// - if merge strategies are valid (in common and in test cases)
+// - if extended test case IDs exist and do not form cycles
+// - if needed test case IDs exist and do not form cycles
+// - if test cases are not marked as both skipped and expected to fail
+// - if timeouts are valid durations (of the testsuite, test cases and hooks)
+// and returns all validation errors found as TestSuiteErrors.
 func (ts *TestSuiteSpec) CheckValidTestSuiteFile() error {
-	var allErrors []string
+	var allErrors TestSuiteErrors
+
+	addError := func(path []any, format string, args ...any) {
+		allErrors = append(allErrors, TestSuiteErrorf(path, format, args...))
+	}
 
 	// Check if an ID contains only alphanumeric characters, underscores, and hyphens
 	hasValidID := func(id string) bool {
@@ -193,40 +343,352 @@ func (ts *TestSuiteSpec) CheckValidTestSuiteFile() error {
 		return true
 	}
 
+	// Check if the merge strategies of a common or test case section are valid
+	checkMergeStrategies := func(owner string, path []any, inputs Inputs, patches Patches, hooks Hooks, assertions Assertions) {
+		for _, section := range []struct {
+			name     string
+			strategy MergeStrategy
+		}{
+			{"inputs", inputs.MergeStrategy},
+			{"patches", patches.MergeStrategy},
+			{"hooks", hooks.MergeStrategy},
+			{"assertions", assertions.MergeStrategy},
+		} {
+			switch section.strategy {
+			case "", MergeStrategyReplace, MergeStrategyAppend:
+			default:
+				addError(at(path, section.name, "merge-strategy"), "%s has invalid %s merge-strategy '%s' (allowed: %s, %s)",
+					owner, section.name, section.strategy, MergeStrategyReplace, MergeStrategyAppend)
+			}
+		}
+	}
+
+	// Check if the timeouts of a list of hooks are valid
+	checkHookTimeouts := func(owner string, path []any, hooks Hooks) {
+		for _, list := range []struct {
+			name  string
+			hooks []Hook
+		}{
+			{"pre-test", hooks.PreTest},
+			{"post-test", hooks.PostTest},
+		} {
+			for j, hook := range list.hooks {
+				if _, err := ParseTimeout(hook.Timeout); err != nil {
+					addError(at(path, list.name, j, "timeout"), "%s has a hook with invalid timeout '%s': %v", owner, hook.Timeout, err)
+				}
+			}
+		}
+	}
+
+	// Check if an xr-from-xrd is valid
+	checkXRFromXRD := func(owner string, path []any, xrFromXRD *XRFromXRD) {
+		if xrFromXRD == nil {
+			return
+		}
+
+		path = at(path, "inputs", "xr-from-xrd")
+
+		if xrFromXRD.XRD == "" {
+			addError(path, "%s has xr-from-xrd without xrd", owner)
+		}
+
+		for j, mode := range xrFromXRD.Modes {
+			switch mode {
+			case XRGenerationModeMinimal, XRGenerationModeMaximal, XRGenerationModeBoundary, XRGenerationModeRandom:
+			default:
+				addError(at(path, "modes", j), "%s has invalid xr-from-xrd mode '%s' (allowed: %s, %s, %s, %s)", owner, mode,
+					XRGenerationModeMinimal, XRGenerationModeMaximal, XRGenerationModeBoundary, XRGenerationModeRandom)
+			}
+		}
+
+		if xrFromXRD.Count < 0 {
+			addError(at(path, "count"), "%s has invalid xr-from-xrd count %d (must not be negative)", owner, xrFromXRD.Count)
+		}
+	}
+
+	commonHasClaim := ts.Common.Inputs.Claim != "" || ts.Common.Inputs.Inline.Claim != nil
+	commonPath := []any{"common"}
+
+	checkMergeStrategies("common", commonPath, ts.Common.Inputs, ts.Common.Patches, ts.Common.Hooks, ts.Common.Assertions)
+	checkXRFromXRD("common", commonPath, ts.Common.Inputs.XRFromXRD)
+
+	if ts.Common.Inputs.XRFromXRD != nil && (commonHasClaim || ts.Common.Inputs.XR != "" || ts.Common.Inputs.Inline.XR != nil) {
+		addError(at(commonPath, "inputs", "xr-from-xrd"), "common cannot have xr-from-xrd together with claim or xr")
+	}
+
+	if _, err := ParseTimeout(ts.Timeout); err != nil {
+		addError([]any{"timeout"}, "testsuite has invalid timeout '%s': %v", ts.Timeout, err)
+	}
+
+	checkHookTimeouts("common", at(commonPath, "hooks"), ts.Common.Hooks)
+
+	for _, name := range slices.Sorted(maps.Keys(ts.HookSets)) {
+		checkHookTimeouts(fmt.Sprintf("hook set '%s'", name), []any{"hook-sets", name}, ts.HookSets[name])
+	}
+
 	// Track used IDs to detect duplicates
 	usedIDs := make(map[string]bool)
 
 	for i := range ts.Tests {
 		test := &ts.Tests[i]
+		path := []any{"tests", i}
+		owner := fmt.Sprintf("test case '%s'", test.Name)
 
 		// Check for empty name
 		if test.Name == "" {
-			allErrors = append(allErrors, "test case has empty name")
+			addError(at(path, "name"), "test case has empty name")
 		}
 
 		// Only validate and check uniqueness for IDs that are explicitly provided
 		if test.ID != "" {
 			// Validate test ID format
 			if !hasValidID(test.ID) {
-				allErrors = append(allErrors, fmt.Sprintf("test case ID '%s' contains invalid characters (allowed: alphanumeric, underscore, hyphen)", test.ID))
+				addError(at(path, "id"), "test case ID '%s' contains invalid characters (allowed: alphanumeric, underscore, hyphen)", test.ID)
 			}
 
 			// Check for duplicate IDs (only among tests that have IDs)
 			if usedIDs[test.ID] {
-				allErrors = append(allErrors, fmt.Sprintf("duplicate test case ID '%s' found", test.ID))
+				addError(at(path, "id"), "duplicate test case ID '%s' found", test.ID)
 			} else {
 				usedIDs[test.ID] = true
 			}
 		}
+
+		checkMergeStrategies(owner, path, test.Inputs, test.Patches, test.Hooks, test.Assertions)
+		checkXRFromXRD(owner, path, test.Inputs.XRFromXRD)
+
+		if test.XRGeneration(ts.Common) != nil {
+			if test.HasClaim() || test.HasXR() || commonHasClaim {
+				addError(at(path, "inputs", "xr-from-xrd"), "%s cannot have xr-from-xrd together with claim or xr", owner)
+			}
+
+			if test.ID != "" {
+				addError(at(path, "id"), "%s cannot have an id because it runs once per XR of xr-from-xrd", owner)
+			}
+		}
+
+		if test.IsSkipped() && test.IsExpectedToFail() {
+			addError(at(path, "xfail"), "%s cannot have both skip and xfail", owner)
+		}
+
+		if _, err := ParseTimeout(test.Timeout); err != nil {
+			addError(at(path, "timeout"), "%s has invalid timeout '%s': %v", owner, test.Timeout, err)
+		}
+
+		checkHookTimeouts(owner, at(path, "hooks"), test.Hooks)
 	}
 
+	allErrors = append(allErrors, ts.checkExtends()...)
+	allErrors = append(allErrors, ts.checkNeeds()...)
+
 	if len(allErrors) > 0 {
-		return fmt.Errorf("invalid testsuite file:\n- %s", strings.Join(allErrors, "\n- "))
+		return allErrors
 	}
 
 	return nil
 }
 
+// checkExtends checks that every extended test case ID exists and that there are no extends cycles.
+func (ts *TestSuiteSpec) checkExtends() TestSuiteErrors {
+	var allErrors TestSuiteErrors
+
+	extendsByID := make(map[string]string)
+
+	for _, test := range ts.Tests {
+		if test.ID != "" {
+			extendsByID[test.ID] = test.Extends
+		}
+	}
+
+	reportedCycles := make(map[string]bool)
+
+	for i, test := range ts.Tests {
+		if test.Extends == "" {
+			continue
+		}
+
+		path := []any{"tests", i, "extends"}
+
+		if _, ok := extendsByID[test.Extends]; !ok {
+			allErrors = append(allErrors, TestSuiteErrorf(path, "test case '%s' extends unknown test case ID '%s'", test.Name, test.Extends))
+			continue
+		}
+
+		if test.ID == "" {
+			continue
+		}
+
+		// Follow the extends chain; a test case with an ID is in a cycle if the chain leads back to it
+		chain := []string{test.ID}
+		for id := test.Extends; id != ""; id = extendsByID[id] {
+			chain = append(chain, id)
+
+			if id == test.ID {
+				if !reportedCycles[test.ID] {
+					for _, cycleID := range chain {
+						reportedCycles[cycleID] = true
+					}
+
+					allErrors = append(allErrors, TestSuiteErrorf(path, "extends cycle detected: %s", strings.Join(chain, " -> ")))
+				}
+
+				break
+			}
+
+			if len(chain) > len(extendsByID)+1 {
+				break
+			}
+		}
+	}
+
+	return allErrors
+}
+
+// checkNeeds checks that every needed test case ID exists and that there are no needs cycles.
+func (ts *TestSuiteSpec) checkNeeds() TestSuiteErrors {
+	var allErrors TestSuiteErrors
+
+	needsByID := make(map[string][]string)
+	indexByID := make(map[string]int)
+
+	for i, test := range ts.Tests {
+		if test.ID != "" {
+			needsByID[test.ID] = test.Needs
+			indexByID[test.ID] = i
+		}
+	}
+
+	for i, test := range ts.Tests {
+		for j, id := range test.Needs {
+			if _, ok := needsByID[id]; !ok {
+				allErrors = append(allErrors, TestSuiteErrorf([]any{"tests", i, "needs", j},
+					"test case '%s' needs unknown test case ID '%s'", test.Name, id))
+			}
+		}
+	}
+
+	for _, cycle := range FindCycles(ts.testIDs(), needsByID) {
+		allErrors = append(allErrors, TestSuiteErrorf([]any{"tests", indexByID[cycle[0]], "needs"},
+			"needs cycle detected: %s", strings.Join(cycle, " -> ")))
+	}
+
+	return allErrors
+}
+
+// testIDs returns the IDs of the test cases that have one, in file order.
+func (ts *TestSuiteSpec) testIDs() []string {
+	var ids []string
+
+	for _, test := range ts.Tests {
+		if test.ID != "" {
+			ids = append(ids, test.ID)
+		}
+	}
+
+	return ids
+}
+
+// FindCycles returns the cycles of a dependency graph, each as the path of IDs that leads back to its first ID.
+// Nodes are visited in the given order, so that the reported cycles are deterministic.
+func FindCycles(ids []string, edges map[string][]string) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var (
+		cycles [][]string
+		state  = make(map[string]int)
+		path   []string
+	)
+
+	var visit func(id string)
+
+	visit = func(id string) {
+		state[id] = visiting
+		path = append(path, id)
+
+		for _, next := range edges[id] {
+			switch state[next] {
+			case visiting:
+				start := slices.Index(path, next)
+				cycle := append(slices.Clone(path[start:]), next)
+				cycles = append(cycles, cycle)
+			case unvisited:
+				if _, ok := edges[next]; ok {
+					visit(next)
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[id] = visited
+	}
+
+	for _, id := range ids {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+
+	return cycles
+}
+
+// ResolveExtends merges every test case that extends another one with the test case it extends,
+// using the same rules as for merging common into a test case. Test cases must be valid (see CheckValidTestSuiteFile).
+func (ts *TestSuiteSpec) ResolveExtends() {
+	indexByID := make(map[string]int)
+
+	for i, test := range ts.Tests {
+		if test.ID != "" {
+			indexByID[test.ID] = i
+		}
+	}
+
+	resolved := make(map[int]bool)
+
+	var resolve func(i int)
+
+	resolve = func(i int) {
+		if resolved[i] {
+			return
+		}
+
+		resolved[i] = true
+
+		test := &ts.Tests[i]
+		if test.Extends == "" {
+			return
+		}
+
+		parentIndex, ok := indexByID[test.Extends]
+		if !ok {
+			return
+		}
+
+		resolve(parentIndex)
+
+		parent := ts.Tests[parentIndex]
+		test.MergeCommon(Common{
+			Inputs:     parent.Inputs,
+			Patches:    parent.Patches,
+			Hooks:      parent.Hooks,
+			Assertions: parent.Assertions,
+		})
+	}
+
+	for i := range ts.Tests {
+		resolve(i)
+	}
+}
+
+// TimeoutDuration returns the timeout of the testsuite, or 0 if it has none. The timeout must be valid (see CheckValidTestSuiteFile).
+func (ts *TestSuiteSpec) TimeoutDuration() time.Duration {
+	timeout, _ := ParseTimeout(ts.Timeout)
+	return timeout
+}
+
 // HasCommonPatches returns true if any common patches are set in the test suite.
 func (ts *TestSuiteSpec) HasCommonPatches() bool {
 	return ts.Common.Patches.HasPatches()
@@ -245,6 +707,7 @@ func (ts *TestSuiteSpec) HasCommonAssertions() bool {
 // HasCommon returns true if any common inputs are set in the test suite spec.
 func (ts *TestSuiteSpec) HasCommon() bool {
 	return ts.Common.Inputs.XR != "" ||
+		ts.Common.Inputs.XRFromXRD != nil ||
 		ts.Common.Inputs.Claim != "" ||
 		ts.Common.Inputs.Composition != "" ||
 		ts.Common.Inputs.Functions != "" ||
@@ -254,19 +717,35 @@ func (ts *TestSuiteSpec) HasCommon() bool {
 		ts.Common.Inputs.ObservedResources != "" ||
 		ts.Common.Inputs.ExtraResources != "" ||
 		ts.Common.Inputs.FunctionCredentials != "" ||
+		ts.Common.Inputs.Inline.HasInlineInputs() ||
 		ts.HasCommonPatches() ||
 		ts.HasCommonHooks() ||
 		ts.HasCommonAssertions()
 }
 
-// HasXR returns true if the TestCase has an XR field specified.
+// HasXR returns true if the TestCase has an XR field specified, either as a path or inline.
 func (tc *TestCase) HasXR() bool {
-	return tc.Inputs.XR != ""
+	return tc.Inputs.XR != "" || tc.Inputs.Inline.XR != nil
 }
 
-// HasClaim returns true if the TestCase has a Claim field specified.
+// HasXRFromXRD returns true if the TestCase generates its XRs from an XRD.
+func (tc *TestCase) HasXRFromXRD() bool {
+	return tc.Inputs.XRFromXRD != nil
+}
+
+// XRGeneration returns how the XRs of the TestCase are generated from an XRD, like MergeCommon merges it: its own
+// xr-from-xrd, or else the one of common when the test case sets no XR, or nil if they are not generated.
+func (tc *TestCase) XRGeneration(common Common) *XRFromXRD {
+	if tc.HasXRFromXRD() || tc.HasXR() {
+		return tc.Inputs.XRFromXRD
+	}
+
+	return common.Inputs.XRFromXRD
+}
+
+// HasClaim returns true if the TestCase has a Claim field specified, either as a path or inline.
 func (tc *TestCase) HasClaim() bool {
-	return tc.Inputs.Claim != ""
+	return tc.Inputs.Claim != "" || tc.Inputs.Inline.Claim != nil
 }
 
 // HasPatches checks if any patches are set in the test case.
@@ -309,16 +788,42 @@ func (tc *TestCase) HasAssertions() bool {
 	return tc.Assertions.HasAssertions()
 }
 
+// IsSkipped returns true if the test case is marked as skipped.
+func (tc *TestCase) IsSkipped() bool {
+	return tc.Skip != ""
+}
+
+// TimeoutDuration returns the timeout of the test case, or 0 if it has none. The timeout must be valid (see CheckValidTestSuiteFile).
+func (tc *TestCase) TimeoutDuration() time.Duration {
+	timeout, _ := ParseTimeout(tc.Timeout)
+	return timeout
+}
+
+// IsExpectedToFail returns true if the test case is marked as expected to fail.
+func (tc *TestCase) IsExpectedToFail() bool {
+	return tc.XFail != ""
+}
+
 // MergeCommon merges common inputs and patches into the test case.
+// Lists and maps of a section are appended to or merged with the common ones when the section's
+// merge strategy (set in the test case, or else in common) is append, otherwise they replace them.
 //
 //nolint:gocognit // too many ifs, but not that complex
 func (tc *TestCase) MergeCommon(common Common) {
-	if tc.Inputs.XR == "" {
+	inputsStrategy := effectiveMergeStrategy(tc.Inputs.MergeStrategy, common.Inputs.MergeStrategy)
+
+	// Inputs that can be given inline are inherited from common only when the test case sets neither form.
+	inline := copyInlineInputs(common.Inputs.Inline)
+
+	if !tc.HasXR() && !tc.HasXRFromXRD() {
 		tc.Inputs.XR = common.Inputs.XR
+		tc.Inputs.Inline.XR = inline.XR
+		tc.Inputs.XRFromXRD = common.Inputs.XRFromXRD
 	}
 
-	if tc.Inputs.Claim == "" {
+	if !tc.HasClaim() {
 		tc.Inputs.Claim = common.Inputs.Claim
+		tc.Inputs.Inline.Claim = inline.Claim
 	}
 
 	if tc.Inputs.Composition == "" {
@@ -329,27 +834,30 @@ func (tc *TestCase) MergeCommon(common Common) {
 		tc.Inputs.Functions = common.Inputs.Functions
 	}
 
-	if len(tc.Inputs.CRDs) == 0 && len(common.Inputs.CRDs) > 0 {
-		tc.Inputs.CRDs = make([]string, len(common.Inputs.CRDs))
-		copy(tc.Inputs.CRDs, common.Inputs.CRDs)
-	}
+	tc.Inputs.CRDs = mergeList(tc.Inputs.CRDs, common.Inputs.CRDs, inputsStrategy)
 
-	if len(tc.Inputs.ContextFiles) == 0 && len(common.Inputs.ContextFiles) > 0 {
-		tc.Inputs.ContextFiles = make(map[string]string)
-		maps.Copy(tc.Inputs.ContextFiles, common.Inputs.ContextFiles)
-	}
+	switch {
+	case inputsStrategy == MergeStrategyAppend:
+		tc.Inputs.ContextFiles, tc.Inputs.Inline.ContextFiles = mergeContextFiles(tc.Inputs, common.Inputs)
+	case len(tc.Inputs.ContextFiles) == 0 && len(tc.Inputs.Inline.ContextFiles) == 0:
+		if len(common.Inputs.ContextFiles) > 0 {
+			tc.Inputs.ContextFiles = make(map[string]string)
+			maps.Copy(tc.Inputs.ContextFiles, common.Inputs.ContextFiles)
+		}
 
-	if len(tc.Inputs.ContextValues) == 0 && len(common.Inputs.ContextValues) > 0 {
-		tc.Inputs.ContextValues = make(map[string]string)
-		maps.Copy(tc.Inputs.ContextValues, common.Inputs.ContextValues)
+		tc.Inputs.Inline.ContextFiles = inline.ContextFiles
 	}
 
-	if tc.Inputs.ObservedResources == "" {
+	tc.Inputs.ContextValues = mergeMap(tc.Inputs.ContextValues, common.Inputs.ContextValues, inputsStrategy)
+
+	if tc.Inputs.ObservedResources == "" && tc.Inputs.Inline.ObservedResources == nil {
 		tc.Inputs.ObservedResources = common.Inputs.ObservedResources
+		tc.Inputs.Inline.ObservedResources = inline.ObservedResources
 	}
 
-	if tc.Inputs.ExtraResources == "" {
+	if tc.Inputs.ExtraResources == "" && tc.Inputs.Inline.ExtraResources == nil {
 		tc.Inputs.ExtraResources = common.Inputs.ExtraResources
+		tc.Inputs.Inline.ExtraResources = inline.ExtraResources
 	}
 
 	if tc.Inputs.FunctionCredentials == "" {
@@ -358,6 +866,8 @@ func (tc *TestCase) MergeCommon(common Common) {
 
 	// Always merge patches if common has patches
 	if common.Patches.HasPatches() {
+		patchesStrategy := effectiveMergeStrategy(tc.Patches.MergeStrategy, common.Patches.MergeStrategy)
+
 		if tc.Patches.XRD == "" {
 			tc.Patches.XRD = common.Patches.XRD
 		}
@@ -373,34 +883,103 @@ func (tc *TestCase) MergeCommon(common Common) {
 		if tc.Patches.ConnectionSecretNamespace == "" {
 			tc.Patches.ConnectionSecretNamespace = common.Patches.ConnectionSecretNamespace
 		}
+
+		tc.Patches.Set = mergeList(tc.Patches.Set, common.Patches.Set, patchesStrategy)
+		tc.Patches.Merge = mergeList(tc.Patches.Merge, common.Patches.Merge, patchesStrategy)
+		tc.Patches.JSONPatch = mergeList(tc.Patches.JSONPatch, common.Patches.JSONPatch, patchesStrategy)
 	}
 
-	// Always merge hooks if common has hooks
+	// Always merge hooks if common has hooks; with append, common hooks run before the test case ones.
 	if common.Hooks.HasHooks() {
-		if !tc.HasPreTestHooks() {
-			tc.Hooks.PreTest = common.Hooks.PreTest
-		}
+		hooksStrategy := effectiveMergeStrategy(tc.Hooks.MergeStrategy, common.Hooks.MergeStrategy)
 
-		if !tc.HasPostTestHooks() {
-			tc.Hooks.PostTest = common.Hooks.PostTest
-		}
+		tc.Hooks.PreTest = mergeList(tc.Hooks.PreTest, common.Hooks.PreTest, hooksStrategy)
+		tc.Hooks.PostTest = mergeList(tc.Hooks.PostTest, common.Hooks.PostTest, hooksStrategy)
 	}
 
 	// Merge assertions per engine: if common has assertions for an engine and the test case does not, use common's.
-	if common.Assertions.HasAssertionsXprin() && !tc.HasAssertionsXprin() {
-		tc.Assertions.Xprin = make([]AssertionXprin, len(common.Assertions.Xprin))
-		copy(tc.Assertions.Xprin, common.Assertions.Xprin)
+	assertionsStrategy := effectiveMergeStrategy(tc.Assertions.MergeStrategy, common.Assertions.MergeStrategy)
+
+	tc.Assertions.Xprin = mergeList(tc.Assertions.Xprin, common.Assertions.Xprin, assertionsStrategy)
+	tc.Assertions.Diff = mergeList(tc.Assertions.Diff, common.Assertions.Diff, assertionsStrategy)
+	tc.Assertions.Dyff = mergeList(tc.Assertions.Dyff, common.Assertions.Dyff, assertionsStrategy)
+}
+
+// effectiveMergeStrategy returns the test case merge strategy if set, otherwise the common one.
+func effectiveMergeStrategy(testCase, common MergeStrategy) MergeStrategy {
+	if testCase != "" {
+		return testCase
+	}
+
+	return common
+}
+
+// mergeList returns a copy of the common items followed by the test case items when the strategy is append,
+// a copy of the common items when the test case has none, and the test case items otherwise.
+func mergeList[T any](testCase, common []T, strategy MergeStrategy) []T {
+	if len(common) == 0 {
+		return testCase
+	}
+
+	if len(testCase) == 0 || strategy == MergeStrategyAppend {
+		return append(slices.Clone(common), testCase...)
+	}
+
+	return testCase
+}
+
+// mergeMap returns the common entries overridden by the test case entries when the strategy is append,
+// a copy of the common entries when the test case has none, and the test case entries otherwise.
+func mergeMap[V any](testCase, common map[string]V, strategy MergeStrategy) map[string]V {
+	if len(common) == 0 {
+		return testCase
+	}
+
+	if len(testCase) == 0 || strategy == MergeStrategyAppend {
+		merged := maps.Clone(common)
+		maps.Copy(merged, testCase)
+
+		return merged
+	}
+
+	return testCase
+}
+
+// mergeContextFiles merges the common context files (paths and inline) with the test case ones.
+// A test case key overrides the common key, whether either of them is a path or inline.
+func mergeContextFiles(testCase, common Inputs) (map[string]string, map[string]map[string]any) {
+	paths := maps.Clone(common.ContextFiles)
+	inline := copyInlineInputs(common.Inline).ContextFiles
+
+	for key, path := range testCase.ContextFiles {
+		delete(inline, key)
+
+		if paths == nil {
+			paths = make(map[string]string)
+		}
+
+		paths[key] = path
 	}
 
-	if common.Assertions.HasAssertionsDiff() && !tc.HasAssertionsDiff() {
-		tc.Assertions.Diff = make([]AssertionGoldenFile, len(common.Assertions.Diff))
-		copy(tc.Assertions.Diff, common.Assertions.Diff)
+	for key, object := range testCase.Inline.ContextFiles {
+		delete(paths, key)
+
+		if inline == nil {
+			inline = make(map[string]map[string]any)
+		}
+
+		inline[key] = object
 	}
 
-	if common.Assertions.HasAssertionsDyff() && !tc.HasAssertionsDyff() {
-		tc.Assertions.Dyff = make([]AssertionGoldenFile, len(common.Assertions.Dyff))
-		copy(tc.Assertions.Dyff, common.Assertions.Dyff)
+	if len(paths) == 0 {
+		paths = nil
 	}
+
+	if len(inline) == 0 {
+		inline = nil
+	}
+
+	return paths, inline
 }
 
 // CheckMandatoryFields checks if all mandatory fields are present in the test case.
@@ -411,8 +990,8 @@ func (tc *TestCase) CheckMandatoryFields() error {
 		allErrors = append(allErrors, "conflicting fields: both 'claim' and 'xr' are specified, but only one is allowed")
 	}
 
-	if !tc.HasClaim() && !tc.HasXR() {
-		allErrors = append(allErrors, "missing mandatory field: either 'claim' or 'xr' must be specified (it can be specified either in the test case or in the common inputs)")
+	if !tc.HasClaim() && !tc.HasXR() && !tc.HasXRFromXRD() {
+		allErrors = append(allErrors, "missing mandatory field: either 'claim', 'xr' or 'xr-from-xrd' must be specified (it can be specified either in the test case or in the common inputs)")
 	}
 
 	if tc.Inputs.Composition == "" {
@@ -18,6 +18,7 @@ package api
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
 	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
@@ -151,6 +152,27 @@ func TestPatches_hasPatches(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "Set patches",
+			patches: Patches{
+				Set: []FieldSet{{Path: "spec.replicas", Value: 3}},
+			},
+			expected: true,
+		},
+		{
+			name: "Merge patches",
+			patches: Patches{
+				Merge: []map[string]any{{"spec": map[string]any{"replicas": 3}}},
+			},
+			expected: true,
+		},
+		{
+			name: "JSON patches",
+			patches: Patches{
+				JSONPatch: []JSONPatchOperation{{Op: "remove", Path: "/spec/replicas"}},
+			},
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -243,6 +265,204 @@ func TestCheckValidTestSuiteFile(t *testing.T) {
 			wantErr:   true,
 			errSubstr: []string{"duplicate test case ID 'test1' found"},
 		},
+		{
+			name: "valid extends",
+			spec: &TestSuiteSpec{
+				Tests: []TestCase{
+					{Name: "Base", ID: "base", Inputs: Inputs{XR: "xr.yaml"}},
+					{Name: "Child", ID: "child", Extends: "base"},
+					{Name: "Grandchild", Extends: "child"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "extends unknown test case ID",
+			spec: &TestSuiteSpec{
+				Tests: []TestCase{
+					{Name: "Child", Extends: "missing"},
+				},
+			},
+			wantErr:   true,
+			errSubstr: []string{"test case 'Child' extends unknown test case ID 'missing'"},
+		},
+		{
+			name: "extends cycles",
+			spec: &TestSuiteSpec{
+				Tests: []TestCase{
+					{Name: "Self", ID: "self", Extends: "self"},
+					{Name: "A", ID: "a", Extends: "b"},
+					{Name: "B", ID: "b", Extends: "c"},
+					{Name: "C", ID: "c", Extends: "a"},
+					{Name: "D", ID: "d", Extends: "a"},
+				},
+			},
+			wantErr: true,
+			errSubstr: []string{
+				"extends cycle detected: self -> self",
+				"extends cycle detected: a -> b -> c -> a",
+			},
+		},
+		{
+			name: "needs unknown test case ID",
+			spec: &TestSuiteSpec{
+				Tests: []TestCase{
+					{Name: "Producer", ID: "producer"},
+					{Name: "Consumer", Needs: []string{"producer", "missing"}},
+				},
+			},
+			wantErr:   true,
+			errSubstr: []string{"test case 'Consumer' needs unknown test case ID 'missing'"},
+		},
+		{
+			name: "needs cycles",
+			spec: &TestSuiteSpec{
+				Tests: []TestCase{
+					{Name: "A", ID: "a", Needs: []string{"b"}},
+					{Name: "B", ID: "b", Needs: []string{"a"}},
+					{Name: "Self", ID: "self", Needs: []string{"self"}},
+				},
+			},
+			wantErr: true,
+			errSubstr: []string{
+				"needs cycle detected: a -> b -> a",
+				"needs cycle detected: self -> self",
+			},
+		},
+		{
+			name: "skip and xfail",
+			spec: &TestSuiteSpec{
+				Tests: []TestCase{
+					{Name: "Skipped", Skip: "not ready"},
+					{Name: "Expected failure", XFail: "known bug"},
+					{Name: "Both", Skip: "not ready", XFail: "known bug"},
+				},
+			},
+			wantErr:   true,
+			errSubstr: []string{"test case 'Both' cannot have both skip and xfail"},
+		},
+		{
+			name: "valid timeouts",
+			spec: &TestSuiteSpec{
+				Timeout: "10m",
+				Tests: []TestCase{
+					{
+						Name:    "Test 1",
+						Timeout: "1m30s",
+						Hooks:   Hooks{PreTest: []Hook{{Run: "true", Timeout: "500ms"}}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid timeouts",
+			spec: &TestSuiteSpec{
+				Timeout:  "ten minutes",
+				HookSets: map[string]Hooks{"setup": {PreTest: []Hook{{Run: "true", Timeout: "1"}}}},
+				Common: Common{
+					Hooks: Hooks{PostTest: []Hook{{Run: "true", Timeout: "0s"}}},
+				},
+				Tests: []TestCase{
+					{
+						Name:    "Test 1",
+						Timeout: "-1m",
+						Hooks:   Hooks{PreTest: []Hook{{Run: "true", Timeout: "1x"}}},
+					},
+				},
+			},
+			wantErr: true,
+			errSubstr: []string{
+				`testsuite has invalid timeout 'ten minutes': time: invalid duration "ten minutes"`,
+				`hook set 'setup' has a hook with invalid timeout '1': time: missing unit in duration "1"`,
+				"common has a hook with invalid timeout '0s': must be positive",
+				"test case 'Test 1' has invalid timeout '-1m': must be positive",
+				`test case 'Test 1' has a hook with invalid timeout '1x': time: unknown unit "x" in duration "1x"`,
+			},
+		},
+		{
+			name: "valid merge strategies",
+			spec: &TestSuiteSpec{
+				Common: Common{
+					Hooks: Hooks{MergeStrategy: MergeStrategyAppend},
+				},
+				Tests: []TestCase{
+					{
+						Name:       "Test 1",
+						Inputs:     Inputs{XR: "xr.yaml", MergeStrategy: MergeStrategyAppend},
+						Assertions: Assertions{MergeStrategy: MergeStrategyReplace},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid merge strategies",
+			spec: &TestSuiteSpec{
+				Common: Common{
+					Patches: Patches{MergeStrategy: "prepend"},
+				},
+				Tests: []TestCase{
+					{
+						Name:   "Test 1",
+						Inputs: Inputs{XR: "xr.yaml"},
+						Hooks:  Hooks{MergeStrategy: "merge"},
+					},
+				},
+			},
+			wantErr: true,
+			errSubstr: []string{
+				"common has invalid patches merge-strategy 'prepend' (allowed: replace, append)",
+				"test case 'Test 1' has invalid hooks merge-strategy 'merge' (allowed: replace, append)",
+			},
+		},
+		{
+			name: "valid xr-from-xrd in common and test case",
+			spec: &TestSuiteSpec{
+				Common: Common{
+					Inputs: Inputs{XRFromXRD: &XRFromXRD{XRD: "xrd.yaml"}},
+				},
+				Tests: []TestCase{
+					{Name: "Test 1"},
+					{Name: "Test 2", Inputs: Inputs{XRFromXRD: &XRFromXRD{XRD: "other-xrd.yaml", Modes: []XRGenerationMode{XRGenerationModeRandom}, Count: 10, Seed: 42}}},
+					{Name: "Test 3", ID: "test3", Inputs: Inputs{XR: "xr.yaml"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid xr-from-xrd",
+			spec: &TestSuiteSpec{
+				Common: Common{
+					Inputs: Inputs{XR: "xr.yaml", XRFromXRD: &XRFromXRD{Modes: []XRGenerationMode{"all"}}},
+				},
+				Tests: []TestCase{
+					{Name: "Test 1", ID: "test1", Inputs: Inputs{Claim: "claim.yaml", XRFromXRD: &XRFromXRD{XRD: "xrd.yaml", Count: -1}}},
+				},
+			},
+			wantErr: true,
+			errSubstr: []string{
+				"common has xr-from-xrd without xrd",
+				"common has invalid xr-from-xrd mode 'all' (allowed: minimal, maximal, boundary, random)",
+				"common cannot have xr-from-xrd together with claim or xr",
+				"test case 'Test 1' has invalid xr-from-xrd count -1 (must not be negative)",
+				"test case 'Test 1' cannot have xr-from-xrd together with claim or xr",
+				"test case 'Test 1' cannot have an id because it runs once per XR of xr-from-xrd",
+			},
+		},
+		{
+			name: "claim with xr-from-xrd inherited from common",
+			spec: &TestSuiteSpec{
+				Common: Common{
+					Inputs: Inputs{XRFromXRD: &XRFromXRD{XRD: "xrd.yaml"}},
+				},
+				Tests: []TestCase{
+					{Name: "Test 1", Inputs: Inputs{Claim: "claim.yaml"}},
+				},
+			},
+			wantErr:   true,
+			errSubstr: []string{"test case 'Test 1' cannot have xr-from-xrd together with claim or xr"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -261,6 +481,42 @@ func TestCheckValidTestSuiteFile(t *testing.T) {
 	}
 }
 
+func TestCheckValidTestSuiteFile_Paths(t *testing.T) {
+	spec := &TestSuiteSpec{
+		Timeout: "5x",
+		HookSets: map[string]Hooks{
+			"setup": {PostTest: []Hook{{Run: "true"}, {Run: "true", Timeout: "-1s"}}},
+		},
+		Tests: []TestCase{
+			{Name: "a", ID: "a", Needs: []string{"b", "missing"}},
+			{Name: "b", ID: "b", Needs: []string{"a"}, Extends: "other"},
+			{ID: "c!", Skip: "flaky", XFail: "broken"},
+		},
+	}
+
+	err := spec.CheckValidTestSuiteFile()
+
+	var testSuiteErrors TestSuiteErrors
+	require.ErrorAs(t, err, &testSuiteErrors)
+
+	var paths [][]any
+	for _, testSuiteError := range testSuiteErrors {
+		paths = append(paths, testSuiteError.Path)
+	}
+
+	assert.Equal(t, [][]any{
+		{"timeout"},
+		{"hook-sets", "setup", "post-test", 1, "timeout"},
+		{"tests", 2, "name"},
+		{"tests", 2, "id"},
+		{"tests", 2, "xfail"},
+		{"tests", 1, "extends"},
+		{"tests", 0, "needs", 1},
+		{"tests", 0, "needs"},
+	}, paths)
+	assert.Contains(t, err.Error(), "invalid testsuite file:\n- testsuite has invalid timeout '5x'")
+}
+
 func TestTestSuiteSpec_hasCommonPatches(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -439,6 +695,50 @@ func TestTestCase_hasClaim(t *testing.T) {
 	}
 }
 
+func TestTestCase_xrGeneration(t *testing.T) {
+	commonXRFromXRD := &XRFromXRD{XRD: "common-xrd.yaml"}
+	ownXRFromXRD := &XRFromXRD{XRD: "xrd.yaml"}
+
+	tests := []struct {
+		name     string
+		inputs   Inputs
+		expected *XRFromXRD
+	}{
+		{
+			name:     "own xr-from-xrd",
+			inputs:   Inputs{XRFromXRD: ownXRFromXRD},
+			expected: ownXRFromXRD,
+		},
+		{
+			name:     "inherited from common",
+			inputs:   Inputs{},
+			expected: commonXRFromXRD,
+		},
+		{
+			name:     "own XR",
+			inputs:   Inputs{XR: "xr.yaml"},
+			expected: nil,
+		},
+		{
+			name:     "own inline XR",
+			inputs:   Inputs{Inline: InlineInputs{XR: map[string]any{"kind": "XR"}}},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			common := Common{Inputs: Inputs{XRFromXRD: commonXRFromXRD, Composition: "composition.yaml"}}
+
+			testCase := TestCase{Inputs: tt.inputs}
+			assert.Same(t, tt.expected, testCase.XRGeneration(common))
+
+			testCase.MergeCommon(common)
+			assert.Same(t, tt.expected, testCase.Inputs.XRFromXRD, "MergeCommon merges xr-from-xrd the same way")
+		})
+	}
+}
+
 func TestTestCase_hasPatches(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1260,6 +1560,32 @@ func TestTestCase_mergeCommon(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "field patches from common used only when test case has none of the same kind",
+			testCase: TestCase{
+				Name:   "test21",
+				Inputs: Inputs{XR: "xr.yaml"},
+				Patches: Patches{
+					Set: []FieldSet{{Path: "spec.replicas", Value: 3}},
+				},
+			},
+			common: Common{
+				Patches: Patches{
+					Set:       []FieldSet{{Path: "spec.replicas", Value: 1}},
+					Merge:     []map[string]any{{"spec": map[string]any{"region": "eu-west-1"}}},
+					JSONPatch: []JSONPatchOperation{{Op: "remove", Path: "/spec/debug"}},
+				},
+			},
+			expected: TestCase{
+				Name:   "test21",
+				Inputs: Inputs{XR: "xr.yaml"},
+				Patches: Patches{
+					Set:       []FieldSet{{Path: "spec.replicas", Value: 3}},
+					Merge:     []map[string]any{{"spec": map[string]any{"region": "eu-west-1"}}},
+					JSONPatch: []JSONPatchOperation{{Op: "remove", Path: "/spec/debug"}},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1298,6 +1624,15 @@ func TestTestCase_checkMandatoryFields(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid TestCase with xr-from-xrd field",
+			inputs: Inputs{
+				XRFromXRD:   &XRFromXRD{XRD: "xrd.yaml"},
+				Composition: "composition.yaml",
+				Functions:   "functions.yaml",
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid TestCase - missing both Claim and XR",
 			inputs: Inputs{
@@ -1305,7 +1640,7 @@ func TestTestCase_checkMandatoryFields(t *testing.T) {
 				Functions:   "functions.yaml",
 			},
 			wantErr: true,
-			errMsg:  "missing mandatory field: either 'claim' or 'xr' must be specified",
+			errMsg:  "missing mandatory field: either 'claim', 'xr' or 'xr-from-xrd' must be specified",
 		},
 		{
 			name: "invalid TestCase - both Claim and XR specified",
@@ -1342,7 +1677,7 @@ func TestTestCase_checkMandatoryFields(t *testing.T) {
 			name:    "invalid TestCase - multiple missing fields",
 			inputs:  Inputs{},
 			wantErr: true,
-			errMsg:  "missing mandatory field: either 'claim' or 'xr' must be specified",
+			errMsg:  "missing mandatory field: either 'claim', 'xr' or 'xr-from-xrd' must be specified",
 		},
 		{
 			name: "invalid TestCase with empty strings (should be treated as missing)",
@@ -1353,7 +1688,7 @@ func TestTestCase_checkMandatoryFields(t *testing.T) {
 				Functions:   "",
 			},
 			wantErr: true,
-			errMsg:  "missing mandatory field: either 'claim' or 'xr' must be specified",
+			errMsg:  "missing mandatory field: either 'claim', 'xr' or 'xr-from-xrd' must be specified",
 		},
 		{
 			name: "valid TestCase with Claim and empty XR",
@@ -1904,3 +2239,238 @@ func TestTestCase_hasAssertions(t *testing.T) {
 		})
 	}
 }
+
+func TestTestCase_mergeCommonAppend(t *testing.T) {
+	commonInputs := Inputs{
+		XR:            "xr.yaml",
+		CRDs:          []string{"common-crd.yaml"},
+		ContextFiles:  map[string]string{"shared": "shared.json", "override": "common.json"},
+		ContextValues: map[string]string{"region": "eu-west-1", "env": "common"},
+		Inline: InlineInputs{
+			ContextFiles: map[string]map[string]any{"inline-shared": {"a": "b"}},
+		},
+	}
+
+	commonHooks := Hooks{
+		PreTest:  []Hook{{Name: "common setup", Run: "echo setup"}},
+		PostTest: []Hook{{Name: "common cleanup", Run: "echo cleanup"}},
+	}
+
+	commonAssertions := Assertions{
+		Xprin: []AssertionXprin{{Name: "common-count", Type: "Count", Value: 2}},
+	}
+
+	commonPatches := Patches{
+		Set: []FieldSet{{Path: "spec.region", Value: "eu-west-1"}},
+	}
+
+	tests := []struct {
+		name     string
+		testCase TestCase
+		common   Common
+		expected TestCase
+	}{
+		{
+			name: "append strategy in test case merges lists and maps with common",
+			testCase: TestCase{
+				Name: "test1",
+				Inputs: Inputs{
+					CRDs:          []string{"test-crd.yaml"},
+					ContextValues: map[string]string{"env": "test"},
+					ContextFiles:  map[string]string{"inline-shared": "test.json"},
+					Inline: InlineInputs{
+						ContextFiles: map[string]map[string]any{"override": {"c": "d"}},
+					},
+					MergeStrategy: MergeStrategyAppend,
+				},
+				Patches: Patches{
+					Set:           []FieldSet{{Path: "spec.size", Value: "large"}},
+					MergeStrategy: MergeStrategyAppend,
+				},
+				Hooks: Hooks{
+					PreTest:       []Hook{{Name: "test setup", Run: "echo test"}},
+					MergeStrategy: MergeStrategyAppend,
+				},
+				Assertions: Assertions{
+					Xprin:         []AssertionXprin{{Name: "test-exists", Type: "Exists", Resource: "Bucket/b"}},
+					MergeStrategy: MergeStrategyAppend,
+				},
+			},
+			common: Common{Inputs: commonInputs, Patches: commonPatches, Hooks: commonHooks, Assertions: commonAssertions},
+			expected: TestCase{
+				Name: "test1",
+				Inputs: Inputs{
+					XR:            "xr.yaml",
+					CRDs:          []string{"common-crd.yaml", "test-crd.yaml"},
+					ContextValues: map[string]string{"region": "eu-west-1", "env": "test"},
+					ContextFiles:  map[string]string{"shared": "shared.json", "inline-shared": "test.json"},
+					Inline: InlineInputs{
+						ContextFiles: map[string]map[string]any{"override": {"c": "d"}},
+					},
+					MergeStrategy: MergeStrategyAppend,
+				},
+				Patches: Patches{
+					Set:           []FieldSet{{Path: "spec.region", Value: "eu-west-1"}, {Path: "spec.size", Value: "large"}},
+					MergeStrategy: MergeStrategyAppend,
+				},
+				Hooks: Hooks{
+					PreTest:       []Hook{{Name: "common setup", Run: "echo setup"}, {Name: "test setup", Run: "echo test"}},
+					PostTest:      []Hook{{Name: "common cleanup", Run: "echo cleanup"}},
+					MergeStrategy: MergeStrategyAppend,
+				},
+				Assertions: Assertions{
+					Xprin: []AssertionXprin{
+						{Name: "common-count", Type: "Count", Value: 2},
+						{Name: "test-exists", Type: "Exists", Resource: "Bucket/b"},
+					},
+					MergeStrategy: MergeStrategyAppend,
+				},
+			},
+		},
+		{
+			name: "append strategy in common applies to test cases that do not set one",
+			testCase: TestCase{
+				Name:  "test2",
+				Hooks: Hooks{PostTest: []Hook{{Name: "test cleanup", Run: "echo test"}}},
+			},
+			common: Common{Hooks: Hooks{
+				PreTest:       commonHooks.PreTest,
+				PostTest:      commonHooks.PostTest,
+				MergeStrategy: MergeStrategyAppend,
+			}},
+			expected: TestCase{
+				Name: "test2",
+				Hooks: Hooks{
+					PreTest:  []Hook{{Name: "common setup", Run: "echo setup"}},
+					PostTest: []Hook{{Name: "common cleanup", Run: "echo cleanup"}, {Name: "test cleanup", Run: "echo test"}},
+				},
+			},
+		},
+		{
+			name: "replace strategy in test case overrides append strategy in common",
+			testCase: TestCase{
+				Name: "test3",
+				Assertions: Assertions{
+					Xprin:         []AssertionXprin{{Name: "test-exists", Type: "Exists", Resource: "Bucket/b"}},
+					MergeStrategy: MergeStrategyReplace,
+				},
+			},
+			common: Common{Assertions: Assertions{
+				Xprin:         commonAssertions.Xprin,
+				MergeStrategy: MergeStrategyAppend,
+			}},
+			expected: TestCase{
+				Name: "test3",
+				Assertions: Assertions{
+					Xprin:         []AssertionXprin{{Name: "test-exists", Type: "Exists", Resource: "Bucket/b"}},
+					MergeStrategy: MergeStrategyReplace,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCase := tt.testCase
+			testCase.MergeCommon(tt.common)
+			assert.Equal(t, tt.expected, testCase)
+		})
+	}
+}
+
+func TestTestSuiteSpec_resolveExtends(t *testing.T) {
+	spec := &TestSuiteSpec{
+		Tests: []TestCase{
+			{
+				Name:    "Grandchild",
+				Extends: "child",
+				Inputs:  Inputs{CRDs: []string{"extra-crd.yaml"}, MergeStrategy: MergeStrategyAppend},
+			},
+			{
+				Name:    "Child",
+				ID:      "child",
+				Extends: "base",
+				Inputs:  Inputs{Claim: "other-claim.yaml"},
+				Assertions: Assertions{
+					Xprin:         []AssertionXprin{{Name: "extra", Type: "Exists", Resource: "Bucket/b"}},
+					MergeStrategy: MergeStrategyAppend,
+				},
+			},
+			{
+				Name: "Base",
+				ID:   "base",
+				Inputs: Inputs{
+					Claim:       "claim.yaml",
+					Composition: "composition.yaml",
+					CRDs:        []string{"crd.yaml"},
+				},
+				Patches: Patches{XRD: "xrd.yaml"},
+				Assertions: Assertions{
+					Xprin: []AssertionXprin{{Name: "count", Type: "Count", Value: 3}},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, spec.CheckValidTestSuiteFile())
+	spec.ResolveExtends()
+
+	child := spec.Tests[1]
+	assert.Equal(t, "Child", child.Name)
+	assert.Equal(t, "child", child.ID)
+	assert.Equal(t, "other-claim.yaml", child.Inputs.Claim)
+	assert.Equal(t, "composition.yaml", child.Inputs.Composition)
+	assert.Equal(t, "xrd.yaml", child.Patches.XRD)
+	assert.Equal(t, []AssertionXprin{
+		{Name: "count", Type: "Count", Value: 3},
+		{Name: "extra", Type: "Exists", Resource: "Bucket/b"},
+	}, child.Assertions.Xprin)
+
+	grandchild := spec.Tests[0]
+	assert.Equal(t, "other-claim.yaml", grandchild.Inputs.Claim)
+	assert.Equal(t, []string{"crd.yaml", "extra-crd.yaml"}, grandchild.Inputs.CRDs)
+	assert.Equal(t, child.Assertions.Xprin, grandchild.Assertions.Xprin)
+
+	// The base test case is unchanged
+	assert.Equal(t, []AssertionXprin{{Name: "count", Type: "Count", Value: 3}}, spec.Tests[2].Assertions.Xprin)
+}
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeout  string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{name: "no timeout", timeout: "", expected: 0},
+		{name: "seconds", timeout: "30s", expected: 30 * time.Second},
+		{name: "combined units", timeout: "1m30s", expected: 90 * time.Second},
+		{name: "zero", timeout: "0s", wantErr: true},
+		{name: "negative", timeout: "-5s", wantErr: true},
+		{name: "missing unit", timeout: "30", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout, err := ParseTimeout(tt.timeout)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, timeout)
+		})
+	}
+}
+
+func TestTimeoutDuration(t *testing.T) {
+	spec := TestSuiteSpec{Timeout: "10m"}
+	testCase := TestCase{Timeout: "2m"}
+	hook := Hook{Timeout: "30s"}
+
+	assert.Equal(t, 10*time.Minute, spec.TimeoutDuration())
+	assert.Equal(t, 2*time.Minute, testCase.TimeoutDuration())
+	assert.Equal(t, 30*time.Second, hook.TimeoutDuration())
+	assert.Equal(t, time.Duration(0), (&TestCase{}).TimeoutDuration())
+}
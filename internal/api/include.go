@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// Include represents a fragment file included by a testsuite file or by another fragment.
+// It can be given as a plain path, or as an object with a path and a namespace.
+type Include struct {
+	Path      string `json:"path"`                // Path to the fragment file, relative to the including file (Required)
+	Namespace string `json:"namespace,omitempty"` // Namespace of the fragment's assertion and hook sets (Optional, defaults to the file name without extension)
+}
+
+// GetNamespace returns the namespace of the included fragment, defaulting to its file name without extension.
+func (i Include) GetNamespace() string {
+	if i.Namespace != "" {
+		return i.Namespace
+	}
+
+	base := filepath.Base(i.Path)
+
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// UnmarshalJSON decodes an Include given either as a path or as an object.
+func (i *Include) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		*i = Include{}
+		return json.Unmarshal(data, &i.Path)
+	}
+
+	type plainInclude Include
+
+	var include plainInclude
+	if err := json.Unmarshal(data, &include); err != nil {
+		return fmt.Errorf("invalid include: must be a path or an object with a path: %w", err)
+	}
+
+	*i = Include(include)
+
+	return nil
+}
+
+// JSONSchema describes an Include as either a path or an object with a path and a namespace.
+func (Include) JSONSchema() *jsonschema.Schema {
+	properties := jsonschema.NewProperties()
+	properties.Set("path", &jsonschema.Schema{Type: "string", Description: "Path to the fragment file, relative to the including file"})
+	properties.Set("namespace", &jsonschema.Schema{Type: "string", Description: "Namespace of the fragment's assertion and hook sets (defaults to the file name without extension)"})
+
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{Type: "string"},
+			{
+				Type:                 "object",
+				Properties:           properties,
+				Required:             []string{"path"},
+				AdditionalProperties: jsonschema.FalseSchema,
+			},
+		},
+	}
+}
+
+// HasSets returns true if the assertions reference any assertion sets.
+func (a *Assertions) HasSets() bool {
+	return len(a.Sets) > 0
+}
+
+// ResolveSets prepends the assertions of the referenced assertion sets, in the order they are referenced.
+func (a *Assertions) ResolveSets(sets map[string]Assertions) error {
+	if !a.HasSets() {
+		return nil
+	}
+
+	var resolved Assertions
+
+	for _, name := range a.Sets {
+		set, ok := sets[name]
+		if !ok {
+			return fmt.Errorf("unknown assertion set '%s'", name)
+		}
+
+		resolved.Xprin = append(resolved.Xprin, set.Xprin...)
+		resolved.Diff = append(resolved.Diff, set.Diff...)
+		resolved.Dyff = append(resolved.Dyff, set.Dyff...)
+	}
+
+	a.Xprin = append(resolved.Xprin, a.Xprin...)
+	a.Diff = append(resolved.Diff, a.Diff...)
+	a.Dyff = append(resolved.Dyff, a.Dyff...)
+	a.Sets = nil
+
+	return nil
+}
+
+// HasSets returns true if the hooks reference any hook sets.
+func (h *Hooks) HasSets() bool {
+	return len(h.Sets) > 0
+}
+
+// ResolveSets prepends the hooks of the referenced hook sets, in the order they are referenced.
+func (h *Hooks) ResolveSets(sets map[string]Hooks) error {
+	if !h.HasSets() {
+		return nil
+	}
+
+	var resolved Hooks
+
+	for _, name := range h.Sets {
+		set, ok := sets[name]
+		if !ok {
+			return fmt.Errorf("unknown hook set '%s'", name)
+		}
+
+		resolved.PreTest = append(resolved.PreTest, set.PreTest...)
+		resolved.PostTest = append(resolved.PostTest, set.PostTest...)
+	}
+
+	h.PreTest = append(resolved.PreTest, h.PreTest...)
+	h.PostTest = append(resolved.PostTest, h.PostTest...)
+	h.Sets = nil
+
+	return nil
+}
+
+// MergeCommon merges another common configuration into this one, with the values of this one taking precedence.
+// It follows the same rules, including the merge strategies, as merging common into a test case. The merge strategy
+// of each section is kept, from this one or else from the other one, so that the test cases are merged with the
+// result as the files set it.
+func (c *Common) MergeCommon(other Common) {
+	tc := TestCase{Inputs: c.Inputs, Patches: c.Patches, Hooks: c.Hooks, Assertions: c.Assertions}
+	tc.MergeCommon(other)
+
+	c.Inputs, c.Patches, c.Hooks, c.Assertions = tc.Inputs, tc.Patches, tc.Hooks, tc.Assertions
+
+	c.Inputs.MergeStrategy = effectiveMergeStrategy(c.Inputs.MergeStrategy, other.Inputs.MergeStrategy)
+	c.Patches.MergeStrategy = effectiveMergeStrategy(c.Patches.MergeStrategy, other.Patches.MergeStrategy)
+	c.Hooks.MergeStrategy = effectiveMergeStrategy(c.Hooks.MergeStrategy, other.Hooks.MergeStrategy)
+	c.Assertions.MergeStrategy = effectiveMergeStrategy(c.Assertions.MergeStrategy, other.Assertions.MergeStrategy)
+}
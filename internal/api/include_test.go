@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	"sigs.k8s.io/yaml"
+)
+
+func TestInclude_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name              string
+		yaml              string
+		expected          []Include
+		expectedNamespace []string
+		errContains       string
+	}{
+		{
+			name: "paths and objects",
+			yaml: `
+include:
+- ../shared/common.yaml
+- path: assertions/standard.yml
+  namespace: std
+- path: hooks.yaml
+`,
+			expected: []Include{
+				{Path: "../shared/common.yaml"},
+				{Path: "assertions/standard.yml", Namespace: "std"},
+				{Path: "hooks.yaml"},
+			},
+			expectedNamespace: []string{"common", "std", "hooks"},
+		},
+		{
+			name:        "invalid include",
+			yaml:        "include:\n- 3\n",
+			errContains: "invalid include: must be a path or an object with a path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec TestSuiteSpec
+
+			err := yaml.Unmarshal([]byte(tt.yaml), &spec)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, spec.Include)
+
+			for i, include := range spec.Include {
+				assert.Equal(t, tt.expectedNamespace[i], include.GetNamespace())
+			}
+		})
+	}
+}
+
+func TestAssertions_ResolveSets(t *testing.T) {
+	sets := map[string]Assertions{
+		"std.count": {Xprin: []AssertionXprin{{Name: "count", Type: "Count", Value: 2}}},
+		"std.golden": {
+			Diff: []AssertionGoldenFile{{Name: "diff", Expected: "golden.yaml"}},
+			Dyff: []AssertionGoldenFile{{Name: "dyff", Expected: "golden.yaml"}},
+		},
+	}
+
+	assertions := Assertions{
+		Sets:  []string{"std.golden", "std.count"},
+		Xprin: []AssertionXprin{{Name: "own", Type: "Exists", Resource: "Bucket/b"}},
+	}
+	require.NoError(t, assertions.ResolveSets(sets))
+
+	assert.Equal(t, Assertions{
+		Xprin: []AssertionXprin{
+			{Name: "count", Type: "Count", Value: 2},
+			{Name: "own", Type: "Exists", Resource: "Bucket/b"},
+		},
+		Diff: []AssertionGoldenFile{{Name: "diff", Expected: "golden.yaml"}},
+		Dyff: []AssertionGoldenFile{{Name: "dyff", Expected: "golden.yaml"}},
+	}, assertions)
+
+	unknown := Assertions{Sets: []string{"std.missing"}}
+	assert.EqualError(t, unknown.ResolveSets(sets), "unknown assertion set 'std.missing'")
+}
+
+func TestHooks_ResolveSets(t *testing.T) {
+	sets := map[string]Hooks{
+		"setup": {
+			PreTest:  []Hook{{Name: "setup", Run: "echo setup"}},
+			PostTest: []Hook{{Name: "teardown", Run: "echo teardown"}},
+		},
+	}
+
+	hooks := Hooks{
+		Sets:    []string{"setup"},
+		PreTest: []Hook{{Name: "own", Run: "echo own"}},
+	}
+	require.NoError(t, hooks.ResolveSets(sets))
+
+	assert.Equal(t, Hooks{
+		PreTest:  []Hook{{Name: "setup", Run: "echo setup"}, {Name: "own", Run: "echo own"}},
+		PostTest: []Hook{{Name: "teardown", Run: "echo teardown"}},
+	}, hooks)
+
+	unknown := Hooks{Sets: []string{"missing"}}
+	assert.EqualError(t, unknown.ResolveSets(sets), "unknown hook set 'missing'")
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/invopop/jsonschema"
+)
+
+// InlineInputs holds the inputs that were given inline as YAML objects instead of file paths.
+// The runner writes them to the temporary inputs directory before render and replaces them with the written paths.
+type InlineInputs struct {
+	Claim             map[string]any            // Inline Claim document
+	XR                map[string]any            // Inline XR document
+	ObservedResources []map[string]any          // Inline observed resources (one or more documents)
+	ExtraResources    []map[string]any          // Inline extra resources (one or more documents)
+	ContextFiles      map[string]map[string]any // Map of context keys to inline context documents
+}
+
+// HasInlineInputs returns true if any input is given inline.
+func (i *InlineInputs) HasInlineInputs() bool {
+	return i.Claim != nil ||
+		i.XR != nil ||
+		i.ObservedResources != nil ||
+		i.ExtraResources != nil ||
+		len(i.ContextFiles) > 0
+}
+
+// UnmarshalJSON decodes Inputs, accepting either a path or an inline YAML object for
+// claim, xr, observed-resources, extra-resources and each context-files entry.
+func (in *Inputs) UnmarshalJSON(data []byte) error {
+	type plainInputs Inputs
+
+	// The fields below shadow their path-only counterparts in plainInputs and keep the raw JSON form.
+	var raw struct {
+		plainInputs
+		Claim             json.RawMessage            `json:"claim,omitempty"`
+		XR                json.RawMessage            `json:"xr,omitempty"`
+		ObservedResources json.RawMessage            `json:"observed-resources,omitempty"`
+		ExtraResources    json.RawMessage            `json:"extra-resources,omitempty"`
+		ContextFiles      map[string]json.RawMessage `json:"context-files,omitempty"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*in = Inputs(raw.plainInputs)
+
+	var err error
+
+	if in.Claim, in.Inline.Claim, err = decodePathOrObject(raw.Claim, "claim"); err != nil {
+		return err
+	}
+
+	if in.XR, in.Inline.XR, err = decodePathOrObject(raw.XR, "xr"); err != nil {
+		return err
+	}
+
+	if in.ObservedResources, in.Inline.ObservedResources, err = decodePathOrDocuments(raw.ObservedResources, "observed-resources"); err != nil {
+		return err
+	}
+
+	if in.ExtraResources, in.Inline.ExtraResources, err = decodePathOrDocuments(raw.ExtraResources, "extra-resources"); err != nil {
+		return err
+	}
+
+	for key, value := range raw.ContextFiles {
+		path, object, err := decodePathOrObject(value, fmt.Sprintf("context-files.%s", key))
+		if err != nil {
+			return err
+		}
+
+		if object != nil {
+			if in.Inline.ContextFiles == nil {
+				in.Inline.ContextFiles = make(map[string]map[string]any)
+			}
+
+			in.Inline.ContextFiles[key] = object
+
+			continue
+		}
+
+		if in.ContextFiles == nil {
+			in.ContextFiles = make(map[string]string)
+		}
+
+		in.ContextFiles[key] = path
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes Inputs, writing inline inputs back under the same keys as their path counterparts.
+func (in Inputs) MarshalJSON() ([]byte, error) {
+	type plainInputs Inputs
+
+	var encoded struct {
+		plainInputs
+		Claim             any            `json:"claim,omitempty"`
+		XR                any            `json:"xr,omitempty"`
+		ObservedResources any            `json:"observed-resources,omitempty"`
+		ExtraResources    any            `json:"extra-resources,omitempty"`
+		ContextFiles      map[string]any `json:"context-files,omitempty"`
+	}
+
+	encoded.plainInputs = plainInputs(in)
+	encoded.Claim = pathOrInline(in.Claim, in.Inline.Claim)
+	encoded.XR = pathOrInline(in.XR, in.Inline.XR)
+	encoded.ObservedResources = pathOrInline(in.ObservedResources, in.Inline.ObservedResources)
+	encoded.ExtraResources = pathOrInline(in.ExtraResources, in.Inline.ExtraResources)
+
+	if len(in.ContextFiles) > 0 || len(in.Inline.ContextFiles) > 0 {
+		encoded.ContextFiles = make(map[string]any, len(in.ContextFiles)+len(in.Inline.ContextFiles))
+		for key, path := range in.ContextFiles {
+			encoded.ContextFiles[key] = path
+		}
+
+		for key, object := range in.Inline.ContextFiles {
+			encoded.ContextFiles[key] = object
+		}
+	}
+
+	return json.Marshal(encoded)
+}
+
+// JSONSchemaExtend describes the context-files values as either a path or an inline object.
+func (Inputs) JSONSchemaExtend(schema *jsonschema.Schema) {
+	contextFiles, ok := schema.Properties.Get("context-files")
+	if !ok {
+		return
+	}
+
+	contextFiles.AdditionalProperties = &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{{Type: "string"}, {Type: "object"}},
+	}
+}
+
+// pathOrInline returns the inline value when set, otherwise the path (nil when both are empty, so omitempty applies).
+func pathOrInline[T map[string]any | []map[string]any](path string, inline T) any {
+	if inline != nil {
+		return inline
+	}
+
+	if path != "" {
+		return path
+	}
+
+	return nil
+}
+
+// decodePathOrObject decodes a raw value that is either a path string or an inline object.
+func decodePathOrObject(raw json.RawMessage, field string) (string, map[string]any, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return "", nil, nil
+	}
+
+	switch raw[0] {
+	case '"':
+		var path string
+		if err := json.Unmarshal(raw, &path); err != nil {
+			return "", nil, fmt.Errorf("invalid %s: %w", field, err)
+		}
+
+		return path, nil, nil
+	case '{':
+		object, err := decodeObject(raw)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid inline %s: %w", field, err)
+		}
+
+		return "", object, nil
+	default:
+		return "", nil, fmt.Errorf("invalid %s: must be a path or an inline object", field)
+	}
+}
+
+// decodePathOrDocuments decodes a raw value that is either a path string, an inline object or a list of inline objects.
+func decodePathOrDocuments(raw json.RawMessage, field string) (string, []map[string]any, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || raw[0] != '[' {
+		path, object, err := decodePathOrObject(raw, field)
+		if err != nil || object == nil {
+			return path, nil, err
+		}
+
+		return "", []map[string]any{object}, nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return "", nil, fmt.Errorf("invalid inline %s: %w", field, err)
+	}
+
+	documents := make([]map[string]any, 0, len(items))
+	for i, item := range items {
+		object, err := decodeObject(item)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid inline %s[%d]: %w", field, i, err)
+		}
+
+		documents = append(documents, object)
+	}
+
+	return "", documents, nil
+}
+
+// decodeObject decodes a JSON object, keeping numbers as json.Number so that they are written back unchanged.
+func decodeObject(raw json.RawMessage) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	if object == nil {
+		return nil, fmt.Errorf("must be an object")
+	}
+
+	return object, nil
+}
+
+// copyInlineInputs returns a copy of the inline inputs with their own top-level maps and slices.
+func copyInlineInputs(i InlineInputs) InlineInputs {
+	out := InlineInputs{
+		Claim: maps.Clone(i.Claim),
+		XR:    maps.Clone(i.XR),
+	}
+
+	if i.ObservedResources != nil {
+		out.ObservedResources = append([]map[string]any(nil), i.ObservedResources...)
+	}
+
+	if i.ExtraResources != nil {
+		out.ExtraResources = append([]map[string]any(nil), i.ExtraResources...)
+	}
+
+	if i.ContextFiles != nil {
+		out.ContextFiles = maps.Clone(i.ContextFiles)
+	}
+
+	return out
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	"sigs.k8s.io/yaml"
+)
+
+func TestInputs_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		yaml        string
+		expected    Inputs
+		errContains string
+	}{
+		{
+			name: "paths only",
+			yaml: `
+xr: xr.yaml
+composition: comp.yaml
+functions: functions.yaml
+context-files:
+  key1: context.json
+observed-resources: observed.yaml
+extra-resources: extra.yaml
+`,
+			expected: Inputs{
+				XR:                "xr.yaml",
+				Composition:       "comp.yaml",
+				Functions:         "functions.yaml",
+				ContextFiles:      map[string]string{"key1": "context.json"},
+				ObservedResources: "observed.yaml",
+				ExtraResources:    "extra.yaml",
+			},
+		},
+		{
+			name: "inline XR and claim",
+			yaml: `
+xr:
+  apiVersion: example.org/v1
+  kind: XExample
+  spec:
+    replicas: 3
+claim:
+  apiVersion: example.org/v1
+  kind: Example
+`,
+			expected: Inputs{
+				Inline: InlineInputs{
+					XR: map[string]any{
+						"apiVersion": "example.org/v1",
+						"kind":       "XExample",
+						"spec":       map[string]any{"replicas": json.Number("3")},
+					},
+					Claim: map[string]any{
+						"apiVersion": "example.org/v1",
+						"kind":       "Example",
+					},
+				},
+			},
+		},
+		{
+			name: "inline observed resources as single object and extra resources as list",
+			yaml: `
+observed-resources:
+  kind: Bucket
+extra-resources:
+- kind: EnvironmentConfig
+- kind: Secret
+`,
+			expected: Inputs{
+				Inline: InlineInputs{
+					ObservedResources: []map[string]any{{"kind": "Bucket"}},
+					ExtraResources:    []map[string]any{{"kind": "EnvironmentConfig"}, {"kind": "Secret"}},
+				},
+			},
+		},
+		{
+			name: "mixed context files",
+			yaml: `
+context-files:
+  from-file: context.json
+  apiextensions.crossplane.io/environment:
+    region: eu-west-1
+`,
+			expected: Inputs{
+				ContextFiles: map[string]string{"from-file": "context.json"},
+				Inline: InlineInputs{
+					ContextFiles: map[string]map[string]any{
+						"apiextensions.crossplane.io/environment": {"region": "eu-west-1"},
+					},
+				},
+			},
+		},
+		{
+			name:        "invalid XR type",
+			yaml:        "xr: 3\n",
+			errContains: "invalid xr: must be a path or an inline object",
+		},
+		{
+			name:        "invalid extra resources list item",
+			yaml:        "extra-resources:\n- foo\n",
+			errContains: "invalid inline extra-resources[0]",
+		},
+		{
+			name:        "invalid context file value",
+			yaml:        "context-files:\n  key1:\n  - foo\n",
+			errContains: "invalid context-files.key1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inputs Inputs
+
+			err := yaml.Unmarshal([]byte(tt.yaml), &inputs)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, inputs)
+		})
+	}
+}
+
+func TestInputs_MarshalJSON_RoundTrip(t *testing.T) {
+	content := `
+inputs:
+  xr:
+    apiVersion: example.org/v1
+    kind: XExample
+    spec:
+      size: 10
+  composition: comp.yaml
+  context-files:
+    from-file: context.json
+    inline-key:
+      foo: bar
+  observed-resources:
+  - kind: Bucket
+name: inline test
+`
+
+	var testCase TestCase
+	require.NoError(t, yaml.Unmarshal([]byte(content), &testCase))
+
+	data, err := yaml.Marshal(testCase)
+	require.NoError(t, err)
+
+	var roundTripped TestCase
+	require.NoError(t, yaml.Unmarshal(data, &roundTripped))
+
+	assert.Equal(t, testCase, roundTripped)
+	assert.Contains(t, string(data), "size: 10")
+	assert.Contains(t, string(data), "from-file: context.json")
+}
+
+func TestTestCase_mergeCommonInline(t *testing.T) {
+	commonXR := map[string]any{"kind": "XCommon"}
+	testXR := map[string]any{"kind": "XTest"}
+
+	tests := []struct {
+		name     string
+		testCase TestCase
+		common   Common
+		expected Inputs
+	}{
+		{
+			name:     "inline XR inherited from common",
+			testCase: TestCase{Name: "test1"},
+			common: Common{Inputs: Inputs{
+				Inline: InlineInputs{XR: commonXR},
+			}},
+			expected: Inputs{Inline: InlineInputs{XR: commonXR}},
+		},
+		{
+			name: "test case XR path overrides common inline XR",
+			testCase: TestCase{Name: "test2", Inputs: Inputs{
+				XR: "xr.yaml",
+			}},
+			common: Common{Inputs: Inputs{
+				Inline: InlineInputs{XR: commonXR},
+			}},
+			expected: Inputs{XR: "xr.yaml"},
+		},
+		{
+			name: "test case inline XR overrides common XR path",
+			testCase: TestCase{Name: "test3", Inputs: Inputs{
+				Inline: InlineInputs{XR: testXR},
+			}},
+			common:   Common{Inputs: Inputs{XR: "common-xr.yaml"}},
+			expected: Inputs{Inline: InlineInputs{XR: testXR}},
+		},
+		{
+			name: "test case inline context files override common context files",
+			testCase: TestCase{Name: "test4", Inputs: Inputs{
+				Inline: InlineInputs{ContextFiles: map[string]map[string]any{"key2": {"a": "b"}}},
+			}},
+			common: Common{Inputs: Inputs{ContextFiles: map[string]string{"key1": "context.json"}}},
+			expected: Inputs{
+				Inline: InlineInputs{ContextFiles: map[string]map[string]any{"key2": {"a": "b"}}},
+			},
+		},
+		{
+			name:     "inline observed and extra resources inherited from common",
+			testCase: TestCase{Name: "test5"},
+			common: Common{Inputs: Inputs{
+				Inline: InlineInputs{
+					ObservedResources: []map[string]any{{"kind": "Bucket"}},
+					ExtraResources:    []map[string]any{{"kind": "Secret"}},
+				},
+			}},
+			expected: Inputs{Inline: InlineInputs{
+				ObservedResources: []map[string]any{{"kind": "Bucket"}},
+				ExtraResources:    []map[string]any{{"kind": "Secret"}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.testCase.MergeCommon(tt.common)
+			assert.Equal(t, tt.expected, tt.testCase.Inputs)
+		})
+	}
+}
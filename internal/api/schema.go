@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/invopop/jsonschema"
+)
+
+// ReflectSchema returns the JSON schema of testsuite files, reflected by r from TestSuiteSpec.
+func ReflectSchema(r *jsonschema.Reflector) *jsonschema.Schema {
+	schema := r.Reflect(&TestSuiteSpec{})
+	schema.Title = "xprin"
+	schema.Description = "xprin test suite files (xprin.yaml or *_xprin.yaml), apiVersion " + APIVersion
+
+	return schema
+}
+
+// Schema returns the JSON schema of testsuite files as a JSON object: the generated data/xprin-testsuite.json, with
+// the descriptions of the Go comments of the source files (see commentMap).
+func Schema() (map[string]any, error) {
+	data, err := json.Marshal(ReflectSchema(&jsonschema.Reflector{CommentMap: commentMap}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema: %w", err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schema: %w", err)
+	}
+
+	return schema, nil
+}
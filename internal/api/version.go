@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Group is the API group of testsuite files.
+	Group = "xprin.crossplane.io"
+	// APIVersion is the current version of the testsuite file format.
+	APIVersion = Group + "/v1alpha1"
+	// Kind is the kind of testsuite files and of the fragments they include.
+	Kind = "TestSuite"
+)
+
+// TypeMeta is the header of a testsuite file or fragment, which identifies the version of its format. It is optional
+// for now: files without it are read as the current version, which is deprecated.
+type TypeMeta struct {
+	APIVersion string `json:"apiVersion,omitempty" jsonschema:"enum=xprin.crossplane.io/v1alpha1"` // Version of the testsuite file format, e.g. "xprin.crossplane.io/v1alpha1" (Optional, will be required in a future version)
+	Kind       string `json:"kind,omitempty"       jsonschema:"enum=TestSuite"`                    // Kind of the file, "TestSuite" (Optional, required with apiVersion)
+}
+
+// SupportedAPIVersions returns the versions of the testsuite file format that this version of xprin reads.
+func SupportedAPIVersions() []string {
+	return []string{APIVersion}
+}
+
+// ParseTypeMeta reads the header of a testsuite file or fragment, ignoring its other fields, so that it can be checked
+// before the file is parsed as a version of the format it might not be.
+func ParseTypeMeta(data []byte) (TypeMeta, error) {
+	var meta TypeMeta
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return meta, fmt.Errorf("failed to parse apiVersion and kind: %w", err)
+	}
+
+	return meta, nil
+}
+
+// IsVersioned returns true if the header sets an apiVersion.
+func (m TypeMeta) IsVersioned() bool {
+	return m.APIVersion != ""
+}
+
+// Check returns an error if the header is not of a version of the format that this version of xprin reads, so that
+// files written for another version are rejected instead of misread. A file without a header is accepted.
+func (m TypeMeta) Check() error {
+	supported := SupportedAPIVersions()
+
+	switch {
+	case m.APIVersion == "" && m.Kind == "":
+		return nil
+	case m.APIVersion == "":
+		return fmt.Errorf("kind '%s' is set without apiVersion, add 'apiVersion: %s'", m.Kind, APIVersion)
+	case strings.HasPrefix(m.APIVersion, Group+"/") && !slices.Contains(supported, m.APIVersion):
+		return fmt.Errorf("apiVersion '%s' is not supported by this version of xprin (supported: %s), upgrade xprin to read this file",
+			m.APIVersion, strings.Join(supported, ", "))
+	case !slices.Contains(supported, m.APIVersion):
+		return fmt.Errorf("unsupported apiVersion '%s' (supported: %s)", m.APIVersion, strings.Join(supported, ", "))
+	case m.Kind == "":
+		return fmt.Errorf("kind is required with apiVersion, add 'kind: %s'", Kind)
+	case m.Kind != Kind:
+		return fmt.Errorf("unsupported kind '%s' for apiVersion %s (must be %s)", m.Kind, m.APIVersion, Kind)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestTypeMetaCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		wantVersioned bool
+		wantErr       string
+	}{
+		{
+			name:          "current version",
+			data:          "apiVersion: xprin.crossplane.io/v1alpha1\nkind: TestSuite\ntests: []\n",
+			wantVersioned: true,
+		},
+		{
+			name: "no header",
+			data: "tests: []\n",
+		},
+		{
+			name:    "kind without apiVersion",
+			data:    "kind: TestSuite\n",
+			wantErr: "kind 'TestSuite' is set without apiVersion, add 'apiVersion: xprin.crossplane.io/v1alpha1'",
+		},
+		{
+			name:          "newer version",
+			data:          "apiVersion: xprin.crossplane.io/v1beta1\nkind: TestSuite\n",
+			wantVersioned: true,
+			wantErr:       "apiVersion 'xprin.crossplane.io/v1beta1' is not supported by this version of xprin (supported: xprin.crossplane.io/v1alpha1), upgrade xprin to read this file",
+		},
+		{
+			name:          "other group",
+			data:          "apiVersion: apiextensions.crossplane.io/v1\nkind: Composition\n",
+			wantVersioned: true,
+			wantErr:       "unsupported apiVersion 'apiextensions.crossplane.io/v1' (supported: xprin.crossplane.io/v1alpha1)",
+		},
+		{
+			name:          "wrong kind",
+			data:          "apiVersion: xprin.crossplane.io/v1alpha1\nkind: Suite\n",
+			wantVersioned: true,
+			wantErr:       "unsupported kind 'Suite' for apiVersion xprin.crossplane.io/v1alpha1 (must be TestSuite)",
+		},
+		{
+			name:          "missing kind",
+			data:          "apiVersion: xprin.crossplane.io/v1alpha1\n",
+			wantVersioned: true,
+			wantErr:       "kind is required with apiVersion, add 'kind: TestSuite'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := ParseTypeMeta([]byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersioned, meta.IsVersioned())
+
+			err = meta.Check()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Code generated by schema-gen. DO NOT EDIT.
+
+package api
+
+// commentMap holds the Go comments of the types of testsuite files, the descriptions of their JSON schema.
+var commentMap = map[string]string{
+	"github.com/crossplane-contrib/xprin/internal/api.AssertionGoldenFile":               "AssertionGoldenFile represents a single golden-file assertion (compare actual output to expected file; used by diff and dyff).",
+	"github.com/crossplane-contrib/xprin/internal/api.AssertionGoldenFile.Expected":      "Path to golden (expected) file (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.AssertionGoldenFile.Name":          "Descriptive name for the assertion (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.AssertionGoldenFile.Resource":      "Resource identifier for resource-based assertions (format: Kind/Name e.g. \"Cluster/platform-aws-rds\") (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.AssertionXprin":                    "AssertionXprin represents a single xprin assertion (single-resource or Count).",
+	"github.com/crossplane-contrib/xprin/internal/api.AssertionXprin.Field":              "Field path for field-based assertions (e.g., \"metadata.name\") (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.AssertionXprin.Name":               "Descriptive name for the assertion (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.AssertionXprin.Operator":           "Operator for field value assertions (== or is) (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.AssertionXprin.Resource":           "Resource identifier for resource-based assertions (format: Kind/Name e.g. \"Cluster/platform-aws-rds\") (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.AssertionXprin.Type":               "Type of assertion (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.AssertionXprin.Value":              "Expected value for the assertion (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Assertions":                        "Assertions represents assertions grouped by execution engine.",
+	"github.com/crossplane-contrib/xprin/internal/api.Assertions.Diff":                   "diff assertions (go-native compare to golden file) (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Assertions.Dyff":                   "dyff assertions (dyff between expected and actual) (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Assertions.MergeStrategy":          "How assertions are merged with common (Optional, default: replace)",
+	"github.com/crossplane-contrib/xprin/internal/api.Assertions.Sets":                   "Names of assertion sets whose assertions are added to these ones (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Assertions.Xprin":                  "xprin assertions (in-process) (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Common":                            "Common represents the common configuration for a testsuite file.",
+	"github.com/crossplane-contrib/xprin/internal/api.Common.Assertions":                 "Common assertions to validate rendered resources for all testcases (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Common.Hooks":                      "Common hooks for all testcases (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Common.Inputs":                     "Common inputs (composition, Claim/XR, etc.) for all testcases (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Common.Patches":                    "Common XR patching configuration for all testcases (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.FieldSet":                          "FieldSet represents a single field of the XR to set.",
+	"github.com/crossplane-contrib/xprin/internal/api.FieldSet.Path":                     "Crossplane fieldpath of the field to set (e.g. \"spec.parameters.tags[0]\") (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.FieldSet.Value":                    "Value to set the field to (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.Hook":                              "Hook represents a single executable step with optional metadata.",
+	"github.com/crossplane-contrib/xprin/internal/api.Hook.Name":                         "Descriptive name for the hook (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Hook.Run":                          "Command to run (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.Hook.Timeout":                      "Timeout for the hook, as a duration (e.g. \"30s\") (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Hooks":                             "Hooks represents the execution hooks configuration.",
+	"github.com/crossplane-contrib/xprin/internal/api.Hooks.MergeStrategy":               "How hooks are merged with common (Optional, default: replace)",
+	"github.com/crossplane-contrib/xprin/internal/api.Hooks.PostTest":                    "Hooks that are executed after the testcase (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Hooks.PreTest":                     "Hooks that are executed before the testcase (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Hooks.Sets":                        "Names of hook sets whose hooks run before these ones (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Include":                           "Include represents a fragment file included by a testsuite file or by another fragment.",
+	"github.com/crossplane-contrib/xprin/internal/api.Include.Namespace":                 "Namespace of the fragment's assertion and hook sets (Optional, defaults to the file name without extension)",
+	"github.com/crossplane-contrib/xprin/internal/api.Include.Path":                      "Path to the fragment file, relative to the including file (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.InlineInputs":                      "InlineInputs holds the inputs that were given inline as YAML objects instead of file paths.",
+	"github.com/crossplane-contrib/xprin/internal/api.InlineInputs.Claim":                "Inline Claim document",
+	"github.com/crossplane-contrib/xprin/internal/api.InlineInputs.ContextFiles":         "Map of context keys to inline context documents",
+	"github.com/crossplane-contrib/xprin/internal/api.InlineInputs.ExtraResources":       "Inline extra resources (one or more documents)",
+	"github.com/crossplane-contrib/xprin/internal/api.InlineInputs.ObservedResources":    "Inline observed resources (one or more documents)",
+	"github.com/crossplane-contrib/xprin/internal/api.InlineInputs.XR":                   "Inline XR document",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs":                            "Inputs represents the inputs for a test case or common configuration.",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.CRDs":                       "Paths to CRD files (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.Claim":                      "Path to Claim file or inline Claim (one of Claim or XR must be set, either in the test case or in the common inputs)",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.Composition":                "Path to composition file (Required unless specified in the common inputs)",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.ContextFiles":               "Map of context keys to file paths or inline objects (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.ContextValues":              "Map of context keys to inline values (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.ExtraResources":             "Path to extra resources file or inline resources (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.FunctionCredentials":        "Path to function credentials file (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.Functions":                  "Path to functions file or directory (Required unless specified in the common inputs)",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.Inline":                     "Inputs given inline instead of as paths",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.MergeStrategy":              "How crds, context-files and context-values are merged with common (Optional, default: replace)",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.ObservedResources":          "Path to observed resources file or inline resources (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.XR":                         "Path to XR file or inline XR (one of Claim or XR must be set, either in the test case or in the common inputs)",
+	"github.com/crossplane-contrib/xprin/internal/api.Inputs.XRFromXRD":                  "XRs generated from the schema of an XRD, one test case per XR (Optional, instead of Claim or XR)",
+	"github.com/crossplane-contrib/xprin/internal/api.JSONPatchOperation":                "JSONPatchOperation represents a single RFC 6902 JSON patch operation.",
+	"github.com/crossplane-contrib/xprin/internal/api.JSONPatchOperation.From":           "JSON pointer to the source location for move and copy (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.JSONPatchOperation.Op":             "Operation to perform (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.JSONPatchOperation.Path":           "JSON pointer to the target location (e.g. \"/spec/parameters/region\") (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.JSONPatchOperation.Value":          "Value for add, replace and test (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.MergeStrategy":                     "MergeStrategy controls how the lists and maps of a test case section are merged with the same section in common.",
+	"github.com/crossplane-contrib/xprin/internal/api.Patches":                           "Patches represents XR patching configuration.",
+	"github.com/crossplane-contrib/xprin/internal/api.Patches.ConnectionSecret":          "When true, create a connection secret for the XR (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Patches.ConnectionSecretName":      "Name of the connection secret (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Patches.ConnectionSecretNamespace": "Namespace of the connection secret (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Patches.JSONPatch":                 "JSON patch operations (RFC 6902) to apply to the XR (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Patches.Merge":                     "JSON merge patches (RFC 7386) to apply to the XR (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Patches.MergeStrategy":             "How set, merge and jsonpatch are merged with common (Optional, default: replace)",
+	"github.com/crossplane-contrib/xprin/internal/api.Patches.Set":                       "Fields to set on the XR using Crossplane fieldpaths (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.Patches.XRD":                       "Path to the XR's or Claim's XRD (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestCase":                          "TestCase represents a single test case.",
+	"github.com/crossplane-contrib/xprin/internal/api.TestCase.Assertions":               "Assertions to validate rendered resources (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestCase.Extends":                  "ID of a testcase to inherit inputs, patches, hooks and assertions from (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestCase.Hooks":                    "Execution hooks (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestCase.ID":                       "Unique identifier for the testcase (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestCase.Inputs":                   "Inputs of a testcase (Required unless specified in the common inputs)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestCase.Name":                     "Descriptive name for the testcase (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestCase.Needs":                    "IDs of testcases that must pass before this testcase runs (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestCase.Patches":                  "XR patching configuration (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestCase.Skip":                     "Reason for skipping the testcase; the testcase does not run when set (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestCase.Timeout":                  "Timeout for the testcase including its hooks, as a duration (e.g. \"2m\") (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestCase.XFail":                    "Reason for expecting the testcase to fail; the testcase passes when it fails and fails when it passes (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestSuiteError":                    "TestSuiteError is an error about a value of a testsuite file.",
+	"github.com/crossplane-contrib/xprin/internal/api.TestSuiteError.Path":               "Keys and list indexes of the value in the testsuite file, e.g. \"tests\", 0, \"id\"",
+	"github.com/crossplane-contrib/xprin/internal/api.TestSuiteErrors":                   "TestSuiteErrors is the list of all errors of a testsuite file.",
+	"github.com/crossplane-contrib/xprin/internal/api.TestSuiteSpec":                     "TestSuiteSpec represents the structure of a testsuite YAML file used by xprin.",
+	"github.com/crossplane-contrib/xprin/internal/api.TestSuiteSpec.AssertionSets":       "Named assertion sets that can be referenced from common and test cases (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestSuiteSpec.Common":              "Common config for all tests (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestSuiteSpec.Env":                 "Names of the environment variables available in templates as .Env (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestSuiteSpec.HookSets":            "Named hook sets that can be referenced from common and test cases (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestSuiteSpec.Include":             "Fragment files whose common config and sets are included (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestSuiteSpec.Tests":               "List of test cases (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestSuiteSpec.Timeout":             "Timeout for all testcases of the testsuite file together, as a duration (e.g. \"10m\") (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TestSuiteSpec.Vars":                "Variables available in templates as .Vars, overridden by --var (Optional)",
+	"github.com/crossplane-contrib/xprin/internal/api.TypeMeta":                          "TypeMeta is the header of a testsuite file or fragment, which identifies the version of its format.",
+	"github.com/crossplane-contrib/xprin/internal/api.TypeMeta.APIVersion":               "Version of the testsuite file format, e.g. \"xprin.crossplane.io/v1alpha1\" (Optional, will be required in a future version)",
+	"github.com/crossplane-contrib/xprin/internal/api.TypeMeta.Kind":                     "Kind of the file, \"TestSuite\" (Optional, required with apiVersion)",
+	"github.com/crossplane-contrib/xprin/internal/api.XRFromXRD":                         "XRFromXRD represents XRs generated from the OpenAPI schema of an XRD.",
+	"github.com/crossplane-contrib/xprin/internal/api.XRFromXRD.Count":                   "Number of random XRs (Optional, default: 5)",
+	"github.com/crossplane-contrib/xprin/internal/api.XRFromXRD.Modes":                   "Kinds of XRs to generate (Optional, default: all of them)",
+	"github.com/crossplane-contrib/xprin/internal/api.XRFromXRD.Seed":                    "Seed of the random XRs (Optional, default: 0)",
+	"github.com/crossplane-contrib/xprin/internal/api.XRFromXRD.XRD":                     "Path to the XRD (Required)",
+	"github.com/crossplane-contrib/xprin/internal/api.XRGenerationMode":                  "XRGenerationMode is a kind of XRs generated from the schema of an XRD.",
+}
@@ -116,7 +116,8 @@ func Load(fs afero.Fs, configPath string) (*Config, error) {
 	return &cfg, nil
 }
 
-// Fallback returns a config that uses only binaries from PATH.
+// Fallback returns a config that uses only binaries from PATH. It returns the config with the dependencies it found
+// even if mandatory dependencies are missing, with an error, so that the commands that do not run them can still run.
 func Fallback() (*Config, error) {
 	mandatoryDeps := []string{CrossplaneCmd}
 
@@ -133,17 +134,19 @@ func Fallback() (*Config, error) {
 		}
 	}
 
-	// Fail only if mandatory dependencies are missing
-	if len(missingMandatoryDeps) > 0 {
-		return nil, fmt.Errorf("missing required dependencies from PATH (%s)", strings.Join(missingMandatoryDeps, ", "))
-	}
-
-	return &Config{
+	cfg := &Config{
 		Dependencies: foundDeps,
 		Subcommands: &Subcommands{
 			Render:   DefaultRenderCmd,
 			Validate: DefaultValidateCmd,
 		},
 		Repositories: make(map[string]string),
-	}, nil
+	}
+
+	// Fail only if mandatory dependencies are missing
+	if len(missingMandatoryDeps) > 0 {
+		return cfg, fmt.Errorf("missing required dependencies from PATH (%s)", strings.Join(missingMandatoryDeps, ", "))
+	}
+
+	return cfg, nil
 }
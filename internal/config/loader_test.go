@@ -403,15 +403,19 @@ func TestFallback(t *testing.T) {
 	// Case 1: No mandatory dependencies -> fail
 	t.Setenv("PATH", tmpDir)
 
-	_, err := Fallback()
+	cfg, err := Fallback()
 	if err == nil {
 		t.Error("Expected error when no mandatory dependencies are present")
 	}
 
+	if cfg == nil || len(cfg.Dependencies) != 0 || cfg.Subcommands == nil {
+		t.Errorf("Expected the fallback config without dependencies, got: %+v", cfg)
+	}
+
 	// Case 2: Only mandatory dependencies (crossplane) -> success
 	createBin(crossplanePath)
 
-	cfg, err := Fallback()
+	cfg, err = Fallback()
 	if err != nil {
 		t.Errorf("Expected no error when mandatory dependency is present, got: %v", err)
 	}
@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gertd/go-pluralize"
+)
+
+// slowestTestCases is the number of slowest test cases listed in the run summary.
+const slowestTestCases = 5
+
+// TestSuiteError is a testsuite file that could not be run, e.g. because it is invalid.
+type TestSuiteError struct {
+	FilePath string
+	Err      error
+}
+
+// RunSummary aggregates the results of all testsuite files of a run. A nil RunSummary ignores all results.
+type RunSummary struct {
+	TestSuites []*TestSuiteResult
+	Errors     []TestSuiteError
+	Duration   time.Duration
+	StartTime  time.Time
+
+	mu sync.Mutex
+}
+
+// NewRunSummary creates a new run summary.
+func NewRunSummary() *RunSummary {
+	return &RunSummary{StartTime: time.Now()}
+}
+
+// AddTestSuite adds the result of a testsuite file that was run.
+func (rs *RunSummary) AddTestSuite(result *TestSuiteResult) {
+	if rs == nil {
+		return
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.TestSuites = append(rs.TestSuites, result)
+}
+
+// AddTestSuiteError adds a testsuite file that could not be run.
+func (rs *RunSummary) AddTestSuiteError(filePath string, err error) {
+	if rs == nil {
+		return
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.Errors = append(rs.Errors, TestSuiteError{FilePath: filePath, Err: err})
+}
+
+// Complete finalizes the run summary with the total duration and returns the summary for chaining.
+func (rs *RunSummary) Complete() *RunSummary {
+	if rs == nil {
+		return nil
+	}
+
+	rs.Duration = time.Since(rs.StartTime)
+
+	return rs
+}
+
+// RunCounts holds the number of testsuite files and test cases of a run by status.
+type RunCounts struct {
+	TestSuitesPassed  int
+	TestSuitesFailed  int
+	TestSuitesErrored int
+	Passed            int
+	Failed            int
+	Skipped           int
+	Errored           int
+}
+
+// Counts returns the number of testsuite files and test cases by status.
+func (rs *RunSummary) Counts() RunCounts {
+	counts := RunCounts{TestSuitesErrored: len(rs.Errors)}
+
+	for _, suite := range rs.TestSuites {
+		switch {
+		case suite.HasErrors():
+			counts.TestSuitesErrored++
+		case suite.HasFailures():
+			counts.TestSuitesFailed++
+		default:
+			counts.TestSuitesPassed++
+		}
+
+		for _, result := range suite.Results {
+			switch result.Status {
+			case StatusPass():
+				counts.Passed++
+			case StatusFail():
+				counts.Failed++
+			case StatusSkip():
+				counts.Skipped++
+			case StatusError():
+				counts.Errored++
+			}
+		}
+	}
+
+	return counts
+}
+
+// summaryTestCase is a test case result with the testsuite file it belongs to.
+type summaryTestCase struct {
+	filePath string
+	result   *TestCaseResult
+}
+
+// String returns the test case in "<testsuite file>: <name>" format.
+func (tc summaryTestCase) String() string {
+	return fmt.Sprintf("%s: %s", displayPath(tc.filePath), tc.result.Name)
+}
+
+// testCases returns the test case results of all testsuite files, in the order they were run.
+func (rs *RunSummary) testCases() []summaryTestCase {
+	var testCases []summaryTestCase
+
+	for _, suite := range rs.TestSuites {
+		for i := range suite.Results {
+			testCases = append(testCases, summaryTestCase{filePath: suite.FilePath, result: &suite.Results[i]})
+		}
+	}
+
+	return testCases
+}
+
+// Print prints the run summary: the totals, the total duration, the slowest test cases and the failed test cases.
+// The summary follows the "ok"/"FAIL" line of each testsuite file, and none of its lines can be mistaken for them.
+func (rs *RunSummary) Print(w io.Writer) {
+	if rs == nil || (len(rs.TestSuites) == 0 && len(rs.Errors) == 0) {
+		return
+	}
+
+	plural := pluralize.NewClient()
+	counts := rs.Counts()
+	testCases := rs.testCases()
+
+	//nolint:errcheck // output function, error handling not practical
+	fmt.Fprintf(w, "Summary: %s, %s in %.3fs\n",
+		plural.Pluralize("testsuite file", len(rs.TestSuites)+len(rs.Errors), true),
+		plural.Pluralize("test case", len(testCases), true),
+		rs.Duration.Seconds())
+	//nolint:errcheck // output function, error handling not practical
+	fmt.Fprintf(w, "    testsuite files: %d passed, %d failed, %d errored\n",
+		counts.TestSuitesPassed, counts.TestSuitesFailed, counts.TestSuitesErrored)
+	//nolint:errcheck // output function, error handling not practical
+	fmt.Fprintf(w, "    test cases: %d passed, %d failed, %d skipped, %d errored\n",
+		counts.Passed, counts.Failed, counts.Skipped, counts.Errored)
+
+	// Skipped test cases did not run, so they cannot be among the slowest
+	slowest := slices.DeleteFunc(slices.Clone(testCases), func(tc summaryTestCase) bool {
+		return tc.result.Status == StatusSkip()
+	})
+	slices.SortStableFunc(slowest, func(a, b summaryTestCase) int {
+		return cmp.Compare(b.result.Duration, a.result.Duration)
+	})
+
+	if len(slowest) > 0 {
+		fmt.Fprintln(w, "    slowest test cases:") //nolint:errcheck // output function, error handling not practical
+
+		for _, tc := range slowest[:min(slowestTestCases, len(slowest))] {
+			fmt.Fprintf(w, "        %.2fs %s\n", tc.result.Duration.Seconds(), tc) //nolint:errcheck // output function, error handling not practical
+		}
+	}
+
+	if counts.Failed+counts.Errored > 0 {
+		fmt.Fprintln(w, "    failed test cases:") //nolint:errcheck // output function, error handling not practical
+
+		for _, tc := range testCases {
+			switch tc.result.Status {
+			case StatusFail():
+				fmt.Fprintf(w, "        %s\n", tc) //nolint:errcheck // output function, error handling not practical
+			case StatusError():
+				fmt.Fprintf(w, "        %s [%s]\n", tc, StatusError()) //nolint:errcheck // output function, error handling not practical
+			}
+		}
+	}
+
+	if len(rs.Errors) > 0 {
+		fmt.Fprintln(w, "    errored testsuite files:") //nolint:errcheck // output function, error handling not practical
+
+		for _, suiteErr := range rs.Errors {
+			fmt.Fprintf(w, "        %s\n", displayPath(suiteErr.FilePath)) //nolint:errcheck // output function, error handling not practical
+		}
+	}
+}
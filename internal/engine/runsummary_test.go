@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" //nolint:depguard // testify is widely used for testing
+)
+
+func newSummaryTestCase(name string, status Status, duration time.Duration) *TestCaseResult {
+	result := NewTestCaseResult(name, "", false, false, false, false, false)
+	result.Status = status
+	result.Duration = duration
+
+	return result
+}
+
+func TestRunSummary_Counts(t *testing.T) {
+	summary := NewRunSummary()
+
+	passing := NewTestSuiteResult("a_xprin.yaml", false)
+	passing.AddResult(newSummaryTestCase("pass", StatusPass(), time.Second))
+	passing.AddResult(newSummaryTestCase("skip", StatusSkip(), 0))
+	summary.AddTestSuite(passing)
+
+	failing := NewTestSuiteResult("b_xprin.yaml", false)
+	failing.AddResult(newSummaryTestCase("fail", StatusFail(), time.Second))
+	summary.AddTestSuite(failing)
+
+	errored := NewTestSuiteResult("c_xprin.yaml", false)
+	errored.AddResult(newSummaryTestCase("fail", StatusFail(), time.Second))
+	errored.AddResult(newSummaryTestCase("error", StatusError(), time.Second))
+	summary.AddTestSuite(errored)
+
+	summary.AddTestSuiteError("d_xprin.yaml", errors.New("invalid testsuite file"))
+
+	assert.Equal(t, RunCounts{
+		TestSuitesPassed:  1,
+		TestSuitesFailed:  1,
+		TestSuitesErrored: 2,
+		Passed:            1,
+		Failed:            2,
+		Skipped:           1,
+		Errored:           1,
+	}, summary.Counts())
+}
+
+func TestRunSummary_Print(t *testing.T) {
+	t.Run("nil or empty summary prints nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		var summary *RunSummary
+
+		summary.AddTestSuite(NewTestSuiteResult("a_xprin.yaml", false))
+		summary.Complete().Print(&buf)
+		NewRunSummary().Complete().Print(&buf)
+
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("prints totals, slowest and failed test cases", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		summary := NewRunSummary()
+
+		first := NewTestSuiteResult("a_xprin.yaml", false)
+		for _, tc := range []*TestCaseResult{
+			newSummaryTestCase("fast", StatusPass(), 100*time.Millisecond),
+			newSummaryTestCase("slow", StatusFail(), 3*time.Second),
+			newSummaryTestCase("skipped", StatusSkip(), 0),
+			newSummaryTestCase("errored", StatusError(), 0),
+		} {
+			first.AddResult(tc)
+		}
+
+		second := NewTestSuiteResult("b_xprin.yaml", false)
+		for i, d := range []time.Duration{2, 1, 4, 5, 6} {
+			second.AddResult(newSummaryTestCase(string(rune('a'+i)), StatusPass(), d*time.Second/10))
+		}
+
+		summary.AddTestSuite(first)
+		summary.AddTestSuite(second)
+		summary.AddTestSuiteError("c_xprin.yaml", errors.New("invalid testsuite file"))
+		summary.Duration = 4 * time.Second
+
+		summary.Print(&buf)
+
+		assert.Equal(t, `Summary: 3 testsuite files, 9 test cases in 4.000s
+    testsuite files: 1 passed, 0 failed, 2 errored
+    test cases: 6 passed, 1 failed, 1 skipped, 1 errored
+    slowest test cases:
+        3.00s a_xprin.yaml: slow
+        0.60s b_xprin.yaml: e
+        0.50s b_xprin.yaml: d
+        0.40s b_xprin.yaml: c
+        0.20s b_xprin.yaml: a
+    failed test cases:
+        a_xprin.yaml: slow
+        a_xprin.yaml: errored [ERROR]
+    errored testsuite files:
+        c_xprin.yaml
+`, buf.String())
+	})
+}
@@ -49,12 +49,21 @@ func indentMultilineBody(indent, body string) string {
 
 // TestCaseResult represents the result of a single test case.
 type TestCaseResult struct {
-	Name      string
-	ID        string // Test case ID for cross-test references
-	Duration  time.Duration
-	Error     error
-	Status    Status
-	StartTime time.Time
+	Name       string
+	ID         string // Test case ID for cross-test references
+	Duration   time.Duration
+	Error      error
+	Status     Status
+	SkipReason string // Why the test case was skipped (only set for skipped test cases)
+	StartTime  time.Time
+
+	// Directory with the persisted inputs and outputs of the test case (only set with --artifacts-dir)
+	ArtifactsDir string
+
+	// Expected failure (only set for test cases marked with xfail)
+	XFailReason     string // Why the test case is expected to fail
+	ExpectedFailure bool   // The test case failed as expected and is reported as passed
+	UnexpectedPass  bool   // The test case passed although it was expected to fail and is reported as failed
 
 	// Raw outputs (stored by runner)
 	RawRenderOutput     []byte
@@ -129,11 +138,51 @@ func (tcr *TestCaseResult) Fail(err error) *TestCaseResult {
 	return tcr.Complete()
 }
 
+// Errored marks a test case that could not run (e.g. because an input file is missing or a command could not be executed)
+// with the given error and completes it, returning the result for chaining.
+func (tcr *TestCaseResult) Errored(err error) *TestCaseResult {
+	tcr.Error = err
+	tcr.Status = StatusError()
+
+	return tcr.Complete()
+}
+
+// HasFailed returns true if the test case failed or could not run.
+func (tcr *TestCaseResult) HasFailed() bool {
+	return tcr.Status == StatusFail() || tcr.Status == StatusError()
+}
+
 // Skip marks a test case as skipped.
 func (tcr *TestCaseResult) Skip() {
 	tcr.Status = StatusSkip()
 }
 
+// SkipWithReason marks a test case as skipped with the given reason and completes it, returning the result for chaining.
+func (tcr *TestCaseResult) SkipWithReason(reason string) *TestCaseResult {
+	tcr.Skip()
+	tcr.SkipReason = reason
+
+	return tcr.Complete()
+}
+
+// ExpectFailure inverts the outcome of a completed test case that is expected to fail, returning the result for chaining.
+// A failed test case is reported as passed, and a passed test case is reported as failed.
+func (tcr *TestCaseResult) ExpectFailure(reason string) *TestCaseResult {
+	tcr.XFailReason = reason
+
+	switch tcr.Status {
+	case StatusFail():
+		tcr.Status = StatusPass()
+		tcr.ExpectedFailure = true
+	case StatusPass():
+		tcr.Status = StatusFail()
+		tcr.UnexpectedPass = true
+		tcr.Error = fmt.Errorf("expected to fail but passed: %s", reason)
+	}
+
+	return tcr
+}
+
 // Complete finalizes a test case result with duration and returns the result for chaining.
 func (tcr *TestCaseResult) Complete() *TestCaseResult {
 	tcr.Duration = time.Since(tcr.StartTime)
@@ -149,6 +198,14 @@ func (tcr *TestCaseResult) FailRender() *TestCaseResult {
 	return tcr.Fail(nil)
 }
 
+// ErrorRender handles a render that could not run because of the environment (e.g. the Docker daemon is not running),
+// with the same formatting as FailRender.
+func (tcr *TestCaseResult) ErrorRender() *TestCaseResult {
+	tcr.FailRender()
+
+	return tcr.Errored(nil)
+}
+
 // HasPipelineFailure returns true if validate, assertions, or post-test hooks failed.
 // Used by the runner to call Fail(nil) when no infrastructure error was collected.
 func (tcr *TestCaseResult) HasPipelineFailure() bool {
@@ -187,6 +244,15 @@ func (tcr *TestCaseResult) Print(w io.Writer) {
 	// Print status line
 	fmt.Fprintf(w, "--- %s: %s (%.2fs)\n", tcr.Status, tcr.Name, tcr.Duration.Seconds()) //nolint:errcheck // output function, error handling not practical
 
+	// Print the skip reason like go test prints t.Skip messages
+	if tcr.Status == StatusSkip() && tcr.SkipReason != "" {
+		fmt.Fprintf(w, "%s%s\n", spaces, tcr.SkipReason) //nolint:errcheck // output function, error handling not practical
+	}
+
+	if tcr.ExpectedFailure {
+		fmt.Fprintf(w, "%sexpected failure: %s\n", spaces, tcr.XFailReason) //nolint:errcheck // output function, error handling not practical
+	}
+
 	fmt.Fprint(w, tcr.FormattedPreTestHooksOutput)  //nolint:errcheck // output function, error handling not practical
 	fmt.Fprint(w, tcr.FormattedRenderOutput)        //nolint:errcheck // output function, error handling not practical
 	fmt.Fprint(w, tcr.FormattedValidateOutput)      //nolint:errcheck // output function, error handling not practical
@@ -194,7 +260,7 @@ func (tcr *TestCaseResult) Print(w io.Writer) {
 	fmt.Fprint(w, tcr.FormattedPostTestHooksOutput) //nolint:errcheck // output function, error handling not practical
 
 	// Print error when set (only set for failures not represented in a section).
-	if tcr.Status == StatusFail() && tcr.Error != nil {
+	if tcr.HasFailed() && tcr.Error != nil {
 		fmt.Fprint(w, formatErrorBlock(tcr.Error.Error())) //nolint:errcheck // output function, error handling not practical
 	}
 }
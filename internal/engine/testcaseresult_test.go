@@ -65,6 +65,43 @@ func TestTestCaseResult_Fail(t *testing.T) {
 	})
 }
 
+func TestTestCaseResult_Errored(t *testing.T) {
+	t.Run("sets error and status to ERROR", func(t *testing.T) {
+		result := NewTestCaseResult("test", "test-id", false, false, false, false, false)
+		err := assert.AnError
+
+		returned := result.Errored(err)
+
+		assert.Equal(t, result, returned) // Should return self for chaining
+		assert.Equal(t, StatusError(), result.Status)
+		assert.Equal(t, err, result.Error)
+		assert.Positive(t, result.Duration) // Should be completed
+		assert.True(t, result.HasFailed())
+	})
+}
+
+func TestTestCaseResult_HasFailed(t *testing.T) {
+	tests := []struct {
+		name   string
+		status Status
+		want   bool
+	}{
+		{name: "pass", status: StatusPass(), want: false},
+		{name: "skip", status: StatusSkip(), want: false},
+		{name: "fail", status: StatusFail(), want: true},
+		{name: "error", status: StatusError(), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewTestCaseResult("test", "test-id", false, false, false, false, false)
+			result.Status = tt.status
+
+			assert.Equal(t, tt.want, result.HasFailed())
+		})
+	}
+}
+
 func TestTestCaseResult_Skip(t *testing.T) {
 	t.Run("sets status to SKIP", func(t *testing.T) {
 		result := NewTestCaseResult("test", "test-id", false, false, false, false, false)
@@ -73,6 +110,66 @@ func TestTestCaseResult_Skip(t *testing.T) {
 
 		assert.Equal(t, StatusSkip(), result.Status)
 	})
+
+	t.Run("with reason sets status, reason and completes", func(t *testing.T) {
+		result := NewTestCaseResult("test", "test-id", false, false, false, false, false)
+
+		returned := result.SkipWithReason("needs test case 'upstream' which failed")
+
+		assert.Equal(t, result, returned)
+		assert.Equal(t, StatusSkip(), result.Status)
+		assert.Equal(t, "needs test case 'upstream' which failed", result.SkipReason)
+		assert.Positive(t, result.Duration)
+
+		var buf bytes.Buffer
+		result.Print(&buf)
+		assert.Contains(t, buf.String(), "--- SKIP: test (")
+		assert.Contains(t, buf.String(), "\n    needs test case 'upstream' which failed\n")
+	})
+}
+
+func TestTestCaseResult_ExpectFailure(t *testing.T) {
+	t.Run("failed test case passes as expected failure", func(t *testing.T) {
+		result := NewTestCaseResult("test", "test-id", true, false, false, false, false)
+		result.Fail(assert.AnError)
+
+		returned := result.ExpectFailure("known bug")
+
+		assert.Equal(t, result, returned)
+		assert.Equal(t, StatusPass(), result.Status)
+		assert.True(t, result.ExpectedFailure)
+		assert.False(t, result.UnexpectedPass)
+		assert.Equal(t, "known bug", result.XFailReason)
+
+		var buf bytes.Buffer
+		result.Print(&buf)
+		assert.Contains(t, buf.String(), "--- PASS: test (")
+		assert.Contains(t, buf.String(), "\n    expected failure: known bug\n")
+	})
+
+	t.Run("passed test case fails as unexpected pass", func(t *testing.T) {
+		result := NewTestCaseResult("test", "test-id", false, false, false, false, false)
+		result.Complete()
+
+		result.ExpectFailure("known bug")
+
+		assert.Equal(t, StatusFail(), result.Status)
+		assert.False(t, result.ExpectedFailure)
+		assert.True(t, result.UnexpectedPass)
+		require.Error(t, result.Error)
+		assert.Equal(t, "expected to fail but passed: known bug", result.Error.Error())
+	})
+
+	t.Run("skipped test case stays skipped", func(t *testing.T) {
+		result := NewTestCaseResult("test", "test-id", false, false, false, false, false)
+		result.SkipWithReason("not ready")
+
+		result.ExpectFailure("known bug")
+
+		assert.Equal(t, StatusSkip(), result.Status)
+		assert.False(t, result.ExpectedFailure)
+		assert.False(t, result.UnexpectedPass)
+	})
 }
 
 func TestTestCaseResult_Complete(t *testing.T) {
@@ -104,6 +201,21 @@ func TestTestCaseResult_FailRender(t *testing.T) {
 	})
 }
 
+func TestTestCaseResult_ErrorRender(t *testing.T) {
+	t.Run("sets hasFailedRender and returns error result with no Error", func(t *testing.T) {
+		result := NewTestCaseResult("test", "test-id", false, false, false, false, false)
+		result.RawRenderOutput = []byte("cannot connect to the Docker daemon")
+
+		returned := result.ErrorRender()
+
+		assert.Equal(t, result, returned) // Should return self for chaining
+		assert.True(t, result.HasFailedRender)
+		assert.Equal(t, StatusError(), result.Status)
+		require.NoError(t, result.Error) // Error is shown only in render section
+		assert.Contains(t, result.FormattedRenderOutput, "cannot connect to the Docker daemon")
+	})
+}
+
 func TestTestCaseResult_MarkValidateFailed(t *testing.T) {
 	t.Run("sets hasFailedValidate and returns formatted error", func(t *testing.T) {
 		result := NewTestCaseResult("test", "test-id", false, false, false, false, false)
@@ -30,7 +30,7 @@ type TestSuiteResult struct {
 	FilePath  string
 	Results   []TestCaseResult
 	Duration  time.Duration
-	Status    Status // StatusPass() or StatusFail() - overall status
+	Status    Status // StatusPass(), StatusFail() or StatusError() if a test case could not run - overall status
 	StartTime time.Time
 	Verbose   bool // Formatting flag for output
 }
@@ -49,8 +49,11 @@ func NewTestSuiteResult(filePath string, verbose bool) *TestSuiteResult {
 func (tsr *TestSuiteResult) AddResult(result *TestCaseResult) {
 	tsr.Results = append(tsr.Results, *result)
 
-	// Update overall status if any test failed
-	if result.Status == StatusFail() {
+	// Update overall status if any test failed; a test case that could not run takes precedence
+	switch {
+	case result.Status == StatusError():
+		tsr.Status = StatusError()
+	case result.Status == StatusFail() && tsr.Status != StatusError():
 		tsr.Status = StatusFail()
 	}
 }
@@ -63,15 +66,12 @@ func (tsr *TestSuiteResult) Complete() *TestSuiteResult {
 
 // Print the file summary in Go test format.
 func (tsr *TestSuiteResult) Print(w io.Writer) {
-	// Convert absolute paths to relative paths when possible (matches Go's testing package behavior)
-	displayPath := tsr.FilePath
-	if pwd, err := os.Getwd(); err == nil {
-		if rel, err := filepath.Rel(pwd, tsr.FilePath); err == nil && !strings.HasPrefix(rel, "..") {
-			displayPath = rel
-		}
-	}
+	displayPath := displayPath(tsr.FilePath)
 
-	if tsr.Status == StatusFail() {
+	tsr.printMarkedTests(w)
+
+	// A testsuite file with test cases that could not run is reported as FAIL, like go test does for packages that cannot be tested
+	if tsr.HasFailures() {
 		fmt.Fprintf(w, "%s\n%s\t%s\t%.3fs\n", StatusFail().Value, StatusFail().Value, displayPath, tsr.Duration.Seconds()) //nolint:errcheck // output function, error handling not practical
 	} else {
 		if tsr.Verbose {
@@ -82,9 +82,49 @@ func (tsr *TestSuiteResult) Print(w io.Writer) {
 	}
 }
 
-// HasFailures returns true if any test failed.
+// printMarkedTests prints the skipped test cases and the test cases marked as expected to fail, with their reasons.
+func (tsr *TestSuiteResult) printMarkedTests(w io.Writer) {
+	for _, result := range tsr.Results {
+		var label, reason string
+
+		switch {
+		case result.Status == StatusSkip():
+			label, reason = "skipped", result.SkipReason
+		case result.ExpectedFailure:
+			label, reason = "expected failure", result.XFailReason
+		case result.UnexpectedPass:
+			label, reason = "unexpected pass", result.XFailReason
+		default:
+			continue
+		}
+
+		if reason == "" {
+			fmt.Fprintf(w, "%s: %s\n", label, result.Name) //nolint:errcheck // output function, error handling not practical
+		} else {
+			fmt.Fprintf(w, "%s: %s (%s)\n", label, result.Name, reason) //nolint:errcheck // output function, error handling not practical
+		}
+	}
+}
+
+// displayPath converts an absolute path to a path relative to the working directory when possible (matches Go's testing package behavior).
+func displayPath(path string) string {
+	if pwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(pwd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+
+	return path
+}
+
+// HasFailures returns true if any test failed or could not run.
 func (tsr *TestSuiteResult) HasFailures() bool {
-	return tsr.Status == StatusFail()
+	return tsr.Status == StatusFail() || tsr.Status == StatusError()
+}
+
+// HasErrors returns true if any test could not run.
+func (tsr *TestSuiteResult) HasErrors() bool {
+	return tsr.Status == StatusError()
 }
 
 // GetCompletedTests returns a map of test ID to test case result for completed tests.
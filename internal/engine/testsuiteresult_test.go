@@ -83,6 +83,20 @@ func TestTestSuiteResult_AddResult(t *testing.T) {
 		assert.Equal(t, StatusFail(), suite.Status)
 		assert.Len(t, suite.Results, 2)
 	})
+
+	t.Run("errored result takes precedence over failing result", func(t *testing.T) {
+		suite := NewTestSuiteResult("test.yaml", false)
+
+		errorResult := NewTestCaseResult("test1", "test1-id", false, false, false, false, false)
+		errorResult.Errored(assert.AnError)
+		suite.AddResult(errorResult)
+
+		failResult := NewTestCaseResult("test2", "test2-id", false, false, false, false, false)
+		failResult.Fail(assert.AnError)
+		suite.AddResult(failResult)
+
+		assert.Equal(t, StatusError(), suite.Status)
+	})
 }
 
 func TestTestSuiteResult_Complete(t *testing.T) {
@@ -141,6 +155,27 @@ func TestTestSuiteResult_Print(t *testing.T) {
 		assert.NotContains(t, output, "ok")
 	})
 
+	t.Run("prints skipped and expected-to-fail test cases with their reasons", func(t *testing.T) {
+		suite := NewTestSuiteResult("test.yaml", false)
+
+		suite.AddResult(NewTestCaseResult("skipped", "", false, false, false, false, false).SkipWithReason("not ready"))
+		suite.AddResult(NewTestCaseResult("xfail", "", false, false, false, false, false).Fail(assert.AnError).ExpectFailure("bug 1"))
+		suite.AddResult(NewTestCaseResult("xpass", "", false, false, false, false, false).Complete().ExpectFailure("bug 2"))
+		suite.AddResult(NewTestCaseResult("passed", "", false, false, false, false, false).Complete())
+		suite.Complete()
+
+		var buf bytes.Buffer
+		suite.Print(&buf)
+
+		output := buf.String()
+		assert.Contains(t, output, "skipped: skipped (not ready)\n")
+		assert.Contains(t, output, "expected failure: xfail (bug 1)\n")
+		assert.Contains(t, output, "unexpected pass: xpass (bug 2)\n")
+		assert.NotContains(t, output, "passed")
+		assert.True(t, strings.HasPrefix(output, "skipped: "))
+		assert.Equal(t, StatusFail(), suite.Status)
+	})
+
 	t.Run("prints relative path when possible", func(t *testing.T) {
 		// This test might be flaky depending on the test environment
 		// but it tests the path conversion logic
@@ -183,6 +218,27 @@ func TestTestSuiteResult_HasFailures(t *testing.T) {
 	})
 }
 
+func TestTestSuiteResult_HasErrors(t *testing.T) {
+	t.Run("returns false for failing suite", func(t *testing.T) {
+		suite := NewTestSuiteResult("test.yaml", false)
+		testResult := NewTestCaseResult("test1", "test1-id", false, false, false, false, false)
+		testResult.Fail(assert.AnError)
+		suite.AddResult(testResult)
+
+		assert.False(t, suite.HasErrors())
+	})
+
+	t.Run("returns true for errored suite", func(t *testing.T) {
+		suite := NewTestSuiteResult("test.yaml", false)
+		testResult := NewTestCaseResult("test1", "test1-id", false, false, false, false, false)
+		testResult.Errored(assert.AnError)
+		suite.AddResult(testResult)
+
+		assert.True(t, suite.HasErrors())
+		assert.True(t, suite.HasFailures())
+	})
+}
+
 func TestTestSuiteResult_Integration(t *testing.T) {
 	t.Run("complete workflow with multiple test cases", func(t *testing.T) {
 		suite := NewTestSuiteResult("integration-test.yaml", true)
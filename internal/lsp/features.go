@@ -0,0 +1,374 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lsp
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/crossplane-contrib/xprin/internal/testexecution/processor"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"go.yaml.in/yaml/v3"
+)
+
+// resourceLinePattern matches the resource field of an assertion, with the start of its value in group 2.
+var resourceLinePattern = regexp.MustCompile(`^(\s*(?:-\s+)?resource:\s*)(.*)$`)
+
+// isPathField returns true if a key path is a field whose value is the path of a file.
+func isPathField(keys []any) bool {
+	// Suffixes of the key paths of the path fields, where -1 matches any list index and "*" any map key
+	fields := [][]any{
+		{"inputs", "xr"},
+		{"inputs", "claim"},
+		{"inputs", "composition"},
+		{"inputs", "functions"},
+		{"inputs", "observed-resources"},
+		{"inputs", "extra-resources"},
+		{"inputs", "function-credentials"},
+		{"inputs", "crds", -1},
+		{"inputs", "context-files", "*"},
+		{"xr-from-xrd", "xrd"},
+		{"patches", "xrd"},
+		{"diff", -1, "expected"},
+		{"dyff", -1, "expected"},
+		{"include", -1},
+		{"include", -1, "path"},
+	}
+
+	return slices.ContainsFunc(fields, func(field []any) bool { return hasSuffix(keys, field) })
+}
+
+// assertionTypeDescription returns the description of a type of xprin assertions, or false if it is not one.
+func assertionTypeDescription(assertionType string) (string, bool) {
+	switch assertionType {
+	case "Count":
+		return "Checks the number of rendered resources.\n\nRequires `value`: the expected number of resources.", true
+	case "Exists":
+		return "Checks that a resource is rendered.\n\nRequires `resource`: `Kind/name`.", true
+	case "NotExists":
+		return "Checks that a resource is not rendered.\n\nRequires `resource`: `Kind/name`, or `Kind` for no resource of that kind.", true
+	case "FieldType":
+		return "Checks the type of a field of a resource.\n\nRequires `resource` (`Kind/name`), `field` (e.g. `spec.replicas`) and " +
+			"`value`: `string`, `number`, `boolean`, `array`, `object` or `null`.", true
+	case "FieldExists":
+		return "Checks that a field of a resource exists.\n\nRequires `resource` (`Kind/name`) and `field` (e.g. `spec.replicas`).", true
+	case "FieldNotExists":
+		return "Checks that a field of a resource does not exist.\n\nRequires `resource` (`Kind/name`) and `field` (e.g. `spec.deprecated`).", true
+	case "FieldValue":
+		return "Checks the value of a field of a resource.\n\nRequires `resource` (`Kind/name`), `field` (e.g. `spec.replicas`), " +
+			"`operator` (`==` or `is`) and `value`.", true
+	default:
+		return "", false
+	}
+}
+
+// definition returns the location of the test case of a .Tests.<id> reference, or of the file of a path field.
+func (s *Server) definition(params TextDocumentPositionParams) (any, error) {
+	path := uriToPath(params.TextDocument.URI)
+	text := s.document(path)
+	line := lineAt(text, params.Position.Line)
+	offset := byteOffset(line, params.Position.Character)
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &root); err != nil {
+		return nil, nil
+	}
+
+	for _, reference := range runner.FindTestReferences(line) {
+		if offset >= reference.Start && offset <= reference.End {
+			return testCaseLocation(&root, text, params.TextDocument.URI, reference.ID), nil
+		}
+	}
+
+	keys, node, isKey := scalarAt(&root, params.Position.Line+1, utf8.RuneCountInString(line[:offset]))
+	if node == nil || isKey || !isPathField(keys) {
+		return nil, nil
+	}
+
+	file := s.resolvePath(path, node.Value)
+	if file == "" {
+		return nil, nil
+	}
+
+	return Location{URI: pathToURI(file)}, nil
+}
+
+// resolvePath returns the file of a path field of a testsuite file, relative to its directory, or an empty string if
+// it is not a file. Paths with templates are resolved like xprin test --changed-since does (see InputFiles).
+func (s *Server) resolvePath(file, value string) string {
+	var path string
+
+	if testexecutionUtils.HasTemplate(value) {
+		testRunner, err := processor.NewTestSuiteRunner(s.fs, file, s.options)
+		if err != nil {
+			return ""
+		}
+
+		for _, input := range testRunner.InputFiles() {
+			if input.Value == value {
+				path = input.Path
+				break
+			}
+		}
+	} else {
+		path = value
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(file), path)
+		}
+	}
+
+	if info, err := s.fs.Stat(path); path == "" || err != nil || info.IsDir() {
+		return ""
+	}
+
+	return path
+}
+
+// testCaseLocation returns the location of the id of the test case with an id, or nil if there is none.
+func testCaseLocation(root *yaml.Node, text, uri, id string) *Location {
+	tests := mappingValue(documentContent(root), "tests")
+	if tests == nil || tests.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	for _, testCase := range tests.Content {
+		node := mappingValue(testCase, "id")
+		if node == nil || node.Value != id {
+			continue
+		}
+
+		line := lineAt(text, node.Line-1)
+		start := character(line, runeOffset(line, node.Column-1))
+
+		return &Location{URI: uri, Range: Range{
+			Start: Position{Line: node.Line - 1, Character: start},
+			End:   Position{Line: node.Line - 1, Character: start + len(utf16.Encode([]rune(node.Value)))},
+		}}
+	}
+
+	return nil
+}
+
+// hover describes the type of an xprin assertion.
+func (s *Server) hover(params TextDocumentPositionParams) (any, error) {
+	text := s.document(uriToPath(params.TextDocument.URI))
+	line := lineAt(text, params.Position.Line)
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &root); err != nil {
+		return nil, nil
+	}
+
+	column := utf8.RuneCountInString(line[:byteOffset(line, params.Position.Character)])
+
+	keys, node, isKey := scalarAt(&root, params.Position.Line+1, column)
+	if node == nil || isKey || !hasSuffix(keys, []any{"xprin", -1, "type"}) {
+		return nil, nil
+	}
+
+	description, ok := assertionTypeDescription(node.Value)
+	if !ok {
+		return nil, nil
+	}
+
+	return Hover{Contents: MarkupContent{Kind: "markdown", Value: "**" + node.Value + "** assertion\n\n" + description}}, nil
+}
+
+// completion completes the resource of an assertion with the Kind/name of the resources of the last render of the
+// testsuite file.
+func (s *Server) completion(params TextDocumentPositionParams) (any, error) {
+	path := uriToPath(params.TextDocument.URI)
+	line := lineAt(s.document(path), params.Position.Line)
+
+	m := resourceLinePattern.FindStringSubmatchIndex(line)
+	if m == nil || byteOffset(line, params.Position.Character) < m[3] {
+		return CompletionList{Items: []CompletionItem{}}, nil
+	}
+
+	s.mu.Lock()
+	resources := s.resources[path]
+	s.mu.Unlock()
+
+	replace := Range{
+		Start: Position{Line: params.Position.Line, Character: character(line, m[3])},
+		End:   Position{Line: params.Position.Line, Character: character(line, len(line))},
+	}
+
+	items := make([]CompletionItem, 0, len(resources))
+
+	for _, resource := range resources {
+		items = append(items, CompletionItem{
+			Label:    resource,
+			Kind:     completionKindValue,
+			Detail:   "rendered resource",
+			TextEdit: &TextEdit{Range: replace, NewText: resource},
+		})
+	}
+
+	return CompletionList{Items: items}, nil
+}
+
+// scalarAt returns the scalar of a YAML document at a line and rune column (1-based line, 0-based column): the
+// rightmost scalar of the line that starts at or before the column, with the keys and list indexes of its path and
+// whether it is a mapping key.
+func scalarAt(root *yaml.Node, line, column int) ([]any, *yaml.Node, bool) {
+	var (
+		bestKeys  []any
+		bestNode  *yaml.Node
+		bestIsKey bool
+	)
+
+	consider := func(keys []any, node *yaml.Node, isKey bool) {
+		if node.Kind != yaml.ScalarNode || node.Line != line || node.Column-1 > column {
+			return
+		}
+
+		if bestNode == nil || node.Column > bestNode.Column {
+			bestKeys, bestNode, bestIsKey = slices.Clone(keys), node, isKey
+		}
+	}
+
+	var walk func(node *yaml.Node, keys []any)
+
+	walk = func(node *yaml.Node, keys []any) {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				walk(child, keys)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				childKeys := append(slices.Clone(keys), node.Content[i].Value)
+				consider(childKeys, node.Content[i], true)
+				walk(node.Content[i+1], childKeys)
+			}
+		case yaml.SequenceNode:
+			for i, child := range node.Content {
+				walk(child, append(slices.Clone(keys), i))
+			}
+		case yaml.ScalarNode:
+			consider(keys, node, false)
+		case yaml.AliasNode:
+		}
+	}
+
+	walk(root, nil)
+
+	return bestKeys, bestNode, bestIsKey
+}
+
+// hasSuffix returns true if the keys of a path end with a pattern, where -1 matches any list index and "*" any key.
+func hasSuffix(keys, pattern []any) bool {
+	if len(keys) < len(pattern) {
+		return false
+	}
+
+	for i, want := range pattern {
+		got := keys[len(keys)-len(pattern)+i]
+
+		switch want {
+		case -1:
+			if _, ok := got.(int); !ok {
+				return false
+			}
+		case "*":
+			if _, ok := got.(string); !ok {
+				return false
+			}
+		default:
+			if got != want {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// documentContent returns the root node of the content of a YAML document.
+func documentContent(root *yaml.Node) *yaml.Node {
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		return root.Content[0]
+	}
+
+	return root
+}
+
+// mappingValue returns the value of a key of a mapping node, or nil if it is not a mapping or has no such key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// lineAt returns a line of a text, without its line ending, or an empty string if the text has no such line.
+func lineAt(text string, line int) string {
+	lines := strings.Split(text, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+
+	return strings.TrimSuffix(lines[line], "\r")
+}
+
+// character returns the UTF-16 offset of a byte offset of a line, as positions of the protocol count characters.
+func character(line string, offset int) int {
+	offset = min(max(offset, 0), len(line))
+
+	return len(utf16.Encode([]rune(line[:offset])))
+}
+
+// byteOffset returns the byte offset of a UTF-16 offset of a line.
+func byteOffset(line string, character int) int {
+	units := 0
+
+	for i, r := range line {
+		if units >= character {
+			return i
+		}
+
+		units += utf16.RuneLen(r)
+	}
+
+	return len(line)
+}
+
+// runeOffset returns the byte offset of a rune offset of a line.
+func runeOffset(line string, runes int) int {
+	for i := range line {
+		if runes <= 0 {
+			return i
+		}
+
+		runes--
+	}
+
+	return len(line)
+}
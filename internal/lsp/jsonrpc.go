@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// readMessage reads a message of the base protocol: headers, of which Content-Length is required, an empty line and
+// the JSON-RPC content.
+func readMessage(r *bufio.Reader) (*message, error) {
+	headers, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := strconv.Atoi(strings.TrimSpace(headers.Get("Content-Length")))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length header %q", headers.Get("Content-Length"))
+	}
+
+	content := make([]byte, length)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return nil, err
+	}
+
+	msg := &message{}
+	if err := json.Unmarshal(content, msg); err != nil {
+		return nil, &responseError{Code: codeParseError, Message: fmt.Sprintf("failed to parse message: %v", err)}
+	}
+
+	return msg, nil
+}
+
+// writer writes the messages of the base protocol, one at a time.
+type writer struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// write writes a message with its Content-Length header.
+func (w *writer) write(msg *message) error {
+	msg.JSONRPC = "2.0"
+
+	content, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := fmt.Fprintf(w.out, "Content-Length: %d\r\n\r\n%s", len(content), content); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+
+	return nil
+}
+
+// respond writes the response to a request, with its result or its error.
+func (w *writer) respond(id json.RawMessage, result any, err error) error {
+	msg := &message{ID: id}
+
+	if err != nil {
+		var rpcErr *responseError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &responseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+
+		msg.Error = rpcErr
+
+		return w.write(msg)
+	}
+
+	content, marshalErr := json.Marshal(result)
+	if marshalErr != nil {
+		return fmt.Errorf("failed to marshal result: %w", marshalErr)
+	}
+
+	msg.Result = content
+
+	return w.write(msg)
+}
+
+// notify writes a notification.
+func (w *writer) notify(method string, params any) error {
+	content, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal params of %s: %w", method, err)
+	}
+
+	return w.write(&message{Method: method, Params: content})
+}
@@ -0,0 +1,371 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lsp provides a language server for testsuite files, used by editors over stdio.
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/crossplane-contrib/xprin/internal/testexecution/processor"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/crossplane-contrib/xprin/internal/version"
+	"github.com/spf13/afero"
+)
+
+// Server is a language server for testsuite files. It publishes the problems found by xprin lint as diagnostics,
+// goes to the definition of input paths and .Tests.<id> references, completes the Kind/name of the resources rendered
+// by the test cases, and describes the assertion types on hover.
+type Server struct {
+	options           *testexecutionUtils.Options
+	render            bool     // Render the test cases of the open testsuite files to complete resources
+	overlay           afero.Fs // The open documents, as the editor has them
+	fs                afero.Fs // The files on disk, overlaid with the open documents
+	renderedResources func(ctx context.Context, file string) ([]string, error)
+	writer            *writer
+	wg                sync.WaitGroup
+
+	mu        sync.Mutex
+	documents map[string]string             // Content of the open documents, by path
+	resources map[string][]string           // Kind/name of the resources of the last render of each testsuite file
+	renders   map[string]context.CancelFunc // Cancels the running render of each testsuite file
+}
+
+// NewServer creates a language server. When render is true, the test cases of each testsuite file are rendered when
+// it is opened or saved, to complete the resources of assertions.
+func NewServer(options *testexecutionUtils.Options, render bool) *Server {
+	overlay := afero.NewMemMapFs()
+
+	s := &Server{
+		options:   options,
+		render:    render,
+		overlay:   overlay,
+		fs:        afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(afero.NewOsFs()), overlay),
+		documents: make(map[string]string),
+		resources: make(map[string][]string),
+		renders:   make(map[string]context.CancelFunc),
+	}
+
+	s.renderedResources = func(ctx context.Context, file string) ([]string, error) {
+		testRunner, err := processor.NewTestSuiteRunner(afero.NewOsFs(), file, s.options)
+		if err != nil {
+			return nil, err
+		}
+
+		return testRunner.RenderedResources(ctx)
+	}
+
+	return s
+}
+
+// Serve reads the messages of the client from in and writes the responses and notifications to out, until the client
+// sends exit, in is closed or ctx is done.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.writer = &writer{out: out}
+
+	defer s.wg.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	messages := make(chan *message)
+	readErr := make(chan error, 1)
+
+	go func() {
+		reader := bufio.NewReader(in)
+
+		for {
+			msg, err := readMessage(reader)
+			if err != nil {
+				var rpcErr *responseError
+				if errors.As(err, &rpcErr) {
+					_ = s.writer.respond(json.RawMessage("null"), nil, rpcErr)
+					continue
+				}
+
+				readErr <- err
+
+				return
+			}
+
+			select {
+			case messages <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("failed to read message: %w", err)
+		case msg := <-messages:
+			if msg.Method == "exit" {
+				return nil
+			}
+
+			if err := s.handle(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handle handles a request or a notification. The client sends no responses, since the server sends no requests.
+func (s *Server) handle(ctx context.Context, msg *message) error {
+	if msg.Method == "" {
+		return nil
+	}
+
+	result, err := s.dispatch(ctx, msg.Method, msg.Params)
+
+	if len(msg.ID) == 0 {
+		var rpcErr *responseError
+		if err != nil && (!errors.As(err, &rpcErr) || rpcErr.Code != codeMethodNotFound) {
+			utils.WarningPrintf("%s: %v\n", msg.Method, err)
+		}
+
+		return nil
+	}
+
+	return s.writer.respond(msg.ID, result, err)
+}
+
+// dispatch runs the method of a request or notification and returns its result.
+func (s *Server) dispatch(ctx context.Context, method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		return map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync":   map[string]any{"openClose": true, "change": syncFull, "save": true},
+				"definitionProvider": true,
+				"hoverProvider":      true,
+				"completionProvider": map[string]any{"triggerCharacters": []string{" ", "/"}},
+			},
+			"serverInfo": map[string]any{"name": "xprin", "version": version.GetVersion()},
+		}, nil
+	case "initialized", "shutdown", "$/cancelRequest", "$/setTrace":
+		return nil, nil
+	case "textDocument/didOpen":
+		return withParams(params, func(p DidOpenTextDocumentParams) (any, error) {
+			return nil, s.didOpen(ctx, p.TextDocument.URI, p.TextDocument.Text)
+		})
+	case "textDocument/didChange":
+		return withParams(params, func(p DidChangeTextDocumentParams) (any, error) {
+			if len(p.ContentChanges) == 0 {
+				return nil, nil
+			}
+
+			return nil, s.didChange(p.TextDocument.URI, p.ContentChanges[len(p.ContentChanges)-1].Text)
+		})
+	case "textDocument/didSave":
+		return withParams(params, func(p DidSaveTextDocumentParams) (any, error) {
+			return nil, s.didSave(ctx, p.TextDocument.URI)
+		})
+	case "textDocument/didClose":
+		return withParams(params, func(p DidCloseTextDocumentParams) (any, error) {
+			return nil, s.didClose(p.TextDocument.URI)
+		})
+	case "textDocument/definition":
+		return withParams(params, s.definition)
+	case "textDocument/hover":
+		return withParams(params, s.hover)
+	case "textDocument/completion":
+		return withParams(params, s.completion)
+	default:
+		return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %s is not supported", method)}
+	}
+}
+
+// withParams decodes the params of a method and runs it.
+func withParams[T any](params json.RawMessage, method func(T) (any, error)) (any, error) {
+	var p T
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+
+	return method(p)
+}
+
+// didOpen stores an opened document, publishes its diagnostics and renders its test cases.
+func (s *Server) didOpen(ctx context.Context, uri, text string) error {
+	if err := s.didChange(uri, text); err != nil {
+		return err
+	}
+
+	s.startRender(ctx, uriToPath(uri))
+
+	return nil
+}
+
+// didChange stores the new content of a document and publishes its diagnostics.
+func (s *Server) didChange(uri, text string) error {
+	path := uriToPath(uri)
+
+	s.mu.Lock()
+	s.documents[path] = text
+	s.mu.Unlock()
+
+	if err := s.overlay.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to store document %s: %w", path, err)
+	}
+
+	if err := afero.WriteFile(s.overlay, path, []byte(text), 0o600); err != nil {
+		return fmt.Errorf("failed to store document %s: %w", path, err)
+	}
+
+	return s.publishDiagnostics(uri)
+}
+
+// didSave publishes the diagnostics of a saved document, since the files it reads may have changed too, and renders
+// its test cases again.
+func (s *Server) didSave(ctx context.Context, uri string) error {
+	s.startRender(ctx, uriToPath(uri))
+
+	return s.publishDiagnostics(uri)
+}
+
+// didClose forgets a closed document and clears its diagnostics.
+func (s *Server) didClose(uri string) error {
+	path := uriToPath(uri)
+
+	s.mu.Lock()
+	delete(s.documents, path)
+
+	if cancel, ok := s.renders[path]; ok {
+		cancel()
+		delete(s.renders, path)
+	}
+	s.mu.Unlock()
+
+	_ = s.overlay.Remove(path)
+
+	return s.writer.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: uri, Diagnostics: []Diagnostic{}})
+}
+
+// publishDiagnostics publishes the problems that xprin lint finds in a testsuite file. Other documents, e.g. the
+// fragments that testsuite files include, have no diagnostics.
+func (s *Server) publishDiagnostics(uri string) error {
+	path := uriToPath(uri)
+	if !processor.IsTestSuiteFile(path) {
+		return nil
+	}
+
+	text := s.document(path)
+	diagnostics := []Diagnostic{}
+
+	for _, finding := range processor.LintTestSuiteFile(s.fs, path, s.options) {
+		line := max(finding.Line-1, 0)
+		content := lineAt(text, line)
+		indent := len(content) - len(strings.TrimLeft(content, " \t-"))
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Range: Range{
+				Start: Position{Line: line, Character: character(content, indent)},
+				End:   Position{Line: line, Character: character(content, len(content))},
+			},
+			Severity: severityError,
+			Source:   "xprin",
+			Message:  finding.Message,
+		})
+	}
+
+	return s.writer.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: uri, Diagnostics: diagnostics})
+}
+
+// startRender renders the test cases of a testsuite file in the background, to complete the resources of its
+// assertions, and stops its previous render if it is still running.
+func (s *Server) startRender(ctx context.Context, path string) {
+	if !s.render || !processor.IsTestSuiteFile(path) {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	s.mu.Lock()
+	if previous, ok := s.renders[path]; ok {
+		previous()
+	}
+
+	s.renders[path] = cancel
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		resources, err := s.renderedResources(ctx, path)
+		if err != nil {
+			utils.WarningPrintf("failed to render the test cases of %s: %v\n", path, err)
+			return
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.resources[path] = resources
+		s.mu.Unlock()
+	}()
+}
+
+// document returns the content of an open document, or else of the file on disk.
+func (s *Server) document(path string) string {
+	s.mu.Lock()
+	text, ok := s.documents[path]
+	s.mu.Unlock()
+
+	if !ok {
+		data, _ := afero.ReadFile(s.fs, path)
+		text = string(data)
+	}
+
+	return text
+}
+
+// uriToPath returns the path of a file URI, or the URI itself if it is not one.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+
+	return filepath.FromSlash(u.Path)
+}
+
+// pathToURI returns the file URI of an absolute path.
+func pathToURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
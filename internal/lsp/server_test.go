@@ -0,0 +1,264 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+const testSuite = `tests:
+- name: first
+  id: first
+  inputs:
+    xr: xr.yaml
+    composition: comp.yaml
+    functions: missing.yaml
+  assertions:
+    xprin:
+    - name: bucket exists
+      type: Exists
+      resource: 
+- name: second
+  hooks:
+    pre-test:
+    - run: echo {{ .Tests.first.Outputs.Render }}
+`
+
+// client is the editor side of a language server session.
+type client struct {
+	t      *testing.T
+	in     io.Writer
+	out    *bufio.Reader
+	nextID int
+}
+
+// notify sends a notification.
+func (c *client) notify(method string, params any) {
+	c.t.Helper()
+
+	c.send(&message{Method: method, Params: c.marshal(params)})
+}
+
+// request sends a request and returns its response.
+func (c *client) request(method string, params any) *message {
+	c.t.Helper()
+
+	c.nextID++
+	c.send(&message{ID: json.RawMessage(fmt.Sprint(c.nextID)), Method: method, Params: c.marshal(params)})
+
+	return c.receive()
+}
+
+// receive returns the next message of the server.
+func (c *client) receive() *message {
+	c.t.Helper()
+
+	msg, err := readMessage(c.out)
+	require.NoError(c.t, err)
+
+	return msg
+}
+
+func (c *client) send(msg *message) {
+	c.t.Helper()
+
+	w := &writer{out: c.in}
+	require.NoError(c.t, w.write(msg))
+}
+
+func (c *client) marshal(v any) json.RawMessage {
+	c.t.Helper()
+
+	data, err := json.Marshal(v)
+	require.NoError(c.t, err)
+
+	return data
+}
+
+// position returns the params of a request about a position of a document.
+func position(uri string, line, character int) TextDocumentPositionParams {
+	return TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{URI: uri}, Position: Position{Line: line, Character: character}}
+}
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "s3_xprin.yaml")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "xr.yaml"), []byte("kind: XBucket\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "comp.yaml"), []byte(""), 0o600))
+
+	server := NewServer(&testexecutionUtils.Options{}, true)
+	server.renderedResources = func(_ context.Context, path string) ([]string, error) {
+		assert.Equal(t, file, path)
+		return []string{"Bucket/logs", "Bucket/main"}, nil
+	}
+
+	clientIn, serverIn := io.Pipe()
+	serverOut, clientOut := io.Pipe()
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- server.Serve(context.Background(), clientIn, clientOut)
+	}()
+
+	c := &client{t: t, in: serverIn, out: bufio.NewReader(serverOut)}
+	uri := pathToURI(file)
+
+	t.Run("initialize", func(t *testing.T) {
+		response := c.request("initialize", map[string]any{})
+		require.Nil(t, response.Error)
+
+		var result struct {
+			Capabilities map[string]any `json:"capabilities"`
+			ServerInfo   struct {
+				Name string `json:"name"`
+			} `json:"serverInfo"`
+		}
+
+		require.NoError(t, json.Unmarshal(response.Result, &result))
+		assert.Equal(t, "xprin", result.ServerInfo.Name)
+		assert.Equal(t, true, result.Capabilities["definitionProvider"])
+		assert.Equal(t, true, result.Capabilities["hoverProvider"])
+
+		c.notify("initialized", map[string]any{})
+	})
+
+	t.Run("diagnostics", func(t *testing.T) {
+		c.notify("textDocument/didOpen", DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: uri, LanguageID: "yaml", Version: 1, Text: testSuite}})
+
+		msg := c.receive()
+		require.Equal(t, "textDocument/publishDiagnostics", msg.Method)
+
+		var params PublishDiagnosticsParams
+		require.NoError(t, json.Unmarshal(msg.Params, &params))
+		assert.Equal(t, uri, params.URI)
+
+		var lines []int
+		for _, diagnostic := range params.Diagnostics {
+			assert.Equal(t, severityError, diagnostic.Severity)
+
+			lines = append(lines, diagnostic.Range.Start.Line)
+		}
+
+		assert.Contains(t, lines, 6, "missing functions file")
+	})
+
+	t.Run("definition of a test case reference", func(t *testing.T) {
+		response := c.request("textDocument/definition", position(uri, 15, 28))
+
+		var location Location
+		require.NoError(t, json.Unmarshal(response.Result, &location))
+		assert.Equal(t, Location{URI: uri, Range: Range{Start: Position{Line: 2, Character: 6}, End: Position{Line: 2, Character: 11}}}, location)
+	})
+
+	t.Run("definition of an input path", func(t *testing.T) {
+		response := c.request("textDocument/definition", position(uri, 4, 10))
+
+		var location Location
+		require.NoError(t, json.Unmarshal(response.Result, &location))
+		assert.Equal(t, pathToURI(filepath.Join(dir, "xr.yaml")), location.URI)
+	})
+
+	t.Run("no definition of a missing file", func(t *testing.T) {
+		response := c.request("textDocument/definition", position(uri, 6, 18))
+		require.Nil(t, response.Error)
+		assert.Equal(t, "null", string(response.Result))
+	})
+
+	t.Run("hover on an assertion type", func(t *testing.T) {
+		response := c.request("textDocument/hover", position(uri, 10, 13))
+
+		var hover Hover
+		require.NoError(t, json.Unmarshal(response.Result, &hover))
+		assert.Equal(t, "markdown", hover.Contents.Kind)
+		assert.Contains(t, hover.Contents.Value, "**Exists** assertion")
+	})
+
+	t.Run("completion of rendered resources", func(t *testing.T) {
+		var list CompletionList
+
+		require.Eventually(t, func() bool {
+			response := c.request("textDocument/completion", position(uri, 11, 16))
+			require.NoError(t, json.Unmarshal(response.Result, &list))
+
+			return len(list.Items) > 0
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.Len(t, list.Items, 2)
+		assert.Equal(t, "Bucket/logs", list.Items[0].Label)
+		assert.Equal(t, &TextEdit{Range: Range{Start: Position{Line: 11, Character: 16}, End: Position{Line: 11, Character: 16}}, NewText: "Bucket/logs"}, list.Items[0].TextEdit)
+	})
+
+	t.Run("unsupported method", func(t *testing.T) {
+		response := c.request("workspace/symbol", map[string]any{})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, codeMethodNotFound, response.Error.Code)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		c.notify("textDocument/didClose", DidCloseTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: uri}})
+
+		var params PublishDiagnosticsParams
+		require.NoError(t, json.Unmarshal(c.receive().Params, &params))
+		assert.Empty(t, params.Diagnostics)
+	})
+
+	c.request("shutdown", nil)
+	c.notify("exit", nil)
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not exit")
+	}
+}
+
+func TestPositions(t *testing.T) {
+	line := "name: \"émoji 🚀 test\""
+
+	tests := []struct {
+		name      string
+		offset    int // Byte offset
+		character int // UTF-16 offset
+	}{
+		{name: "ASCII", offset: 6, character: 6},
+		{name: "after a 2-byte rune", offset: 9, character: 8},
+		{name: "after a surrogate pair", offset: 19, character: 16},
+		{name: "end of line", offset: len(line), character: 21},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.character, character(line, tt.offset))
+			assert.Equal(t, tt.offset, byteOffset(line, tt.character))
+		})
+	}
+}
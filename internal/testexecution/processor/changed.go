@@ -0,0 +1,294 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/gertd/go-pluralize"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/spf13/afero"
+)
+
+// ChangedReasonKind is the kind of a reason why a testsuite file is affected by changed files.
+type ChangedReasonKind string
+
+// Kinds of reasons why a testsuite file is affected by changed files.
+const (
+	ChangedReasonTestSuiteFile  ChangedReasonKind = "testsuite-file"   // The testsuite file changed
+	ChangedReasonIncludedFile   ChangedReasonKind = "included-file"    // An included fragment file changed
+	ChangedReasonInputFile      ChangedReasonKind = "input-file"       // An input, patch or golden file of a test case changed
+	ChangedReasonCannotBeLoaded ChangedReasonKind = "cannot-be-loaded" // The testsuite file cannot be loaded, so its error is reported
+)
+
+// ChangedReason is a reason why a testsuite file is affected by changed files.
+type ChangedReason struct {
+	Kind    ChangedReasonKind
+	Message string // e.g. "testsuite file changed" or "composition composition.yaml changed"
+}
+
+// ChangedSelection is a testsuite file that is affected by changed files, with the reasons why.
+type ChangedSelection struct {
+	File      string
+	Reasons   []ChangedReason
+	TestCases []int // Indexes of the affected test cases, nil if all of them are, e.g. because the testsuite file changed
+}
+
+// String returns the selection as the testsuite file followed by the messages of its reasons.
+func (s ChangedSelection) String() string {
+	messages := make([]string, 0, len(s.Reasons))
+	for _, reason := range s.Reasons {
+		messages = append(messages, reason.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", s.File, strings.Join(messages, ", "))
+}
+
+// SelectChangedTestSuiteFiles returns the testsuite files of the targets (see FindTestSuiteFiles) that are affected
+// by the files changed since a git ref, and prints why each one was selected. The changed files are those that differ
+// between the merge base of the ref and HEAD, and the working tree, including uncommitted and untracked files.
+func SelectChangedTestSuiteFiles(fs afero.Fs, targets []string, ref string, options *testexecutionUtils.Options) ([]string, error) {
+	files, err := FindTestSuiteFiles(fs, targets, options.Debug)
+	if err != nil {
+		return nil, err
+	}
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	changed, err := changedFiles(pwd, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Debug {
+		for _, file := range changed {
+			utils.DebugPrintf("Changed since %s: %s\n", ref, file)
+		}
+	}
+
+	selections := AffectedTestSuiteFiles(fs, files, changed, options)
+
+	plural := pluralize.NewClient()
+	utils.OutputPrintf("changed since %s: %d of %s selected\n", ref, len(selections), plural.Pluralize("testsuite file", len(files), true))
+
+	selected := []string{}
+
+	for _, selection := range selections {
+		utils.OutputPrintf("    %s\n", selection)
+
+		selected = append(selected, selection.File)
+	}
+
+	return selected, nil
+}
+
+// AffectedTestSuiteFiles returns the testsuite files that are affected by the changed files (absolute paths): those
+// that changed themselves, or whose included fragments, or the inputs, patches or golden files of whose test cases
+// changed (see runner.Runner.InputFiles). A changed file affects an input directory, e.g. functions, if it is in it.
+// Testsuite files that cannot be loaded are selected so that their error is reported. Only the test cases that read the
+// changed inputs, patches or golden files are affected, unless the testsuite file or its included fragments changed.
+func AffectedTestSuiteFiles(fs afero.Fs, files, changed []string, options *testexecutionUtils.Options) []ChangedSelection {
+	isChanged := func(path string) bool {
+		for _, file := range changed {
+			if file == path || strings.HasPrefix(file, path+string(filepath.Separator)) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	var selections []ChangedSelection
+
+	for _, file := range files {
+		var (
+			reasons   []ChangedReason
+			testCases []int
+			all       bool
+		)
+
+		addReason := func(kind ChangedReasonKind, message string) {
+			reason := ChangedReason{Kind: kind, Message: message}
+			if !slices.Contains(reasons, reason) {
+				reasons = append(reasons, reason)
+			}
+		}
+
+		if absPath, err := filepath.Abs(file); err == nil && isChanged(absPath) {
+			addReason(ChangedReasonTestSuiteFile, "testsuite file changed")
+
+			all = true
+		}
+
+		testSuiteSpec, included, err := loadTestSuite(fs, file)
+
+		switch {
+		case err != nil && strings.HasPrefix(err.Error(), "no test cases found"):
+		case err != nil:
+			addReason(ChangedReasonCannotBeLoaded, "testsuite file cannot be loaded")
+
+			all = true
+		default:
+			for _, fragment := range included {
+				if isChanged(fragment) {
+					addReason(ChangedReasonIncludedFile, fmt.Sprintf("included file %s changed", relativePath(fragment)))
+
+					all = true
+				}
+			}
+
+			for _, input := range runner.NewRunner(options, file, testSuiteSpec).InputFiles() {
+				if isChanged(input.Path) {
+					addReason(ChangedReasonInputFile, fmt.Sprintf("%s %s changed", input.Description, input.Value))
+
+					for _, i := range input.TestCases {
+						if !slices.Contains(testCases, i) {
+							testCases = append(testCases, i)
+						}
+					}
+				}
+			}
+		}
+
+		if len(reasons) == 0 {
+			if options.Debug {
+				utils.DebugPrintf("Skipping testsuite file %s because it is not affected by the changed files\n", file)
+			}
+
+			continue
+		}
+
+		selection := ChangedSelection{File: file, Reasons: reasons}
+		if !all {
+			slices.Sort(testCases)
+			selection.TestCases = testCases
+		}
+
+		selections = append(selections, selection)
+	}
+
+	return selections
+}
+
+// changedFiles returns the absolute paths of the files of the git repository of dir that changed since a ref: the
+// files that differ between the merge base of the ref and HEAD (or the ref itself, if they have none) and HEAD, and
+// the files that are modified, staged or untracked in the working tree.
+func changedFiles(dir, ref string) ([]string, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository of %s: %w", dir, err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git worktree of %s: %w", dir, err)
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve git ref %s: %w", ref, err)
+	}
+
+	base, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit of git ref %s: %w", ref, err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve git HEAD: %w", err)
+	}
+
+	headCommit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit of git HEAD: %w", err)
+	}
+
+	if mergeBases, err := headCommit.MergeBase(base); err == nil && len(mergeBases) > 0 {
+		base = mergeBases[0]
+	}
+
+	baseTree, err := base.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree of git ref %s: %w", ref, err)
+	}
+
+	headTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree of git HEAD: %w", err)
+	}
+
+	changes, err := object.DiffTree(baseTree, headTree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff git HEAD against %s: %w", ref, err)
+	}
+
+	names := make(map[string]bool)
+
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name != "" {
+				names[name] = true
+			}
+		}
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git status: %w", err)
+	}
+
+	for name, fileStatus := range status {
+		if fileStatus.Staging != git.Unmodified || fileStatus.Worktree != git.Unmodified {
+			names[name] = true
+		}
+	}
+
+	root := worktree.Filesystem.Root()
+	files := make([]string, 0, len(names))
+
+	for name := range names {
+		files = append(files, filepath.Join(root, filepath.FromSlash(name)))
+	}
+
+	slices.Sort(files)
+
+	return files, nil
+}
+
+// relativePath returns a path relative to the working directory, or the path itself if it is outside of it.
+func relativePath(path string) string {
+	if pwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(pwd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+
+	return path
+}
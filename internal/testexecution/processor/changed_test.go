@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestAffectedTestSuiteFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	files := map[string]string{
+		"/repo/tests/aws_xprin.yaml": `include:
+- path: common.yaml
+tests:
+- name: network
+  inputs:
+    xr: xr.yaml
+    crds:
+    - ../apis/crds
+- name: storage
+  inputs:
+    xr: storage-xr.yaml
+`,
+		"/repo/tests/common.yaml": `common:
+  inputs:
+    composition: ../apis/composition.yaml
+    functions: ../functions
+`,
+		"/repo/tests/gcp_xprin.yaml": `tests:
+- name: cluster
+  inputs:
+    xr: gcp-xr.yaml
+    composition: ../apis/gcp.yaml
+    functions: ../functions
+  assertions:
+    diff:
+    - name: golden
+      expected: golden/cluster.yaml
+`,
+		"/repo/tests/empty_xprin.yaml":   "tests: []\n",
+		"/repo/tests/invalid_xprin.yaml": "tests:\n- name: a\n  timeout: 5x\n",
+	}
+
+	for file, content := range files {
+		require.NoError(t, afero.WriteFile(fs, file, []byte(content), 0o644))
+	}
+
+	suites := []string{"/repo/tests/aws_xprin.yaml", "/repo/tests/empty_xprin.yaml", "/repo/tests/gcp_xprin.yaml", "/repo/tests/invalid_xprin.yaml"}
+
+	tests := []struct {
+		name    string
+		changed []string
+		want    []ChangedSelection
+	}{
+		{
+			name:    "nothing affected",
+			changed: []string{"/repo/README.md", "/repo/apis/crdsfoo.yaml"},
+			want: []ChangedSelection{
+				{File: "/repo/tests/invalid_xprin.yaml", Reasons: []ChangedReason{{Kind: ChangedReasonCannotBeLoaded, Message: "testsuite file cannot be loaded"}}},
+			},
+		},
+		{
+			name:    "testsuite file, input and golden file",
+			changed: []string{"/repo/tests/gcp_xprin.yaml", "/repo/tests/golden/cluster.yaml", "/repo/tests/xr.yaml"},
+			want: []ChangedSelection{
+				{File: "/repo/tests/aws_xprin.yaml", Reasons: []ChangedReason{{Kind: ChangedReasonInputFile, Message: "XR xr.yaml changed"}}, TestCases: []int{0}},
+				{File: "/repo/tests/gcp_xprin.yaml", Reasons: []ChangedReason{
+					{Kind: ChangedReasonTestSuiteFile, Message: "testsuite file changed"},
+					{Kind: ChangedReasonInputFile, Message: "golden file golden/cluster.yaml changed"},
+				}},
+				{File: "/repo/tests/invalid_xprin.yaml", Reasons: []ChangedReason{{Kind: ChangedReasonCannotBeLoaded, Message: "testsuite file cannot be loaded"}}},
+			},
+		},
+		{
+			name:    "included file and file in an input directory",
+			changed: []string{"/repo/tests/common.yaml", "/repo/functions/fn.yaml", "/repo/apis/crds/xrd.yaml"},
+			want: []ChangedSelection{
+				{File: "/repo/tests/aws_xprin.yaml", Reasons: []ChangedReason{
+					{Kind: ChangedReasonIncludedFile, Message: "included file /repo/tests/common.yaml changed"},
+					{Kind: ChangedReasonInputFile, Message: "functions ../functions changed"},
+					{Kind: ChangedReasonInputFile, Message: "crd ../apis/crds changed"},
+				}},
+				{File: "/repo/tests/gcp_xprin.yaml", Reasons: []ChangedReason{{Kind: ChangedReasonInputFile, Message: "functions ../functions changed"}}, TestCases: []int{0}},
+				{File: "/repo/tests/invalid_xprin.yaml", Reasons: []ChangedReason{{Kind: ChangedReasonCannotBeLoaded, Message: "testsuite file cannot be loaded"}}},
+			},
+		},
+		{
+			name:    "inputs of some test cases",
+			changed: []string{"/repo/tests/storage-xr.yaml"},
+			want: []ChangedSelection{
+				{File: "/repo/tests/aws_xprin.yaml", Reasons: []ChangedReason{{Kind: ChangedReasonInputFile, Message: "XR storage-xr.yaml changed"}}, TestCases: []int{1}},
+				{File: "/repo/tests/invalid_xprin.yaml", Reasons: []ChangedReason{{Kind: ChangedReasonCannotBeLoaded, Message: "testsuite file cannot be loaded"}}},
+			},
+		},
+		{
+			name:    "common input of all test cases",
+			changed: []string{"/repo/apis/composition.yaml", "/repo/tests/storage-xr.yaml"},
+			want: []ChangedSelection{
+				{File: "/repo/tests/aws_xprin.yaml", Reasons: []ChangedReason{
+					{Kind: ChangedReasonInputFile, Message: "composition ../apis/composition.yaml changed"},
+					{Kind: ChangedReasonInputFile, Message: "XR storage-xr.yaml changed"},
+				}, TestCases: []int{0, 1}},
+				{File: "/repo/tests/invalid_xprin.yaml", Reasons: []ChangedReason{{Kind: ChangedReasonCannotBeLoaded, Message: "testsuite file cannot be loaded"}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AffectedTestSuiteFiles(fs, suites, tt.changed, &testexecutionUtils.Options{}))
+		})
+	}
+}
+
+func TestChangedSelection_String(t *testing.T) {
+	selection := ChangedSelection{File: "tests/aws_xprin.yaml", Reasons: []ChangedReason{
+		{Kind: ChangedReasonTestSuiteFile, Message: "testsuite file changed"},
+		{Kind: ChangedReasonInputFile, Message: "XR xr.yaml changed"},
+	}}
+
+	assert.Equal(t, "tests/aws_xprin.yaml: testsuite file changed, XR xr.yaml changed", selection.String())
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	unittestsUtils.CreateGitRepo(t, unittestsUtils.GitRepoOptions{Path: dir})
+
+	repo, err := git.PlainOpen(dir)
+	require.NoError(t, err)
+
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(files map[string]string) string {
+		for file, content := range files {
+			path := filepath.Join(dir, file)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			_, err := worktree.Add(file)
+			require.NoError(t, err)
+		}
+
+		hash, err := worktree.Commit("commit", &git.CommitOptions{
+			Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+
+		return hash.String()
+	}
+
+	base := commit(map[string]string{"a.yaml": "a", "b.yaml": "b", "tests/c.yaml": "c"})
+	commit(map[string]string{"b.yaml": "b2", "tests/d.yaml": "d"})
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tests", "c.yaml"), []byte("c2"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "e.yaml"), []byte("e"), 0o600))
+
+	changed, err := changedFiles(filepath.Join(dir, "tests"), base)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "b.yaml"),
+		filepath.Join(dir, "e.yaml"),
+		filepath.Join(dir, "tests", "c.yaml"),
+		filepath.Join(dir, "tests", "d.yaml"),
+	}, changed)
+
+	_, err = changedFiles(dir, "unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to resolve git ref unknown")
+}
@@ -18,11 +18,14 @@ limitations under the License.
 package processor
 
 import (
+	"errors"
 	"fmt"
+	iofs "io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/crossplane-contrib/xprin/internal/utils"
 	"github.com/spf13/afero"
 )
 
@@ -100,3 +103,86 @@ func isValidTestSuiteFileName(filename string) bool {
 	base := filepath.Base(filename)
 	return base == "xprin.yaml" || (strings.HasSuffix(base, "_xprin.yaml") && len(base) > len("_xprin.yaml"))
 }
+
+// IsTestSuiteFile returns true if a file is named like a testsuite file: xprin.yaml or *_xprin.yaml.
+func IsTestSuiteFile(path string) bool {
+	return isValidTestSuiteFileName(path)
+}
+
+// FindTestSuiteFiles returns the testsuite files of the targets, in the same way as ProcessTargets finds them:
+// targets are files, directories, or recursive directories ending with "...". Targets that do not exist,
+// directories without testsuite files and files that are not named like testsuite files are skipped.
+func FindTestSuiteFiles(fs afero.Fs, targets []string, debug bool) ([]string, error) {
+	var files []string
+
+	addDirectory := func(dir string) error {
+		dirFiles, err := findTestSuiteFiles(fs, dir)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "no test files found matching pattern") {
+				if debug {
+					utils.DebugPrintf("No testsuite files in directory %s\n", dir)
+				}
+
+				return nil
+			}
+
+			return err
+		}
+
+		files = append(files, dirFiles...)
+
+		return nil
+	}
+
+	for _, path := range targets {
+		if strings.HasSuffix(path, "...") {
+			root := strings.TrimSuffix(strings.TrimSuffix(path, "..."), string(filepath.Separator))
+
+			dirs, err := recursiveDirs(fs, root)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find testsuite files in %s: %w", root, err)
+			}
+
+			for _, dir := range dirs {
+				if err := addDirectory(dir); err != nil {
+					return nil, err
+				}
+			}
+
+			continue
+		}
+
+		info, err := fs.Stat(path)
+		if errors.Is(err, iofs.ErrNotExist) {
+			if debug {
+				utils.DebugPrintf("Skipping test path %s because it does not exist\n", path)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to access test path %s: %w", path, err)
+		}
+
+		if info.IsDir() {
+			if err := addDirectory(path); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if !isValidTestSuiteFileName(path) {
+			if debug {
+				utils.DebugPrintf("Skipping file %s because it is not a valid test file. It should be named 'xprin.yaml' or end with '_xprin.yaml' with at least one character before the underscore\n", path)
+			}
+
+			continue
+		}
+
+		files = append(files, path)
+	}
+
+	return files, nil
+}
@@ -156,6 +156,35 @@ func TestFindTestSuiteFiles(t *testing.T) {
 	})
 }
 
+func TestFindTestSuiteFiles_Targets(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	for _, file := range []string{"/tests/xprin.yaml", "/tests/aws/aws_xprin.yaml", "/tests/aws/other.yaml", "/tests/gcp/gcp_xprin.yaml"} {
+		require.NoError(t, afero.WriteFile(fs, file, []byte("tests: []\n"), 0o644))
+	}
+
+	require.NoError(t, fs.MkdirAll("/tests/empty", 0o755))
+
+	tests := []struct {
+		name     string
+		targets  []string
+		expected []string
+	}{
+		{"file", []string{"/tests/gcp/gcp_xprin.yaml"}, []string{"/tests/gcp/gcp_xprin.yaml"}},
+		{"directory", []string{"/tests/aws"}, []string{"/tests/aws/aws_xprin.yaml"}},
+		{"recursive directory", []string{"/tests/..."}, []string{"/tests/xprin.yaml", "/tests/aws/aws_xprin.yaml", "/tests/gcp/gcp_xprin.yaml"}},
+		{"skipped targets", []string{"/tests/empty", "/tests/missing", "/tests/aws/other.yaml"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := FindTestSuiteFiles(fs, tt.targets, false)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, files)
+		})
+	}
+}
+
 func TestIsValidTestSuiteFileName(t *testing.T) {
 	testCases := []struct {
 		name     string
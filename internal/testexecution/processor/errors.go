@@ -19,15 +19,32 @@ package processor
 import (
 	"fmt"
 	"os"
+
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 )
 
+// reportedError is an error that was reported for a target whose tests could not be run.
+type reportedError struct {
+	msg string
+}
+
+// Error implements error.
+func (e *reportedError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns testexecutionUtils.ErrTestsErrored, so that the error is reported with the exit code for tests that could not be run.
+func (e *reportedError) Unwrap() error {
+	return testexecutionUtils.ErrTestsErrored
+}
+
 // reportError handles error reporting: print detailed error and FAIL status, returns the error for tracking.
 func reportError(target, failureReason string, err error) error {
 	errorMsg := fmt.Sprintf("%s in %s: %v", failureReason, target, err)
 	fmt.Fprintf(os.Stderr, "# %s\n%s\n", target, errorMsg)
 	fmt.Fprintf(os.Stderr, "FAIL\t%s\t[%s]\n", target, failureReason)
 
-	return fmt.Errorf("%s", errorMsg)
+	return &reportedError{msg: errorMsg}
 }
 
 // reportTestSuiteError handles error reporting for test suite files with detailed error message.
@@ -36,5 +53,5 @@ func reportTestSuiteError(testSuiteFile string, err error, failureReason string)
 	fmt.Fprintf(os.Stderr, "%s\n", errMsg)
 	fmt.Fprintf(os.Stderr, "FAIL\t%s\t[%s]\n", testSuiteFile, failureReason)
 
-	return fmt.Errorf("%s", errMsg)
+	return &reportedError{msg: errMsg}
 }
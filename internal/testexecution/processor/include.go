@@ -0,0 +1,282 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+)
+
+// includeResolver resolves the fragments included by a testsuite file.
+type includeResolver struct {
+	fs            afero.Fs
+	testSuiteFile string
+	stack         []string // Fragment files being resolved, used to detect include cycles
+	included      []string // Fragment files included so far, directly or by other fragments
+}
+
+// resolveIncludes merges the common config and vars of all included fragments into the testsuite's ones,
+// and expands the assertion and hook sets referenced from common and from the test cases.
+// It returns the absolute paths of the included fragment files, or an api.TestSuiteError about the value
+// of the testsuite file that cannot be resolved.
+func resolveIncludes(fs afero.Fs, testSuiteFile string, spec *api.TestSuiteSpec) ([]string, error) {
+	r := &includeResolver{fs: fs, testSuiteFile: testSuiteFile}
+
+	absPath, err := utils.ExpandPathRelativeToTestSuiteFile(testSuiteFile, filepath.Base(testSuiteFile))
+	if err != nil {
+		return nil, err
+	}
+
+	r.stack = []string{absPath}
+
+	assertionSets, hookSets, err := r.resolve(spec, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range spec.Tests {
+		test := &spec.Tests[i]
+
+		if err := test.Assertions.ResolveSets(assertionSets); err != nil {
+			return nil, api.TestSuiteErrorf([]any{"tests", i, "assertions"}, "test case '%s': %w", test.Name, err)
+		}
+
+		if err := test.Hooks.ResolveSets(hookSets); err != nil {
+			return nil, api.TestSuiteErrorf([]any{"tests", i, "hooks"}, "test case '%s': %w", test.Name, err)
+		}
+	}
+
+	return r.included, nil
+}
+
+// resolve resolves the includes of a testsuite or fragment located at relDir (relative to the testsuite file directory).
+// It merges the included common config and vars into spec.Common, spec.Vars and spec.Env, expands the sets referenced from spec.Common,
+// and returns all sets visible from spec: its own by name, and those of included fragments as "<namespace>.<name>".
+// Its errors are api.TestSuiteErrors about the values of spec.
+func (r *includeResolver) resolve(spec *api.TestSuiteSpec, relDir string) (map[string]api.Assertions, map[string]api.Hooks, error) {
+	assertionSets := make(map[string]api.Assertions)
+	hookSets := make(map[string]api.Hooks)
+
+	for name, set := range spec.AssertionSets {
+		if set.HasSets() {
+			return nil, nil, api.TestSuiteErrorf([]any{"assertion-sets", name}, "assertion set '%s' cannot reference other assertion sets", name)
+		}
+
+		assertionSets[name] = set
+	}
+
+	for name, set := range spec.HookSets {
+		if set.HasSets() {
+			return nil, nil, api.TestSuiteErrorf([]any{"hook-sets", name}, "hook set '%s' cannot reference other hook sets", name)
+		}
+
+		hookSets[name] = set
+	}
+
+	var (
+		included     api.Common
+		includedVars = make(map[string]string)
+		includedEnv  []string
+		namespaces   = make(map[string]string)
+	)
+
+	for i, include := range spec.Include {
+		path := []any{"include", i}
+
+		if include.Path == "" {
+			return nil, nil, api.TestSuiteErrorf(path, "include has empty path")
+		}
+
+		namespace := include.GetNamespace()
+		if !isValidNamespace(namespace) {
+			return nil, nil, api.TestSuiteErrorf(append(path, "namespace"), "include %s has invalid namespace '%s' (allowed: alphanumeric, underscore, hyphen)", include.Path, namespace)
+		}
+
+		if other, ok := namespaces[namespace]; ok {
+			return nil, nil, api.TestSuiteErrorf(path, "includes %s and %s have the same namespace '%s'", other, include.Path, namespace)
+		}
+
+		namespaces[namespace] = include.Path
+
+		fragment, fragmentRelDir, err := r.load(include.Path, relDir)
+		if err != nil {
+			return nil, nil, &api.TestSuiteError{Path: path, Err: err}
+		}
+
+		fragmentAssertionSets, fragmentHookSets, err := r.resolve(fragment, fragmentRelDir)
+
+		r.stack = r.stack[:len(r.stack)-1]
+
+		if err != nil {
+			return nil, nil, api.TestSuiteErrorf(path, "failed to include %s: %w", include.Path, err)
+		}
+
+		// Later includes take precedence over earlier ones
+		fragment.Common.MergeCommon(included)
+		included = fragment.Common
+		maps.Copy(includedVars, fragment.Vars)
+		includedEnv = append(includedEnv, fragment.Env...)
+
+		for name, set := range fragmentAssertionSets {
+			assertionSets[namespace+"."+name] = set
+		}
+
+		for name, set := range fragmentHookSets {
+			hookSets[namespace+"."+name] = set
+		}
+	}
+
+	// The including file takes precedence over all its includes
+	spec.Common.MergeCommon(included)
+
+	if len(includedVars) > 0 {
+		maps.Copy(includedVars, spec.Vars)
+		spec.Vars = includedVars
+	}
+
+	for _, name := range includedEnv {
+		if !slices.Contains(spec.Env, name) {
+			spec.Env = append(spec.Env, name)
+		}
+	}
+
+	if err := spec.Common.Assertions.ResolveSets(assertionSets); err != nil {
+		return nil, nil, api.TestSuiteErrorf([]any{"common", "assertions"}, "common: %w", err)
+	}
+
+	if err := spec.Common.Hooks.ResolveSets(hookSets); err != nil {
+		return nil, nil, api.TestSuiteErrorf([]any{"common", "hooks"}, "common: %w", err)
+	}
+
+	return assertionSets, hookSets, nil
+}
+
+// load reads and parses a fragment file included from relDir, pushes it on the include stack,
+// and rebases its relative paths so that they are relative to the testsuite file directory.
+func (r *includeResolver) load(path, relDir string) (*api.TestSuiteSpec, string, error) {
+	fragmentPath := rebasePath(relDir, path)
+
+	absPath, err := utils.ExpandPathRelativeToTestSuiteFile(r.testSuiteFile, fragmentPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to expand include path %s: %w", path, err)
+	}
+
+	for i, p := range r.stack {
+		if p == absPath {
+			cycle := append(append([]string{}, r.stack[i:]...), absPath)
+			return nil, "", fmt.Errorf("include cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	data, err := afero.ReadFile(r.fs, absPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read included file %s: %w", path, err)
+	}
+
+	var fragment api.TestSuiteSpec
+	if err := parse(path, data, &fragment); err != nil {
+		return nil, "", fmt.Errorf("failed to parse included file %s: %w", path, err)
+	}
+
+	if len(fragment.Tests) > 0 {
+		return nil, "", fmt.Errorf("included file %s must not contain tests", path)
+	}
+
+	r.stack = append(r.stack, absPath)
+	r.included = append(r.included, absPath)
+
+	fragmentRelDir := filepath.Dir(fragmentPath)
+	rebaseCommon(fragmentRelDir, &fragment.Common)
+
+	for name, set := range fragment.AssertionSets {
+		rebaseAssertions(fragmentRelDir, &set)
+		fragment.AssertionSets[name] = set
+	}
+
+	return &fragment, fragmentRelDir, nil
+}
+
+// isValidNamespace checks if a namespace contains only alphanumeric characters, underscores, and hyphens.
+func isValidNamespace(namespace string) bool {
+	if namespace == "" {
+		return false
+	}
+
+	for _, char := range namespace {
+		if (char < 'a' || char > 'z') && (char < 'A' || char > 'Z') && (char < '0' || char > '9') && char != '_' && char != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rebasePath joins a relative path with relDir.
+// Empty, absolute, home-relative and templated paths are returned unchanged.
+func rebasePath(relDir, path string) string {
+	if relDir == "." || path == "" || filepath.IsAbs(path) || strings.HasPrefix(path, "~") || strings.HasPrefix(path, "{{") {
+		return path
+	}
+
+	return filepath.Join(relDir, path)
+}
+
+// rebaseCommon rebases the relative paths of a fragment's common config.
+func rebaseCommon(relDir string, common *api.Common) {
+	inputs := &common.Inputs
+	inputs.Claim = rebasePath(relDir, inputs.Claim)
+	inputs.XR = rebasePath(relDir, inputs.XR)
+	inputs.Composition = rebasePath(relDir, inputs.Composition)
+	inputs.Functions = rebasePath(relDir, inputs.Functions)
+	inputs.ObservedResources = rebasePath(relDir, inputs.ObservedResources)
+	inputs.ExtraResources = rebasePath(relDir, inputs.ExtraResources)
+	inputs.FunctionCredentials = rebasePath(relDir, inputs.FunctionCredentials)
+
+	if inputs.XRFromXRD != nil {
+		inputs.XRFromXRD.XRD = rebasePath(relDir, inputs.XRFromXRD.XRD)
+	}
+
+	for i, crd := range inputs.CRDs {
+		inputs.CRDs[i] = rebasePath(relDir, crd)
+	}
+
+	for key, path := range inputs.ContextFiles {
+		inputs.ContextFiles[key] = rebasePath(relDir, path)
+	}
+
+	common.Patches.XRD = rebasePath(relDir, common.Patches.XRD)
+
+	rebaseAssertions(relDir, &common.Assertions)
+}
+
+// rebaseAssertions rebases the golden file paths of diff and dyff assertions.
+func rebaseAssertions(relDir string, assertions *api.Assertions) {
+	for i := range assertions.Diff {
+		assertions.Diff[i].Expected = rebasePath(relDir, assertions.Diff[i].Expected)
+	}
+
+	for i := range assertions.Dyff {
+		assertions.Dyff[i].Expected = rebasePath(relDir, assertions.Dyff[i].Expected)
+	}
+}
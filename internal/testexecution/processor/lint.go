@@ -0,0 +1,305 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/gertd/go-pluralize"
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v3"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// yamlErrorLinePattern matches the line number in a YAML syntax error.
+var yamlErrorLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// Finding is a problem found in a testsuite file without running it.
+type Finding struct {
+	File    string
+	Line    int // 0 if the problem is not located at a line
+	Message string
+}
+
+// String returns the finding as file:line: message.
+func (f Finding) String() string {
+	if f.Line == 0 {
+		return fmt.Sprintf("%s: %s", f.File, f.Message)
+	}
+
+	return fmt.Sprintf("%s:%d: %s", f.File, f.Line, f.Message)
+}
+
+// LintTargets checks the testsuite files of the targets without running them, and prints the problems found as
+// file:line: message. The files are validated against the JSON schema of testsuite files and checked as when they are
+// run: the includes, test case IDs, extends, needs and timeouts, the mandatory fields of each test case with common
+// merged, the input and golden files, the assertions and the templates. It returns an error wrapping
+// testexecutionUtils.ErrLintFailed if problems were found.
+func LintTargets(fs afero.Fs, targets []string, options *testexecutionUtils.Options) error {
+	files, err := FindTestSuiteFiles(fs, targets, options.Debug)
+	if err != nil {
+		return err
+	}
+
+	plural := pluralize.NewClient()
+
+	var findings []Finding
+
+	for _, file := range files {
+		if options.Debug {
+			utils.DebugPrintf("Linting testsuite file %s\n", file)
+		}
+
+		findings = append(findings, LintTestSuiteFile(fs, file, options)...)
+
+		if data, err := afero.ReadFile(fs, file); err == nil {
+			if meta, err := api.ParseTypeMeta(data); err == nil && !meta.IsVersioned() {
+				warnOnce(file, "%s: %s\n", filepath.Base(file), unversionedMessage)
+			}
+		}
+	}
+
+	for _, finding := range findings {
+		utils.OutputPrintf("%s\n", finding)
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("found %s in %s: %w", plural.Pluralize("problem", len(findings), true),
+			plural.Pluralize("testsuite file", len(files), true), testexecutionUtils.ErrLintFailed)
+	}
+
+	utils.OutputPrintf("ok\t%s, no problems found\n", plural.Pluralize("testsuite file", len(files), true))
+
+	return nil
+}
+
+// LintTestSuiteFile checks a testsuite file without running it (see LintTargets) and returns the problems found,
+// ordered by line.
+func LintTestSuiteFile(fs afero.Fs, file string, options *testexecutionUtils.Options) []Finding {
+	findings := lintTestSuiteFile(fs, file, options)
+
+	for i := range findings {
+		findings[i].File = file
+	}
+
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return a.Line - b.Line
+	})
+
+	return findings
+}
+
+// lintTestSuiteFile returns the problems of a testsuite file, without the file.
+//
+//nolint:gocognit,gocyclo // sequence of independent checks
+func lintTestSuiteFile(fs afero.Fs, file string, options *testexecutionUtils.Options) []Finding {
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return []Finding{{Message: fmt.Sprintf("failed to read testsuite file: %v", err)}}
+	}
+
+	doc, err := parseTestSuiteDocument(data)
+	if err != nil {
+		return []Finding{{Line: yamlErrorLine(err), Message: err.Error()}}
+	}
+
+	root, data := doc.root, doc.data
+
+	// A file of another version of the format is not checked against this one
+	if meta, err := api.ParseTypeMeta(data); err == nil {
+		if err := meta.Check(); err != nil {
+			field := "apiVersion"
+			if meta.APIVersion == "" || slices.Contains(api.SupportedAPIVersions(), meta.APIVersion) {
+				field = "kind"
+			}
+
+			return []Finding{{Line: nodeLine(&root, field), Message: err.Error()}}
+		}
+	}
+
+	schema, err := api.Schema()
+	if err != nil {
+		return []Finding{{Message: err.Error()}}
+	}
+
+	findings := validateSchema(&root, schema)
+
+	for _, line := range doc.unquoted {
+		findings = append(findings, Finding{Line: line, Message: unquotedTemplateMessage})
+	}
+
+	// raw is the testsuite as written in the file, spec the one that runs, with the includes resolved
+	var raw, spec api.TestSuiteSpec
+	if err := sigsyaml.Unmarshal(data, &raw); err != nil {
+		if len(findings) == 0 {
+			findings = append(findings, Finding{Line: yamlErrorLine(err), Message: err.Error()})
+		}
+
+		return findings
+	}
+
+	if len(raw.Tests) == 0 {
+		return append(findings, Finding{Line: nodeLine(&root, "tests"), Message: "no test cases found"})
+	}
+
+	// Unlike loadTestSuite, go on after the errors of the includes and of the validation, to find all the problems
+	_ = sigsyaml.Unmarshal(data, &spec)
+
+	_, includesErr := resolveIncludes(fs, file, &spec)
+	if includesErr != nil {
+		findings = append(findings, testSuiteErrorFinding(&root, includesErr))
+	}
+
+	var testSuiteErrors api.TestSuiteErrors
+	if err := spec.CheckValidTestSuiteFile(); errors.As(err, &testSuiteErrors) {
+		for _, testSuiteError := range testSuiteErrors {
+			findings = append(findings, testSuiteErrorFinding(&root, testSuiteError))
+		}
+	} else if includesErr == nil {
+		spec.ResolveExtends()
+
+		for i, testCase := range spec.Tests {
+			testCase.MergeCommon(spec.Common)
+
+			if err := testCase.CheckMandatoryFields(); err != nil {
+				for _, message := range strings.Split(err.Error(), "\n") {
+					findings = append(findings, Finding{
+						Line:    nodeLine(&root, "tests", i),
+						Message: fmt.Sprintf("test case '%s': %s", testCase.Name, strings.TrimSpace(message)),
+					})
+				}
+			}
+		}
+	}
+
+	var ids []string
+
+	for _, testCase := range raw.Tests {
+		if testCase.ID != "" {
+			ids = append(ids, testCase.ID)
+		}
+	}
+
+	refs := runner.TemplateReferences{
+		TestIDs:      ids,
+		Vars:         slices.Sorted(maps.Keys(spec.Vars)),
+		Repositories: slices.Sorted(maps.Keys(options.Repositories)),
+		Env:          spec.Env,
+	}
+	refs.Vars = append(refs.Vars, slices.Sorted(maps.Keys(options.Vars))...)
+
+	findings = append(findings, templateFindings(&root, func(name, value string, keys []string) error {
+		if err := checkTemplate(name, value, keys); err != nil {
+			return err
+		}
+
+		return runner.CheckTemplateReferences(name, value, refs)
+	})...)
+
+	// The paths of the testsuite can use the vars and environment variables of its includes
+	raw.Vars = spec.Vars
+	raw.Env = spec.Env
+
+	for _, problem := range runner.NewRunner(options, file, &raw).Lint() {
+		findings = append(findings, Finding{Line: nodeLine(&root, problem.Path...), Message: problem.Message})
+	}
+
+	return findings
+}
+
+// testSuiteErrorFinding returns the finding of an error of the testsuite file, at the line of the value it is about
+// if it is an api.TestSuiteError.
+func testSuiteErrorFinding(root *yaml.Node, err error) Finding {
+	finding := Finding{Message: err.Error()}
+
+	var testSuiteError *api.TestSuiteError
+	if errors.As(err, &testSuiteError) {
+		finding.Line = nodeLine(root, testSuiteError.Path...)
+	}
+
+	return finding
+}
+
+// nodeLine returns the line of the value at path (keys and list indexes) in a YAML document,
+// or of the deepest value on the way to it if it does not exist.
+func nodeLine(root *yaml.Node, path ...any) int {
+	node := root
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return 0
+		}
+
+		node = node.Content[0]
+	}
+
+	line := node.Line
+
+	for _, key := range path {
+		var next *yaml.Node
+
+		switch k := key.(type) {
+		case string:
+			if node.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == k {
+						next = node.Content[i+1]
+						line = node.Content[i].Line
+					}
+				}
+			}
+		case int:
+			if node.Kind == yaml.SequenceNode && k < len(node.Content) {
+				next = node.Content[k]
+				line = next.Line
+			}
+		}
+
+		if next == nil {
+			break
+		}
+
+		node = next
+		if node.Kind == yaml.AliasNode && node.Alias != nil {
+			node = node.Alias
+		}
+	}
+
+	return line
+}
+
+// yamlErrorLine returns the line number of a YAML syntax error, or 0 if it has none.
+func yamlErrorLine(err error) int {
+	m := yamlErrorLinePattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+
+	line, _ := strconv.Atoi(m[1])
+
+	return line
+}
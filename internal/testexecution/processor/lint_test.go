@@ -0,0 +1,331 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	"go.yaml.in/yaml/v3"
+)
+
+func TestValidateSchema(t *testing.T) {
+	schema, err := api.Schema()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []Finding
+	}{
+		{
+			name: "valid testsuite",
+			content: `include:
+- fragment.yaml
+- path: other.yaml
+  namespace: other
+vars:
+  region: eu
+tests:
+- name: inline
+  timeout: 30
+  inputs:
+    xr:
+      kind: XR
+    crds: [crd.yaml]
+  assertions:
+    xprin:
+    - name: count
+      type: Count
+      value: 1
+`,
+		},
+		{
+			name: "unknown field, missing required field and invalid value",
+			content: `tests:
+- name: typo
+  inputs:
+    composiiton: composition.yaml
+  assertions:
+    xprin:
+    - type: Exist
+`,
+			expected: []Finding{
+				{Line: 4, Message: "tests[0].inputs: unknown field 'composiiton'"},
+				{Line: 7, Message: "tests[0].assertions.xprin[0].type: invalid value 'Exist' (allowed: Count, Exists, NotExists, FieldType, FieldExists, FieldNotExists, FieldValue)"},
+				{Line: 7, Message: "tests[0].assertions.xprin[0]: missing required field 'name'"},
+			},
+		},
+		{
+			name: "wrong types",
+			content: `tests:
+  name: not a list
+vars: [a]
+`,
+			expected: []Finding{
+				{Line: 2, Message: "tests: must be an array"},
+				{Line: 3, Message: "vars: must be an object"},
+			},
+		},
+		{
+			name: "one of the forms of a value",
+			content: `include:
+- path: fragment.yaml
+  prefix: x
+tests:
+- name: list xr
+  inputs:
+    xr: [a]
+`,
+			expected: []Finding{
+				{Line: 3, Message: "include[0]: unknown field 'prefix'"},
+				{Line: 7, Message: "tests[0].inputs.xr: must be a string or an object"},
+			},
+		},
+		{
+			name: "scalars where strings are expected and null values",
+			content: `vars:
+  replicas: 3
+  enabled: true
+  since: 2024-01-01
+tests:
+- name: 42
+  inputs:
+  assertions:
+    xprin:
+    - name: count
+      type: Count
+      value: 1
+`,
+		},
+		{
+			name: "aliases and merge keys",
+			content: `common:
+  inputs: &inputs
+    composition: composition.yaml
+tests:
+- name: alias
+  inputs: *inputs
+- name: merge
+  inputs:
+    <<: *inputs
+    xr: xr.yaml
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var root yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(tt.content), &root))
+
+			assert.Equal(t, tt.expected, validateSchema(&root, schema))
+		})
+	}
+}
+
+func TestLintTestSuiteFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"composition.yaml", "functions.yaml", "xr.yaml"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte("kind: X\n"), 0o600))
+	}
+
+	options := &testexecutionUtils.Options{Repositories: map[string]string{"myrepo": dir}}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []Finding
+	}{
+		{
+			name: "valid testsuite",
+			content: `common:
+  inputs:
+    composition: composition.yaml
+    functions: "{{ .Repositories.myrepo }}/functions.yaml"
+tests:
+- name: base
+  id: base
+  inputs:
+    xr: xr.yaml
+- name: derived
+  extends: base
+  hooks:
+    pre-test:
+    - run: cat {{ .Tests.base.Outputs.XR }}
+`,
+		},
+		{
+			name: "invalid testsuite",
+			content: `timeout: 5x
+common:
+  inputs:
+    composition: composition.yaml
+tests:
+- name: base
+  id: base
+  inputs:
+    xr: missing.yaml
+    functions: functions.yaml
+  assertions:
+    xprin:
+    - name: exists
+      type: Exists
+      resource: Cluster
+- name: duplicate
+  id: base
+  needs: [other]
+  inputs:
+    xr: xr.yaml
+    functions: functions.yaml
+  hooks:
+    post-test:
+    - run: echo {{ .Vars.missing }}
+`,
+			expected: []Finding{
+				{Line: 1, Message: "testsuite has invalid timeout '5x': time: unknown unit \"x\" in duration \"5x\""},
+				{Line: 9, Message: "XR file not found: stat " + filepath.Join(dir, "missing.yaml") + ": no such file or directory"},
+				{Line: 13, Message: "assertion 'exists': exists assertion value must be in format 'Kind/name', got 'Cluster'"},
+				{Line: 17, Message: "duplicate test case ID 'base' found"},
+				{Line: 18, Message: "test case 'duplicate' needs unknown test case ID 'other'"},
+				{Line: 24, Message: ".Vars.missing references unknown var 'missing'"},
+			},
+		},
+		{
+			name: "newer version",
+			content: `# A testsuite file of a newer xprin
+apiVersion: xprin.crossplane.io/v1beta1
+kind: TestSuite
+tests:
+- name: base
+  new-field: true
+`,
+			expected: []Finding{
+				{Line: 2, Message: "apiVersion 'xprin.crossplane.io/v1beta1' is not supported by this version of xprin (supported: xprin.crossplane.io/v1alpha1), upgrade xprin to read this file"},
+			},
+		},
+		{
+			name: "wrong kind",
+			content: `apiVersion: xprin.crossplane.io/v1alpha1
+kind: Composition
+tests:
+- name: base
+`,
+			expected: []Finding{
+				{Line: 2, Message: "unsupported kind 'Composition' for apiVersion xprin.crossplane.io/v1alpha1 (must be TestSuite)"},
+			},
+		},
+		{
+			name: "missing mandatory fields",
+			content: `tests:
+- name: incomplete
+  inputs:
+    xr: xr.yaml
+    composition: composition.yaml
+`,
+			expected: []Finding{
+				{Line: 2, Message: "test case 'incomplete': missing mandatory field: functions (it can be specified either in the test case or in the common inputs)"},
+			},
+		},
+		{
+			name: "unquoted template",
+			content: `vars:
+  xr: xr.yaml
+tests:
+- name: unquoted
+  inputs:
+    xr: {{ .Vars.xr }}
+    composition: composition.yaml
+    functions: functions.yaml
+`,
+			expected: []Finding{
+				{Line: 6, Message: "template at the start of a value is not quoted, which is deprecated and will be an error in a future release: quote the value"},
+			},
+		},
+		{
+			name: "invalid YAML",
+			content: `tests:
+- name: invalid
+   inputs: [
+`,
+			expected: []Finding{
+				{Line: 3, Message: "yaml: line 3: mapping values are not allowed in this context"},
+			},
+		},
+		{
+			name:     "no test cases",
+			content:  "tests: []\n",
+			expected: []Finding{{Line: 1, Message: "no test cases found"}},
+		},
+		{
+			name: "missing include",
+			content: `include:
+- missing.yaml
+tests:
+- name: included
+  inputs:
+    xr: xr.yaml
+    composition: composition.yaml
+    functions: functions.yaml
+`,
+			expected: []Finding{
+				{Line: 2, Message: "failed to read included file missing.yaml: open " + filepath.Join(dir, "missing.yaml") + ": no such file or directory"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(dir, "lint_xprin.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(tt.content), 0o600))
+
+			findings := LintTestSuiteFile(afero.NewOsFs(), file, options)
+
+			var expected []Finding
+			for _, finding := range tt.expected {
+				finding.File = file
+				expected = append(expected, finding)
+			}
+
+			assert.Equal(t, expected, findings)
+		})
+	}
+}
+
+func TestLintTargets(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad_xprin.yaml"), []byte("tests: []\n"), 0o600))
+
+	err := LintTargets(afero.NewOsFs(), []string{dir}, &testexecutionUtils.Options{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, testexecutionUtils.ErrLintFailed))
+	assert.Contains(t, err.Error(), "found 1 problem in 1 testsuite file")
+
+	require.NoError(t, LintTargets(afero.NewOsFs(), []string{filepath.Join(dir, "missing")}, &testexecutionUtils.Options{}))
+}
+
+func TestFinding_String(t *testing.T) {
+	assert.Equal(t, "aws_xprin.yaml:3: invalid", Finding{File: "aws_xprin.yaml", Line: 3, Message: "invalid"}.String())
+	assert.Equal(t, "aws_xprin.yaml: invalid", Finding{File: "aws_xprin.yaml", Message: "invalid"}.String())
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+)
+
+// Listing formats.
+const (
+	ListFormatText = "text"
+	ListFormatJSON = "json"
+)
+
+// TestSuiteListing is a testsuite file with the test cases that xprin test would run.
+type TestSuiteListing struct {
+	File      string            `json:"file"`
+	Error     string            `json:"error,omitempty"` // Why the testsuite file cannot be run
+	TestCases []TestCaseListing `json:"test-cases"`
+}
+
+// TestCaseListing is a test case of a testsuite file, with common and the test case it extends merged.
+type TestCaseListing struct {
+	Name       string          `json:"name"`
+	ID         string          `json:"id,omitempty"`
+	Input      string          `json:"input"` // xr or claim
+	Assertions AssertionCounts `json:"assertions"`
+	Extends    string          `json:"extends,omitempty"`
+	Needs      []string        `json:"needs,omitempty"` // IDs of the test cases it depends on, in needs or referenced as .Tests.<id>
+	Skip       string          `json:"skip,omitempty"`
+	XFail      string          `json:"xfail,omitempty"`
+}
+
+// AssertionCounts are the number of assertions of a test case by engine.
+type AssertionCounts struct {
+	Xprin int `json:"xprin"`
+	Diff  int `json:"diff"`
+	Dyff  int `json:"dyff"`
+}
+
+// String returns the assertion counts as e.g. "xprin: 2, diff: 1", or "none".
+func (c AssertionCounts) String() string {
+	var counts []string
+
+	for _, count := range []struct {
+		engine string
+		n      int
+	}{{"xprin", c.Xprin}, {"diff", c.Diff}, {"dyff", c.Dyff}} {
+		if count.n > 0 {
+			counts = append(counts, fmt.Sprintf("%s: %d", count.engine, count.n))
+		}
+	}
+
+	if len(counts) == 0 {
+		return "none"
+	}
+
+	return strings.Join(counts, ", ")
+}
+
+// ListTargets returns the testsuite files of the targets, found in the same way as ProcessTargets finds them, with
+// their test cases. Testsuite files without test cases are skipped, and those that cannot be loaded are listed with
+// their error.
+func ListTargets(fs afero.Fs, targets []string, debug bool) ([]TestSuiteListing, error) {
+	files, err := FindTestSuiteFiles(fs, targets, debug)
+	if err != nil {
+		return nil, err
+	}
+
+	listings := []TestSuiteListing{}
+
+	for _, file := range files {
+		listing := TestSuiteListing{File: file, TestCases: []TestCaseListing{}}
+
+		testSuiteSpec, _, err := loadTestSuite(fs, file)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "no test cases found") {
+				continue
+			}
+
+			listing.Error = err.Error()
+			listings = append(listings, listing)
+
+			continue
+		}
+
+		dependencies := runner.NewRunner(&testexecutionUtils.Options{Debug: debug}, file, testSuiteSpec).TestCaseDependencies()
+
+		for i, testCase := range testSuiteSpec.Tests {
+			if testSuiteSpec.HasCommon() {
+				testCase.MergeCommon(testSuiteSpec.Common)
+			}
+
+			listing.TestCases = append(listing.TestCases, newTestCaseListing(testCase, dependencies[i]))
+		}
+
+		listings = append(listings, listing)
+	}
+
+	return listings, nil
+}
+
+// newTestCaseListing returns the listing of a test case with the IDs of the test cases it depends on.
+func newTestCaseListing(testCase api.TestCase, needs []string) TestCaseListing {
+	listing := TestCaseListing{
+		Name:    testCase.Name,
+		ID:      testCase.ID,
+		Extends: testCase.Extends,
+		Needs:   needs,
+		Skip:    testCase.Skip,
+		XFail:   testCase.XFail,
+		Assertions: AssertionCounts{
+			Xprin: len(testCase.Assertions.Xprin),
+			Diff:  len(testCase.Assertions.Diff),
+			Dyff:  len(testCase.Assertions.Dyff),
+		},
+	}
+
+	switch {
+	case testCase.HasXRFromXRD():
+		listing.Input = "xr-from-xrd"
+	case testCase.HasXR():
+		listing.Input = "xr"
+	case testCase.HasClaim():
+		listing.Input = "claim"
+	}
+
+	return listing
+}
+
+// PrintListings prints testsuite listings in a format: text, a table of the test cases of each testsuite file,
+// or json.
+func PrintListings(w io.Writer, listings []TestSuiteListing, format string) error {
+	if format == ListFormatJSON {
+		data, err := json.MarshalIndent(listings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal listing: %w", err)
+		}
+
+		_, err = fmt.Fprintf(w, "%s\n", data)
+
+		return err
+	}
+
+	for _, listing := range listings {
+		if listing.Error != "" {
+			fmt.Fprintf(w, "%s\n    error: %s\n", listing.File, strings.ReplaceAll(listing.Error, "\n", "\n    ")) //nolint:errcheck // output function, error handling not practical
+			continue
+		}
+
+		fmt.Fprintf(w, "%s\n", listing.File) //nolint:errcheck // output function, error handling not practical
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "    NAME\tID\tINPUT\tASSERTIONS\tNEEDS") //nolint:errcheck // output function, error handling not practical
+
+		for _, testCase := range listing.TestCases {
+			//nolint:errcheck // output function, error handling not practical
+			fmt.Fprintf(tw, "    %s\t%s\t%s\t%s\t%s\n",
+				testCase.Name, orDash(testCase.ID), orDash(testCase.Input), testCase.Assertions, orDash(strings.Join(testCase.Needs, ", ")))
+		}
+
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// orDash returns s, or "-" if s is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
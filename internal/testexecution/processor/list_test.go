@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestListTargets(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	files := map[string]string{
+		"/tests/aws_xprin.yaml": `common:
+  inputs:
+    xr: xr.yaml
+  assertions:
+    xprin:
+    - name: count
+      type: Count
+      value: 1
+tests:
+- name: base
+  id: base
+- name: derived
+  extends: base
+  needs: [base]
+  skip: not now
+  inputs:
+    xr: other-xr.yaml
+  assertions:
+    diff:
+    - name: golden
+      expected: golden.yaml
+- name: report
+  hooks:
+    post-test:
+    - run: "cat {{ .Tests.base.Outputs.XR }}"
+`,
+		"/tests/empty_xprin.yaml":   "tests: []\n",
+		"/tests/invalid_xprin.yaml": "tests:\n- name: a\n  timeout: 5x\n",
+	}
+
+	for file, content := range files {
+		require.NoError(t, afero.WriteFile(fs, file, []byte(content), 0o644))
+	}
+
+	listings, err := ListTargets(fs, []string{"/tests"}, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []TestSuiteListing{
+		{
+			File: "/tests/aws_xprin.yaml",
+			TestCases: []TestCaseListing{
+				{Name: "base", ID: "base", Input: "xr", Assertions: AssertionCounts{Xprin: 1}},
+				{
+					Name:       "derived",
+					Input:      "xr",
+					Assertions: AssertionCounts{Xprin: 1, Diff: 1},
+					Extends:    "base",
+					Needs:      []string{"base"},
+					Skip:       "not now",
+				},
+				{Name: "report", Input: "xr", Assertions: AssertionCounts{Xprin: 1}, Needs: []string{"base"}},
+			},
+		},
+		{
+			File:      "/tests/invalid_xprin.yaml",
+			Error:     "invalid testsuite file:\n- test case 'a' has invalid timeout '5x': time: unknown unit \"x\" in duration \"5x\"",
+			TestCases: []TestCaseListing{},
+		},
+	}, listings)
+}
+
+func TestPrintListings(t *testing.T) {
+	listings := []TestSuiteListing{
+		{
+			File: "tests/aws_xprin.yaml",
+			TestCases: []TestCaseListing{
+				{Name: "base", ID: "base", Input: "xr", Assertions: AssertionCounts{Xprin: 2, Dyff: 1}},
+				{Name: "derived test", Input: "claim", Needs: []string{"base", "other"}},
+			},
+		},
+		{File: "tests/invalid_xprin.yaml", Error: "invalid testsuite file:\n- bad", TestCases: []TestCaseListing{}},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, PrintListings(&buf, listings, ListFormatText))
+
+		assert.Equal(t, `tests/aws_xprin.yaml
+    NAME          ID    INPUT  ASSERTIONS         NEEDS
+    base          base  xr     xprin: 2, dyff: 1  -
+    derived test  -     claim  none               base, other
+tests/invalid_xprin.yaml
+    error: invalid testsuite file:
+    - bad
+`, buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, PrintListings(&buf, listings, ListFormatJSON))
+
+		var got []TestSuiteListing
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		assert.Equal(t, listings, got)
+		assert.Contains(t, buf.String(), `"test-cases": [`)
+	})
+}
@@ -17,36 +17,128 @@ limitations under the License.
 package processor
 
 import (
+	"errors"
 	"fmt"
-	"strings"
+	"path/filepath"
+	"sync"
 
 	"github.com/crossplane-contrib/xprin/internal/api"
-	"github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
 	"github.com/spf13/afero"
 	"sigs.k8s.io/yaml"
 )
 
-// load loads and validates a single testsuite file.
+// unversionedMessage is the deprecation warning about testsuite files without the apiVersion and kind header.
+const unversionedMessage = "no apiVersion and kind, which will be required in a future version of xprin: add 'apiVersion: " +
+	api.APIVersion + "' and 'kind: " + api.Kind + "'"
+
+// warnedFiles records the warnings printed about testsuite files and fragments, so that each one is printed once per
+// run even if a file is loaded several times, e.g. to select the files affected by changes and then to run them.
+//
+//nolint:gochecknoglobals // The warnings printed by the process
+var warnedFiles sync.Map
+
+// warnOnce prints a warning about a testsuite file or fragment, unless it was already printed.
+func warnOnce(path, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if _, warned := warnedFiles.LoadOrStore(path+"\x00"+message, true); !warned {
+		utils.WarningPrintf("%s", message)
+	}
+}
+
+// load loads and validates a single testsuite file, resolving its includes.
 func load(fs afero.Fs, path string) (*api.TestSuiteSpec, error) {
+	testSuiteSpec, _, err := loadWithIncludes(fs, path)
+	return testSuiteSpec, err
+}
+
+// loadWithIncludes loads a testsuite file like load, and also returns the absolute paths of the fragment files it includes.
+func loadWithIncludes(fs afero.Fs, path string) (*api.TestSuiteSpec, []string, error) {
 	data, err := afero.ReadFile(fs, path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read testsuite file %s: %w", path, err)
+		return nil, nil, fmt.Errorf("failed to read testsuite file %s: %w", path, err)
 	}
 
-	content := string(data)
-
-	if strings.Contains(content, "{{") {
-		content = utils.ReplaceTemplateVarsWithPlaceholders(content)
+	var testSuiteSpec api.TestSuiteSpec
+	if err := parse(path, data, &testSuiteSpec); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse testsuite file %s: %w", path, err)
 	}
 
-	var testSuiteSpec api.TestSuiteSpec
-	if err := yaml.Unmarshal([]byte(content), &testSuiteSpec); err != nil {
-		return nil, fmt.Errorf("failed to parse testsuite file %s: %w", path, err)
+	// Fragments can have the header too, but only testsuite files will require it
+	if meta, err := api.ParseTypeMeta(data); err == nil && !meta.IsVersioned() {
+		warnOnce(path, "%s: %s\n", filepath.Base(path), unversionedMessage)
 	}
 
 	if len(testSuiteSpec.Tests) == 0 {
-		return nil, fmt.Errorf("no test cases found in testsuite file %s", path)
+		return nil, nil, fmt.Errorf("no test cases found in testsuite file %s", path)
+	}
+
+	included, err := resolveIncludes(fs, path, &testSuiteSpec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve includes of testsuite file %s: %w", path, err)
+	}
+
+	return &testSuiteSpec, included, nil
+}
+
+// loadTestSuite loads and validates a single testsuite file, and resolves its extended test cases, so that it can be
+// given to a runner. It also returns the absolute paths of the fragment files it includes.
+func loadTestSuite(fs afero.Fs, path string) (*api.TestSuiteSpec, []string, error) {
+	testSuiteSpec, included, err := loadWithIncludes(fs, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := testSuiteSpec.CheckValidTestSuiteFile(); err != nil {
+		return nil, nil, err
+	}
+
+	// Inherit from extended test cases before common is merged into each test case by the runner
+	testSuiteSpec.ResolveExtends()
+
+	return testSuiteSpec, included, nil
+}
+
+// NewTestSuiteRunner loads and validates a single testsuite file, and returns a runner of its test cases with their
+// extended test cases resolved.
+func NewTestSuiteRunner(fs afero.Fs, path string, options *testexecutionUtils.Options) (*runner.Runner, error) {
+	testSuiteSpec, _, err := loadTestSuite(fs, path)
+
+	var testSuiteErrors api.TestSuiteErrors
+	if errors.As(err, &testSuiteErrors) {
+		return nil, fmt.Errorf("invalid testsuite file %s: %w", path, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return runner.NewRunner(options, path, testSuiteSpec), nil
+}
+
+// parse parses a testsuite file or fragment, after checking that it is of a version of the format that xprin reads
+// (see api.TypeMeta) and that its templates are valid. It warns about the deprecated unquoted templates.
+func parse(path string, data []byte, spec *api.TestSuiteSpec) error {
+	doc, err := parseTestSuiteDocument(data)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range doc.unquoted {
+		warnOnce(path, "%s:%d: %s\n", filepath.Base(path), line, unquotedTemplateMessage)
+	}
+
+	if meta, err := api.ParseTypeMeta(doc.data); err == nil {
+		if err := meta.Check(); err != nil {
+			return err
+		}
+	}
+
+	if err := checkTemplates(path, &doc.root); err != nil {
+		return err
 	}
 
-	return &testSuiteSpec, nil
+	return yaml.Unmarshal(doc.data, spec)
 }
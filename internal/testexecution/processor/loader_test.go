@@ -19,7 +19,7 @@ package processor
 import (
 	"testing"
 
-	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/api"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
 	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
@@ -153,16 +153,56 @@ tests:
 		assert.Contains(t, err.Error(), "failed to parse testsuite file")
 	})
 
+	t.Run("apiVersion and kind", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			header  string
+			wantErr string
+		}{
+			{name: "current version", header: "apiVersion: xprin.crossplane.io/v1alpha1\nkind: TestSuite\n"},
+			{name: "no header", header: ""},
+			{name: "newer version", header: "apiVersion: xprin.crossplane.io/v1\nkind: TestSuite\n", wantErr: "apiVersion 'xprin.crossplane.io/v1' is not supported by this version of xprin"},
+			{name: "other group", header: "apiVersion: example.org/v1\nkind: TestSuite\n", wantErr: "unsupported apiVersion 'example.org/v1'"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				testFile := "/versioned_xprin.yaml"
+				require.NoError(t, afero.WriteFile(fs, testFile, []byte(tt.header+"tests:\n- name: test1\n  future-field: {}\n"), 0o644))
+
+				spec, err := load(fs, testFile)
+				if tt.wantErr == "" {
+					require.NoError(t, err)
+					assert.Len(t, spec.Tests, 1)
+
+					return
+				}
+
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			})
+		}
+
+		t.Run("fragment of a newer version", func(t *testing.T) {
+			require.NoError(t, afero.WriteFile(fs, "/versioned/fragment.yaml", []byte("apiVersion: xprin.crossplane.io/v2\nkind: TestSuite\n"), 0o644))
+			require.NoError(t, afero.WriteFile(fs, "/versioned/suite_xprin.yaml", []byte("include:\n- fragment.yaml\ntests:\n- name: test1\n"), 0o644))
+
+			_, err := load(fs, "/versioned/suite_xprin.yaml")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "failed to parse included file fragment.yaml: apiVersion 'xprin.crossplane.io/v2' is not supported")
+		})
+	})
+
 	// Test template variable handling
 	t.Run("template variable handling", func(t *testing.T) {
 		t.Run("with template variables", func(t *testing.T) {
 			contentWithTemplateVars := `
 common:
   inputs:
-    functions: {{ .Repositories.myrepo }}/functions
+    functions: "{{ .Repositories.myrepo }}/functions"
     crds:
-    - {{ .Repositories.otherrepo }}/crds1
-    - {{ .Repositories.otherrepo }}/crds2
+    - "{{ .Repositories.otherrepo }}/crds1"
+    - "{{ .Repositories.otherrepo }}/crds2"
   hooks:
     pre-test:
     - name: "pre-test hook"
@@ -184,22 +224,22 @@ tests:
 			config, err := load(fs, testFile)
 			require.NoError(t, err)
 
-			// Check that template variables are converted to placeholders during load
-			assert.Contains(t, config.Common.Inputs.Functions, testexecutionUtils.CreatePlaceholder(".Repositories.myrepo"))
-			assert.Contains(t, config.Common.Inputs.CRDs[0], testexecutionUtils.CreatePlaceholder(".Repositories.otherrepo"))
-			assert.Contains(t, config.Common.Inputs.CRDs[1], testexecutionUtils.CreatePlaceholder(".Repositories.otherrepo"))
-			assert.Contains(t, config.Common.Hooks.PreTest[0].Run, testexecutionUtils.CreatePlaceholder(".Inputs.XR"))
-			assert.Contains(t, config.Common.Hooks.PostTest[0].Run, testexecutionUtils.CreatePlaceholder(".Outputs.XR"))
+			// Check that template variables are kept as is during load
+			assert.Contains(t, config.Common.Inputs.Functions, "{{ .Repositories.myrepo }}")
+			assert.Contains(t, config.Common.Inputs.CRDs[0], "{{ .Repositories.otherrepo }}")
+			assert.Contains(t, config.Common.Inputs.CRDs[1], "{{ .Repositories.otherrepo }}")
+			assert.Contains(t, config.Common.Hooks.PreTest[0].Run, "{{ .Inputs.XR }}")
+			assert.Contains(t, config.Common.Hooks.PostTest[0].Run, "{{ .Outputs.XR }}")
 		})
 
 		t.Run("mixed content", func(t *testing.T) {
 			contentMixed := `
 common:
   inputs:
-    functions: {{ .Repositories.myrepo }}/functions
+    functions: "{{ .Repositories.myrepo }}/functions"
     crds:
     - ./static-crd
-    - {{ .Repositories.otherrepo }}/dynamic-crd
+    - "{{ .Repositories.otherrepo }}/dynamic-crd"
   hooks:
     pre-test:
     - name: "mixed hook"
@@ -218,10 +258,426 @@ tests:
 			require.NoError(t, err)
 
 			// Check mixed content
-			assert.Contains(t, config.Common.Inputs.Functions, testexecutionUtils.CreatePlaceholder(".Repositories.myrepo"))
+			assert.Contains(t, config.Common.Inputs.Functions, "{{ .Repositories.myrepo }}")
 			assert.Equal(t, "./static-crd", config.Common.Inputs.CRDs[0])
-			assert.Contains(t, config.Common.Inputs.CRDs[1], testexecutionUtils.CreatePlaceholder(".Repositories.otherrepo"))
-			assert.Contains(t, config.Common.Hooks.PreTest[0].Run, testexecutionUtils.CreatePlaceholder(".Inputs.XR"))
+			assert.Contains(t, config.Common.Inputs.CRDs[1], "{{ .Repositories.otherrepo }}")
+			assert.Contains(t, config.Common.Hooks.PreTest[0].Run, "{{ .Inputs.XR }}")
+		})
+
+		t.Run("unquoted templates at the start of a value are deprecated", func(t *testing.T) {
+			content := `
+tests:
+- name: test1
+  inputs:
+    xr: {{ .Vars.dir }}/xr.yaml
+    composition: {{ .Vars.composition }}
+    functions: "{{ .Vars.functions }}"
+  hooks:
+    pre-test:
+    - run: |
+        cat <<EOF
+        xr: {{ .Inputs.XR }}
+        EOF
+`
+
+			testFile := "/unquoted_xprin.yaml"
+			require.NoError(t, afero.WriteFile(fs, testFile, []byte(content), 0o644))
+
+			config, err := load(fs, testFile)
+			require.NoError(t, err)
+
+			assert.Equal(t, "{{ .Vars.dir }}/xr.yaml", config.Tests[0].Inputs.XR)
+			assert.Equal(t, "{{ .Vars.composition }}", config.Tests[0].Inputs.Composition)
+			assert.Equal(t, "{{ .Vars.functions }}", config.Tests[0].Inputs.Functions)
+			assert.Equal(t, "cat <<EOF\nxr: {{ .Inputs.XR }}\nEOF\n", config.Tests[0].Hooks.PreTest[0].Run)
+
+			doc, err := parseTestSuiteDocument([]byte(content))
+			require.NoError(t, err)
+			assert.Equal(t, []int{5, 6}, doc.unquoted)
 		})
+
+		t.Run("escaped literal braces", func(t *testing.T) {
+			content := `
+tests:
+- name: test1
+  inputs:
+    xr: xr1.yaml
+    composition: comp1.yaml
+  hooks:
+    pre-test:
+    - run: echo \{{ plain }}
+    - run: 'echo \{{ single-quoted }}'
+    - run: "echo \\{{ double-quoted }}"
+`
+
+			testFile := "/escaped_xprin.yaml"
+			require.NoError(t, afero.WriteFile(fs, testFile, []byte(content), 0o644))
+
+			config, err := load(fs, testFile)
+			require.NoError(t, err)
+
+			hooks := config.Tests[0].Hooks.PreTest
+			assert.Equal(t, `echo \{{ plain }}`, hooks[0].Run)
+			assert.Equal(t, `echo \{{ single-quoted }}`, hooks[1].Run)
+			assert.Equal(t, `echo \{{ double-quoted }}`, hooks[2].Run)
+		})
+
+		errorTests := []struct {
+			name    string
+			content string
+			wantErr string
+		}{
+			{
+				name: "unquoted template with an invalid action",
+				content: `
+tests:
+- name: test1
+  inputs:
+    xr: {{ .Vars.dir }/xr.yaml
+    composition: comp1.yaml
+`,
+				wantErr: "yaml:",
+			},
+			{
+				name: "escaped literal braces with a single backslash in a double-quoted value",
+				content: `
+tests:
+- name: test1
+  inputs:
+    xr: "\{{ .Vars.dir }}/xr.yaml"
+    composition: comp1.yaml
+`,
+				wantErr: "found unknown escape character",
+			},
+			{
+				name: "invalid template syntax",
+				content: `
+tests:
+- name: test1
+  inputs:
+    xr: "{{ .Vars.dir }/xr.yaml"
+    composition: comp1.yaml
+`,
+				wantErr: "errors_xprin.yaml:5:",
+			},
+			{
+				name: "outputs in a pre-test hook",
+				content: `
+tests:
+- name: test1
+  inputs:
+    xr: xr1.yaml
+    composition: comp1.yaml
+  hooks:
+    pre-test:
+    - run: "cat {{ .Outputs.XR }}"
+`,
+				wantErr: "errors_xprin.yaml:9: .Outputs is not available in pre-render templates",
+			},
+			{
+				name: "outputs in a test case field",
+				content: `
+tests:
+- name: test1
+  inputs:
+    xr: "{{ .Outputs.XR }}"
+    composition: comp1.yaml
+`,
+				wantErr: "errors_xprin.yaml:5: .Outputs is not available in pre-render templates",
+			},
+			{
+				name: "vars in testsuite vars",
+				content: `
+vars:
+  a: one
+  b: "{{ .Vars.a }}"
+tests:
+- name: test1
+  inputs:
+    xr: xr1.yaml
+    composition: comp1.yaml
+`,
+				wantErr: "errors_xprin.yaml:4: .Vars is not available in load templates",
+			},
+		}
+
+		for _, tt := range errorTests {
+			t.Run(tt.name, func(t *testing.T) {
+				testFile := "/errors_xprin.yaml"
+				require.NoError(t, afero.WriteFile(fs, testFile, []byte(tt.content), 0o644))
+
+				_, err := load(fs, testFile)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			})
+		}
+
+		t.Run("outputs in a post-test hook", func(t *testing.T) {
+			content := `
+tests:
+- name: test1
+  inputs:
+    xr: xr1.yaml
+    composition: comp1.yaml
+  hooks:
+    post-test:
+    - run: |
+        cat {{ .Outputs.XR }}
+        echo '\{{ literal }}'
+`
+
+			testFile := "/post_test_xprin.yaml"
+			require.NoError(t, afero.WriteFile(fs, testFile, []byte(content), 0o644))
+
+			_, err := load(fs, testFile)
+			require.NoError(t, err)
+		})
+	})
+}
+
+func TestLoadWithIncludes(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	write := func(path, content string) {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0o644))
+	}
+
+	write("/shared/base.yaml", `
+include:
+- ../crds/crds.yaml
+common:
+  inputs:
+    composition: composition.yaml
+    functions: /abs/functions
+    crds:
+    - base-crd.yaml
+  hooks:
+    sets:
+    - setup
+hook-sets:
+  setup:
+    pre-test:
+    - name: "base setup"
+      run: "echo setup"
+assertion-sets:
+  standard:
+    xprin:
+    - name: "count"
+      type: Count
+      value: 3
+    diff:
+    - name: "golden"
+      expected: golden/full.yaml
+`)
+	write("/crds/crds.yaml", `
+common:
+  inputs:
+    crds:
+    - crd.yaml
+assertion-sets:
+  crds-exist:
+    xprin:
+    - name: "crd exists"
+      type: Exists
+      resource: CustomResourceDefinition/foo
+`)
+
+	t.Run("fragments are merged, rebased and their sets namespaced", func(t *testing.T) {
+		write("/tests/suite_xprin.yaml", `
+include:
+- ../shared/base.yaml
+- path: ../shared/base.yaml
+  namespace: other
+common:
+  inputs:
+    functions: functions
+tests:
+- name: test1
+  inputs:
+    xr: xr.yaml
+  assertions:
+    sets:
+    - base.standard
+    - base.crds.crds-exist
+    xprin:
+    - name: "own"
+      type: NotExists
+      resource: Bucket/foo
+`)
+
+		config, err := load(fs, "/tests/suite_xprin.yaml")
+		require.NoError(t, err)
+
+		assert.Equal(t, "../shared/composition.yaml", config.Common.Inputs.Composition)
+		assert.Equal(t, "functions", config.Common.Inputs.Functions)
+		assert.Equal(t, []string{"../shared/base-crd.yaml"}, config.Common.Inputs.CRDs)
+		assert.Equal(t, []api.Hook{{Name: "base setup", Run: "echo setup"}}, config.Common.Hooks.PreTest)
+
+		assertions := config.Tests[0].Assertions
+		assert.Empty(t, assertions.Sets)
+		assert.Equal(t, []string{"count", "crd exists", "own"}, []string{assertions.Xprin[0].Name, assertions.Xprin[1].Name, assertions.Xprin[2].Name})
+		assert.Equal(t, "../shared/golden/full.yaml", assertions.Diff[0].Expected)
 	})
+
+	t.Run("merge strategies of fragments are kept", func(t *testing.T) {
+		write("/strategy/fragment.yaml", `
+common:
+  inputs:
+    crds:
+    - fragment-crd.yaml
+    merge-strategy: append
+  hooks:
+    pre-test:
+    - run: "echo fragment"
+    merge-strategy: append
+  assertions:
+    xprin:
+    - name: "fragment count"
+      type: Count
+      value: 1
+    merge-strategy: append
+`)
+		write("/strategy/suite_xprin.yaml", `
+include:
+- fragment.yaml
+common:
+  inputs:
+    composition: composition.yaml
+tests:
+- name: test1
+  inputs:
+    crds:
+    - test-crd.yaml
+  hooks:
+    pre-test:
+    - run: "echo test"
+  assertions:
+    xprin:
+    - name: "test exists"
+      type: Exists
+      resource: Bucket/foo
+`)
+
+		config, err := load(fs, "/strategy/suite_xprin.yaml")
+		require.NoError(t, err)
+
+		assert.Equal(t, api.MergeStrategyAppend, config.Common.Inputs.MergeStrategy)
+		assert.Equal(t, api.MergeStrategyAppend, config.Common.Hooks.MergeStrategy)
+		assert.Equal(t, api.MergeStrategyAppend, config.Common.Assertions.MergeStrategy)
+
+		testCase := config.Tests[0]
+		testCase.MergeCommon(config.Common)
+
+		assert.Equal(t, []string{"fragment-crd.yaml", "test-crd.yaml"}, testCase.Inputs.CRDs)
+		assert.Equal(t, []api.Hook{{Run: "echo fragment"}, {Run: "echo test"}}, testCase.Hooks.PreTest)
+		require.Len(t, testCase.Assertions.Xprin, 2)
+		assert.Equal(t, "fragment count", testCase.Assertions.Xprin[0].Name)
+		assert.Equal(t, "test exists", testCase.Assertions.Xprin[1].Name)
+	})
+
+	t.Run("merge strategy of the including file takes precedence", func(t *testing.T) {
+		write("/precedence/fragment.yaml", "common:\n  inputs:\n    crds:\n    - fragment-crd.yaml\n    merge-strategy: append\n")
+		write("/precedence/suite_xprin.yaml", "include:\n- fragment.yaml\ncommon:\n  inputs:\n    merge-strategy: replace\ntests:\n- name: test1\n  inputs:\n    crds:\n    - test-crd.yaml\n")
+
+		config, err := load(fs, "/precedence/suite_xprin.yaml")
+		require.NoError(t, err)
+
+		testCase := config.Tests[0]
+		testCase.MergeCommon(config.Common)
+
+		assert.Equal(t, []string{"test-crd.yaml"}, testCase.Inputs.CRDs)
+	})
+
+	t.Run("vars of fragments are merged", func(t *testing.T) {
+		write("/vars/first.yaml", "vars:\n  region: us-east-1\n  size: small\n  tier: dev\n")
+		write("/vars/second.yaml", "vars:\n  size: large\n")
+		write("/vars/suite_xprin.yaml", "include:\n- first.yaml\n- second.yaml\nvars:\n  region: eu-west-1\ntests:\n- name: test1\n")
+
+		config, err := load(fs, "/vars/suite_xprin.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"region": "eu-west-1", "size": "large", "tier": "dev"}, config.Vars)
+	})
+
+	t.Run("include cycle", func(t *testing.T) {
+		write("/cycle/a.yaml", "include:\n- b.yaml\n")
+		write("/cycle/b.yaml", "include:\n- a.yaml\n")
+		write("/cycle/suite_xprin.yaml", "include:\n- a.yaml\ntests:\n- name: test1\n")
+
+		_, err := load(fs, "/cycle/suite_xprin.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "include cycle detected: /cycle/a.yaml -> /cycle/b.yaml -> /cycle/a.yaml")
+	})
+
+	t.Run("self include", func(t *testing.T) {
+		write("/self/suite_xprin.yaml", "include:\n- suite_xprin.yaml\ntests:\n- name: test1\n")
+
+		_, err := load(fs, "/self/suite_xprin.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "include cycle detected: /self/suite_xprin.yaml -> /self/suite_xprin.yaml")
+	})
+
+	t.Run("unknown assertion set", func(t *testing.T) {
+		write("/unknown/suite_xprin.yaml", "include:\n- ../shared/base.yaml\ntests:\n- name: test1\n  assertions:\n    sets:\n    - base.missing\n")
+
+		_, err := load(fs, "/unknown/suite_xprin.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "test case 'test1': unknown assertion set 'base.missing'")
+	})
+
+	t.Run("duplicate namespace", func(t *testing.T) {
+		write("/dup/suite_xprin.yaml", "include:\n- ../shared/base.yaml\n- ../other/base.yaml\ntests:\n- name: test1\n")
+
+		_, err := load(fs, "/dup/suite_xprin.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "have the same namespace 'base'")
+	})
+
+	t.Run("fragment with tests", func(t *testing.T) {
+		write("/withtests/fragment.yaml", "tests:\n- name: nested\n")
+		write("/withtests/suite_xprin.yaml", "include:\n- fragment.yaml\ntests:\n- name: test1\n")
+
+		_, err := load(fs, "/withtests/suite_xprin.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "included file fragment.yaml must not contain tests")
+	})
+
+	t.Run("missing fragment", func(t *testing.T) {
+		write("/missing/suite_xprin.yaml", "include:\n- nope.yaml\ntests:\n- name: test1\n")
+
+		_, err := load(fs, "/missing/suite_xprin.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read included file nope.yaml")
+	})
+}
+
+func TestLoadTestSuite(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "/tests/shared.yaml", []byte("common:\n  inputs:\n    functions: functions\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/tests/suite_xprin.yaml", []byte(`include:
+- shared.yaml
+tests:
+- name: base
+  id: base
+  inputs:
+    xr: xr.yaml
+- name: derived
+  extends: base
+`), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/tests/invalid_xprin.yaml", []byte("tests:\n- name: a\n  timeout: 5x\n"), 0o644))
+
+	spec, included, err := loadTestSuite(fs, "/tests/suite_xprin.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/tests/shared.yaml"}, included)
+	assert.Equal(t, "xr.yaml", spec.Tests[1].Inputs.XR)
+
+	_, _, err = loadTestSuite(fs, "/tests/invalid_xprin.yaml")
+
+	var testSuiteErrors api.TestSuiteErrors
+	require.ErrorAs(t, err, &testSuiteErrors)
+	assert.Equal(t, []any{"tests", 0, "timeout"}, testSuiteErrors[0].Path)
+
+	_, err = NewTestSuiteRunner(fs, "/tests/invalid_xprin.yaml", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid testsuite file /tests/invalid_xprin.yaml: invalid testsuite file:\n- test case 'a' has invalid timeout")
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package processor
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	iofs "io/fs"
@@ -34,7 +35,7 @@ import (
 
 // runnerInterface allows dependency injection for test runners (for production and testing).
 type runnerInterface interface {
-	RunTests() error
+	RunTests(ctx context.Context) error
 }
 
 // Mockable functions
@@ -46,13 +47,30 @@ var (
 	}
 )
 
-// ProcessTargets processes the targets and runs the tests
+// ProcessTargets processes the targets and runs the tests. When ctx is done, e.g. on Ctrl-C, the running tests are stopped
+// and no further targets are processed.
 //
 //nolint:gocognit // Complex target processing with multiple validation and execution phases
-func ProcessTargets(fs afero.Fs, targets []string, options *testexecutionUtils.Options) error {
-	var hasErrors bool
+func ProcessTargets(ctx context.Context, fs afero.Fs, targets []string, options *testexecutionUtils.Options) error {
+	var hasFailures, hasErrors bool
+
+	// record records whether the tests of a target only failed, or some of them could not be run
+	record := func(err error) {
+		if errors.Is(err, testexecutionUtils.ErrTestsFailed) && !errors.Is(err, testexecutionUtils.ErrTestsErrored) {
+			hasFailures = true
+		} else {
+			hasErrors = true
+		}
+	}
+
+	ctx, cancel := testexecutionUtils.WithTimeout(ctx, options.Timeout, "global")
+	defer cancel()
 
 	for _, path := range targets {
+		if testexecutionUtils.IsCanceled(ctx) {
+			break
+		}
+
 		if strings.HasSuffix(path, "...") {
 			root := strings.TrimSuffix(path, "...")
 			if strings.HasSuffix(root, string(filepath.Separator)) {
@@ -68,13 +86,17 @@ func ProcessTargets(fs afero.Fs, targets []string, options *testexecutionUtils.O
 			}
 
 			for _, dir := range dirs {
+				if testexecutionUtils.IsCanceled(ctx) {
+					break
+				}
+
 				info, err := fs.Stat(dir)
 				if err != nil || !info.IsDir() {
 					continue
 				}
 
-				if err := processDirectory(fs, dir, options); err != nil {
-					hasErrors = true
+				if err := processDirectory(ctx, fs, dir, options); err != nil {
+					record(err)
 				}
 			}
 
@@ -98,8 +120,8 @@ func ProcessTargets(fs afero.Fs, targets []string, options *testexecutionUtils.O
 		}
 
 		if info.IsDir() {
-			if err := processDirectory(fs, path, options); err != nil {
-				hasErrors = true
+			if err := processDirectory(ctx, fs, path, options); err != nil {
+				record(err)
 			}
 
 			continue
@@ -114,14 +136,35 @@ func ProcessTargets(fs afero.Fs, targets []string, options *testexecutionUtils.O
 			continue
 		}
 
-		if err := processTestSuiteFile(fs, path, options); err != nil {
-			hasErrors = true
+		if err := processTestSuiteFile(ctx, fs, path, options); err != nil {
+			record(err)
 		}
 	}
 
+	summary := options.Summary.Complete()
+	summary.Print(utils.Output())
+
+	if err := options.Artifacts.WriteManifest(fs, summary); err != nil {
+		utils.WarningPrintf("failed to write artifacts manifest: %v\n", err)
+	}
+
+	if msg := options.FailureBudget.AbortMessage(); msg != "" {
+		utils.OutputPrintf("%s\n", msg)
+	}
+
+	if testexecutionUtils.IsCanceled(ctx) {
+		utils.OutputPrintf("FAIL\n")
+		return fmt.Errorf("processing canceled: %w", context.Cause(ctx))
+	}
+
 	if hasErrors {
 		utils.OutputPrintf("FAIL\n")
-		return fmt.Errorf("processing completed with errors")
+		return fmt.Errorf("processing completed with errors: %w", testexecutionUtils.ErrTestsErrored)
+	}
+
+	if hasFailures {
+		utils.OutputPrintf("FAIL\n")
+		return fmt.Errorf("processing completed with errors: %w", testexecutionUtils.ErrTestsFailed)
 	}
 
 	return nil
@@ -129,7 +172,7 @@ func ProcessTargets(fs afero.Fs, targets []string, options *testexecutionUtils.O
 
 // processDirectory handles finding testsuite files in a directory, printing the go test-style message if none are found.
 // Optionally runs tests from each found testsuite file after loading and validating the configuration.
-func processDirectory(fs afero.Fs, dir string, options *testexecutionUtils.Options) error {
+func processDirectory(ctx context.Context, fs afero.Fs, dir string, options *testexecutionUtils.Options) error {
 	if options.Debug {
 		utils.DebugPrintf("Processing directory %s\n", dir)
 	}
@@ -152,49 +195,53 @@ func processDirectory(fs afero.Fs, dir string, options *testexecutionUtils.Optio
 		utils.DebugPrintf("Found %s in directory %s\n", plural.Pluralize("testsuite file", len(files), true), dir)
 	}
 
-	var hasErrors bool
+	var errs []error
 
 	for _, testSuiteFile := range files {
-		if err := processTestSuiteFile(fs, testSuiteFile, options); err != nil {
-			hasErrors = true
+		if testexecutionUtils.IsCanceled(ctx) {
+			break
+		}
+
+		if err := processTestSuiteFile(ctx, fs, testSuiteFile, options); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
-	if hasErrors {
-		return fmt.Errorf("errors occurred processing files in directory %s", dir)
+	if len(errs) > 0 {
+		return fmt.Errorf("errors occurred processing files in directory %s: %w", dir, errors.Join(errs...))
 	}
 
 	return nil
 }
 
 // processTestSuiteFile processes a single test file, loading the configuration and running tests if applicable.
-func processTestSuiteFile(fs afero.Fs, testSuiteFile string, options *testexecutionUtils.Options) error {
+func processTestSuiteFile(ctx context.Context, fs afero.Fs, testSuiteFile string, options *testexecutionUtils.Options) error {
 	if options.Debug {
 		utils.DebugPrintf("Processing testsuite file %s\n", testSuiteFile)
 	}
 
 	// Load and validate test configuration
-	testSuiteSpec, err := load(fs, testSuiteFile)
+	testSuiteSpec, _, err := loadTestSuite(fs, testSuiteFile)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), ("no test cases found")) {
 			fmt.Fprintf(os.Stderr, "?   \t%s\t[no test cases found]\n", testSuiteFile)
 			return nil
 		}
 
-		return reportTestSuiteError(testSuiteFile, err, "invalid testsuite file")
-	}
+		options.Summary.AddTestSuiteError(testSuiteFile, err)
 
-	// Now that we know we have tests to run, check for empty names and duplicate IDs
-	if err := testSuiteSpec.CheckValidTestSuiteFile(); err != nil {
 		return reportTestSuiteError(testSuiteFile, err, "invalid testsuite file")
 	}
 
 	testRunner := newRunnerFunc(options, testSuiteFile, testSuiteSpec)
 
-	fileErr := testRunner.RunTests()
+	fileErr := testRunner.RunTests(ctx)
 	if fileErr != nil {
-		errMsg := fileErr.Error()
-		if !strings.Contains(errMsg, "tests failed in testsuite") {
+		// The results of failed, errored and canceled testsuite files were already printed by the runner
+		ranTests := errors.Is(fileErr, testexecutionUtils.ErrTestsFailed) || errors.Is(fileErr, testexecutionUtils.ErrTestsErrored)
+		if !ranTests && !testexecutionUtils.IsCanceled(ctx) {
+			options.Summary.AddTestSuiteError(testSuiteFile, fileErr)
+
 			return reportTestSuiteError(testSuiteFile, fileErr, "testsuite file execution error")
 		}
 
@@ -18,13 +18,16 @@ package processor
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
 	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
 	"github.com/spf13/afero"
@@ -41,12 +44,17 @@ const (
 
 // mockRunner is a mock implementation of runnerInterface for testing.
 type mockRunner struct {
-	runTestsFunc func() error
-	output       io.Writer
-	options      *testexecutionUtils.Options
+	runTestsFunc        func() error
+	runTestsContextFunc func(ctx context.Context) error
+	output              io.Writer
+	options             *testexecutionUtils.Options
 }
 
-func (m *mockRunner) RunTests() error {
+func (m *mockRunner) RunTests(ctx context.Context) error {
+	if m.runTestsContextFunc != nil {
+		return m.runTestsContextFunc(ctx)
+	}
+
 	if m.runTestsFunc != nil {
 		return m.runTestsFunc()
 	}
@@ -66,7 +74,7 @@ func TestProcessTargets(t *testing.T) {
 			return &mockRunner{options: options}
 		}
 
-		err := ProcessTargets(afero.NewMemMapFs(), []string{"non-existent-path"}, &testexecutionUtils.Options{Debug: false})
+		err := ProcessTargets(context.Background(), afero.NewMemMapFs(), []string{"non-existent-path"}, &testexecutionUtils.Options{Debug: false})
 
 		assert.NoError(t, err, "expected no error for non-existent path")
 	})
@@ -82,7 +90,7 @@ func TestProcessTargets(t *testing.T) {
 		var err error
 
 		stderrOutput := unittestsUtils.CaptureStderr(func() {
-			err = ProcessTargets(fs, []string{"/testdir"}, &testexecutionUtils.Options{})
+			err = ProcessTargets(context.Background(), fs, []string{"/testdir"}, &testexecutionUtils.Options{})
 		})
 
 		require.NoError(t, err, "expected no error for directory path")
@@ -101,7 +109,7 @@ func TestProcessTargets(t *testing.T) {
 		var err error
 
 		stderrOutput := unittestsUtils.CaptureStderr(func() {
-			err = ProcessTargets(fs, []string{"/base..."}, &testexecutionUtils.Options{})
+			err = ProcessTargets(context.Background(), fs, []string{"/base..."}, &testexecutionUtils.Options{})
 		})
 
 		// No errors expected
@@ -119,7 +127,7 @@ func TestProcessTargets(t *testing.T) {
 		var err error
 
 		_ = unittestsUtils.CaptureOutput(func() {
-			err = ProcessTargets(afero.NewMemMapFs(), []string{"[]..."}, &testexecutionUtils.Options{})
+			err = ProcessTargets(context.Background(), afero.NewMemMapFs(), []string{"[]..."}, &testexecutionUtils.Options{})
 		})
 
 		// Should have an error
@@ -140,11 +148,11 @@ func TestProcessTargets(t *testing.T) {
 		var err error
 
 		output := unittestsUtils.CaptureOutput(func() {
-			err = ProcessTargets(fs, []string{testFile}, &testexecutionUtils.Options{})
+			err = ProcessTargets(context.Background(), fs, []string{testFile}, &testexecutionUtils.Options{})
 		})
 
 		// Should have an error from loading the invalid YAML
-		require.Error(t, err)
+		require.ErrorIs(t, err, testexecutionUtils.ErrTestsErrored)
 		assert.Contains(t, err.Error(), "processing completed with errors")
 		assert.Contains(t, output.Stderr, "failed to parse testsuite file")
 		assert.Contains(t, output.Stderr, "FAIL")
@@ -171,12 +179,170 @@ func TestProcessTargets(t *testing.T) {
 			return runner
 		}
 
-		err := ProcessTargets(fs, []string{testFile}, &testexecutionUtils.Options{})
+		err := ProcessTargets(context.Background(), fs, []string{testFile}, &testexecutionUtils.Options{})
 
 		// Should be no errors
 		assert.NoError(t, err)
 	})
 
+	t.Run("global timeout", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		testFile := "/test_xprin.yaml"
+		require.NoError(t, afero.WriteFile(fs, testFile, []byte(testContentWithTests), 0o644))
+
+		var hasDeadline bool
+
+		newRunnerFunc = func(options *testexecutionUtils.Options, _ string, _ *api.TestSuiteSpec) runnerInterface {
+			return &mockRunner{
+				options: options,
+				runTestsContextFunc: func(ctx context.Context) error {
+					_, hasDeadline = ctx.Deadline()
+					return nil
+				},
+			}
+		}
+
+		err := ProcessTargets(context.Background(), fs, []string{testFile}, &testexecutionUtils.Options{Timeout: time.Hour})
+		require.NoError(t, err)
+		assert.True(t, hasDeadline, "the tests should run with the global timeout")
+	})
+
+	t.Run("interrupted", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, "/tests/a_xprin.yaml", []byte(testContentWithTests), 0o644))
+		require.NoError(t, afero.WriteFile(fs, "/tests/b_xprin.yaml", []byte(testContentWithTests), 0o644))
+
+		ctx, cancel := context.WithCancelCause(context.Background())
+
+		var ran []string
+
+		newRunnerFunc = func(options *testexecutionUtils.Options, testSuiteFile string, _ *api.TestSuiteSpec) runnerInterface {
+			return &mockRunner{
+				options: options,
+				runTestsContextFunc: func(_ context.Context) error {
+					ran = append(ran, testSuiteFile)
+					cancel(testexecutionUtils.ErrInterrupted)
+
+					return errors.New("testsuite a_xprin.yaml was canceled: interrupted")
+				},
+			}
+		}
+
+		var err error
+
+		_ = unittestsUtils.CaptureStderr(func() {
+			err = ProcessTargets(ctx, fs, []string{"/tests", "/tests/b_xprin.yaml"}, &testexecutionUtils.Options{})
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, "processing canceled: interrupted", err.Error())
+		assert.Equal(t, []string{"/tests/a_xprin.yaml"}, ran)
+	})
+
+	t.Run("aborted early after max failures", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, "/tests/a_xprin.yaml", []byte(testContentWithTests), 0o644))
+		require.NoError(t, afero.WriteFile(fs, "/tests/b_xprin.yaml", []byte(testContentWithTests), 0o644))
+
+		var ran []string
+
+		newRunnerFunc = func(options *testexecutionUtils.Options, testSuiteFile string, _ *api.TestSuiteSpec) runnerInterface {
+			return &mockRunner{
+				options: options,
+				runTestsContextFunc: func(_ context.Context) error {
+					ran = append(ran, testSuiteFile)
+					options.FailureBudget.AddFailure()
+
+					return fmt.Errorf("%w in testsuite", testexecutionUtils.ErrTestsFailed)
+				},
+			}
+		}
+
+		var err error
+
+		stdout := unittestsUtils.CaptureStdout(func() {
+			err = ProcessTargets(context.Background(), fs, []string{"/tests"}, &testexecutionUtils.Options{FailureBudget: testexecutionUtils.NewFailureBudget(1)})
+		})
+
+		require.ErrorIs(t, err, testexecutionUtils.ErrTestsFailed)
+		require.NotErrorIs(t, err, testexecutionUtils.ErrTestsErrored)
+		// The remaining testsuite files still run so that their test cases are reported as skipped
+		assert.Equal(t, []string{"/tests/a_xprin.yaml", "/tests/b_xprin.yaml"}, ran)
+		assert.Contains(t, stdout, "execution aborted early after 2 failed test cases (max failures: 1)\nFAIL\n")
+	})
+
+	t.Run("run summary", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, "/tests/a_xprin.yaml", []byte(testContentWithTests), 0o644))
+		require.NoError(t, afero.WriteFile(fs, "/tests/b_xprin.yaml", []byte("tests: ["), 0o644))
+
+		newRunnerFunc = func(options *testexecutionUtils.Options, testSuiteFile string, _ *api.TestSuiteSpec) runnerInterface {
+			return &mockRunner{
+				options: options,
+				runTestsContextFunc: func(_ context.Context) error {
+					result := engine.NewTestSuiteResult(testSuiteFile, false)
+					result.AddResult(engine.NewTestCaseResult("test1", "", false, false, false, false, false).Complete())
+					options.Summary.AddTestSuite(result.Complete())
+
+					return nil
+				},
+			}
+		}
+
+		var (
+			err    error
+			stdout string
+		)
+
+		_ = unittestsUtils.CaptureStderr(func() {
+			stdout = unittestsUtils.CaptureStdout(func() {
+				err = ProcessTargets(context.Background(), fs, []string{"/tests"}, &testexecutionUtils.Options{Summary: engine.NewRunSummary()})
+			})
+		})
+
+		require.ErrorIs(t, err, testexecutionUtils.ErrTestsErrored)
+		assert.Contains(t, stdout, "Summary: 2 testsuite files, 1 test case in ")
+		assert.Contains(t, stdout, "    testsuite files: 1 passed, 0 failed, 1 errored\n")
+		assert.Contains(t, stdout, "    test cases: 1 passed, 0 failed, 0 skipped, 0 errored\n")
+		assert.Contains(t, stdout, "    errored testsuite files:\n        /tests/b_xprin.yaml\nFAIL\n")
+	})
+
+	t.Run("artifacts manifest", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, "/tests/a_xprin.yaml", []byte(testContentWithTests), 0o644))
+
+		newRunnerFunc = func(options *testexecutionUtils.Options, testSuiteFile string, _ *api.TestSuiteSpec) runnerInterface {
+			return &mockRunner{
+				options: options,
+				runTestsContextFunc: func(_ context.Context) error {
+					result := engine.NewTestSuiteResult(testSuiteFile, false)
+					result.AddResult(engine.NewTestCaseResult("test1", "", false, false, false, false, false).Complete())
+					options.Summary.AddTestSuite(result.Complete())
+
+					return nil
+				},
+			}
+		}
+
+		options := &testexecutionUtils.Options{
+			Summary:   engine.NewRunSummary(),
+			Artifacts: testexecutionUtils.NewArtifacts("/artifacts"),
+		}
+
+		var err error
+
+		_ = unittestsUtils.CaptureStdout(func() {
+			err = ProcessTargets(context.Background(), fs, []string{"/tests"}, options)
+		})
+
+		require.NoError(t, err)
+
+		manifest, err := afero.ReadFile(fs, "/artifacts/"+testexecutionUtils.ManifestFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(manifest), `"file": "/tests/a_xprin.yaml"`)
+		assert.Contains(t, string(manifest), `"name": "test1"`)
+	})
+
 	// Test with recursive path that has a trailing slash
 	t.Run("recursive path with trailing slash", func(t *testing.T) {
 		// Create directory structure
@@ -196,7 +362,7 @@ func TestProcessTargets(t *testing.T) {
 		var err error
 
 		stderrOutput := unittestsUtils.CaptureStderr(func() {
-			err = ProcessTargets(fs, []string{path}, &testexecutionUtils.Options{})
+			err = ProcessTargets(context.Background(), fs, []string{path}, &testexecutionUtils.Options{})
 		})
 
 		// No errors expected
@@ -224,7 +390,7 @@ func TestProcessTargets(t *testing.T) {
 		var err error
 
 		stderrOutput := unittestsUtils.CaptureStderr(func() {
-			err = ProcessTargets(fs, []string{path}, &testexecutionUtils.Options{})
+			err = ProcessTargets(context.Background(), fs, []string{path}, &testexecutionUtils.Options{})
 		})
 
 		// No errors expected
@@ -286,7 +452,7 @@ func TestProcessTargets(t *testing.T) {
 		}
 
 		// Process individual files first to confirm direct file handling works as expected
-		err := ProcessTargets(fs, []string{invalidFile1, invalidFile2, invalidFile3, invalidFile4, validFile1, validFile2, validFile3}, &testexecutionUtils.Options{})
+		err := ProcessTargets(context.Background(), fs, []string{invalidFile1, invalidFile2, invalidFile3, invalidFile4, validFile1, validFile2, validFile3}, &testexecutionUtils.Options{})
 
 		// Should not error since invalid files are just ignored
 		require.NoError(t, err)
@@ -298,7 +464,7 @@ func TestProcessTargets(t *testing.T) {
 		buf.Reset()
 
 		// Process the directory containing both invalid and valid files
-		err = ProcessTargets(fs, []string{"/testdir"}, &testexecutionUtils.Options{})
+		err = ProcessTargets(context.Background(), fs, []string{"/testdir"}, &testexecutionUtils.Options{})
 
 		// After directory processing, should not error
 		require.NoError(t, err, "Processing directory with mixed valid/invalid files should not error")
@@ -326,7 +492,7 @@ func TestProcessDirectory(t *testing.T) {
 				var err error
 
 				out := unittestsUtils.CaptureStderr(func() {
-					err = processDirectory(fs, dir, &testexecutionUtils.Options{})
+					err = processDirectory(context.Background(), fs, dir, &testexecutionUtils.Options{})
 				})
 				assert.Contains(t, out, "?   \t"+dir+"\t[no testsuite files]", "expected no testsuite files message")
 				assert.NoError(t, err, "did not expect error for empty directory")
@@ -344,7 +510,7 @@ func TestProcessDirectory(t *testing.T) {
 		var err error
 
 		out := unittestsUtils.CaptureStderr(func() {
-			err = processDirectory(fs, badPattern, &testexecutionUtils.Options{})
+			err = processDirectory(context.Background(), fs, badPattern, &testexecutionUtils.Options{})
 		})
 		// processDirectory treats "no test files found" as a special case and doesn't return an error
 		// It just prints a message to stderr
@@ -368,7 +534,7 @@ func TestProcessDirectory(t *testing.T) {
 		var err error
 
 		out := unittestsUtils.CaptureOutput(func() {
-			err = processDirectory(fs, dir, &testexecutionUtils.Options{})
+			err = processDirectory(context.Background(), fs, dir, &testexecutionUtils.Options{})
 		})
 		// Since we're writing dummy files, there will likely be errors during processing
 		// but that's not what we're testing here - we're testing file discovery
@@ -389,7 +555,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 		var err error
 
 		out := unittestsUtils.CaptureStderr(func() {
-			err = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+			err = processTestSuiteFile(context.Background(), fs, testFile, &testexecutionUtils.Options{})
 		})
 		if !strings.Contains(out, "?   \t/suite.yaml\t[no test cases found]") {
 			t.Errorf("expected no test cases found output, got: %q", out)
@@ -414,7 +580,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 		}
 
 		stderrOutput := unittestsUtils.CaptureStderr(func() {
-			err = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+			err = processTestSuiteFile(context.Background(), fs, testFile, &testexecutionUtils.Options{})
 		})
 
 		// Should have an error returned
@@ -446,7 +612,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 		}
 
 		stderrOutput := unittestsUtils.CaptureStderr(func() {
-			err = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+			err = processTestSuiteFile(context.Background(), fs, testFile, &testexecutionUtils.Options{})
 		})
 
 		if !strings.Contains(stderrOutput, "?   \t/suite.yaml\t[no test cases found]") {
@@ -467,13 +633,13 @@ func TestProcessTestSuiteFile(t *testing.T) {
 
 		runner := &mockRunner{output: bytes.NewBuffer(nil), options: &testexecutionUtils.Options{}}
 		runner.runTestsFunc = func() error {
-			return errors.New("tests failed in testsuite suite.yaml: something failed")
+			return fmt.Errorf("%w in testsuite suite.yaml: something failed", testexecutionUtils.ErrTestsFailed)
 		}
 		newRunnerFunc = func(_ *testexecutionUtils.Options, _ string, _ *api.TestSuiteSpec) runnerInterface {
 			return runner
 		}
 
-		err = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+		err = processTestSuiteFile(context.Background(), fs, testFile, &testexecutionUtils.Options{})
 		if err == nil {
 			t.Errorf("expected error, got nil")
 		}
@@ -497,7 +663,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 		}
 
 		stderrOutput := unittestsUtils.CaptureStderr(func() {
-			err = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+			err = processTestSuiteFile(context.Background(), fs, testFile, &testexecutionUtils.Options{})
 		})
 
 		// Check stderr for FAIL status
@@ -533,7 +699,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 		}
 
 		stderrOutput := unittestsUtils.CaptureStderr(func() {
-			err = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+			err = processTestSuiteFile(context.Background(), fs, testFile, &testexecutionUtils.Options{})
 		})
 
 		if strings.Contains(stderrOutput, "FAIL") {
@@ -545,6 +711,42 @@ func TestProcessTestSuiteFile(t *testing.T) {
 		}
 	})
 
+	t.Run("extends are resolved before running", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		testFile := testSuiteYAML
+		content := `
+tests:
+- name: base
+  id: base
+  inputs:
+    xr: xr.yaml
+    composition: comp.yaml
+    functions: functions.yaml
+- name: child
+  extends: base
+  inputs:
+    xr: other-xr.yaml
+`
+		require.NoError(t, afero.WriteFile(fs, testFile, []byte(content), 0o644))
+
+		var gotSpec *api.TestSuiteSpec
+
+		runner := &mockRunner{output: bytes.NewBuffer(nil), options: &testexecutionUtils.Options{}}
+		runner.runTestsFunc = func() error {
+			return nil
+		}
+		newRunnerFunc = func(_ *testexecutionUtils.Options, _ string, spec *api.TestSuiteSpec) runnerInterface {
+			gotSpec = spec
+			return runner
+		}
+
+		require.NoError(t, processTestSuiteFile(context.Background(), fs, testFile, &testexecutionUtils.Options{}))
+		require.NotNil(t, gotSpec)
+		assert.Equal(t, "other-xr.yaml", gotSpec.Tests[1].Inputs.XR)
+		assert.Equal(t, "comp.yaml", gotSpec.Tests[1].Inputs.Composition)
+		assert.Equal(t, "functions.yaml", gotSpec.Tests[1].Inputs.Functions)
+	})
+
 	// Table-driven tests for name validation scenarios
 	t.Run("testsuite file validation", func(t *testing.T) {
 		tests := []struct {
@@ -625,7 +827,7 @@ func TestProcessTestSuiteFile(t *testing.T) {
 				}
 
 				stderrOutput := unittestsUtils.CaptureStderr(func() {
-					processErr = processTestSuiteFile(fs, testFile, &testexecutionUtils.Options{})
+					processErr = processTestSuiteFile(context.Background(), fs, testFile, &testexecutionUtils.Options{})
 				})
 
 				if len(tt.expectedErrors) > 0 {
@@ -0,0 +1,326 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"go.yaml.in/yaml/v3"
+)
+
+// schemaURL is the location of the JSON schema of testsuite files when it is compiled.
+const schemaURL = "xprin-testsuite.json"
+
+// validateSchema validates a YAML document against a JSON schema and returns the values that do not match it.
+func validateSchema(root *yaml.Node, schema map[string]any) []Finding {
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return nil
+		}
+
+		root = root.Content[0]
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaURL, schema); err != nil {
+		return []Finding{{Message: fmt.Sprintf("invalid schema: %v", err)}}
+	}
+
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return []Finding{{Message: fmt.Sprintf("invalid schema: %v", err)}}
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if err := compiled.Validate(jsonValue(root)); !errors.As(err, &validationErr) {
+		return nil
+	}
+
+	var findings []Finding
+
+	for _, leaf := range schemaProblems(validationErr) {
+		findings = append(findings, schemaFindings(root, leaf)...)
+	}
+
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+
+		return strings.Compare(a.Message, b.Message)
+	})
+
+	return findings
+}
+
+// jsonValue returns the JSON value of a YAML node, with aliases and merge keys resolved. A null value of a field is
+// the same as an unset field, as when the testsuite file is loaded.
+func jsonValue(node *yaml.Node) any {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil
+		}
+
+		return jsonValue(node.Content[0])
+	case yaml.AliasNode:
+		return jsonValue(node.Alias)
+	case yaml.MappingNode:
+		object := make(map[string]any)
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value != "<<" {
+				continue
+			}
+
+			for _, merged := range mergedMappings(node.Content[i+1]) {
+				for key, value := range jsonValue(merged).(map[string]any) {
+					object[key] = value
+				}
+			}
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if key := node.Content[i].Value; key != "<<" {
+				object[key] = jsonValue(node.Content[i+1])
+
+				if object[key] == nil {
+					delete(object, key)
+				}
+			}
+		}
+
+		return object
+	case yaml.SequenceNode:
+		array := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			array = append(array, jsonValue(item))
+		}
+
+		return array
+	case yaml.ScalarNode:
+		var value any
+		if node.Tag == "!!str" || node.Decode(&value) != nil {
+			return node.Value
+		}
+
+		if _, ok := value.(bool); ok || value == nil {
+			return value
+		}
+
+		// Other scalars are numbers, or types that JSON does not have (e.g. timestamps), which are read as strings
+		if number, err := strconv.ParseFloat(fmt.Sprint(value), 64); err == nil {
+			return number
+		}
+
+		return node.Value
+	default:
+		return nil
+	}
+}
+
+// mergedMappings returns the mappings merged by the value of a merge key: a mapping or a list of mappings.
+func mergedMappings(node *yaml.Node) []*yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		return []*yaml.Node{node}
+	case yaml.SequenceNode:
+		var mappings []*yaml.Node
+		for _, item := range node.Content {
+			mappings = append(mappings, mergedMappings(item)...)
+		}
+
+		return mappings
+	default:
+		return nil
+	}
+}
+
+// schemaProblems returns the errors of a schema validation error that are problems of the testsuite file: for a value
+// that can have one of several forms, those of the form of the same type as the value.
+// A scalar is not a problem where a string is expected, since it is read as a string when the file is loaded.
+func schemaProblems(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	switch k := err.ErrorKind.(type) {
+	case *kind.Type:
+		if slices.Contains(k.Want, "string") && slices.Contains([]string{"number", "integer", "boolean"}, k.Got) {
+			return nil
+		}
+
+		return []*jsonschema.ValidationError{err}
+	case *kind.OneOf, *kind.AnyOf:
+		var (
+			sameType []*jsonschema.ValidationError
+			types    []string
+		)
+
+		for _, cause := range err.Causes {
+			problems := schemaProblems(cause)
+			if len(problems) == 0 {
+				return nil
+			}
+
+			if t := typeError(cause); t != nil {
+				types = append(types, t.Want...)
+			} else {
+				sameType = append(sameType, problems...)
+			}
+		}
+
+		if len(sameType) > 0 || len(types) == 0 {
+			return sameType
+		}
+
+		return []*jsonschema.ValidationError{{InstanceLocation: err.InstanceLocation, ErrorKind: &kind.Type{Want: types}}}
+	}
+
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+
+	var problems []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		problems = append(problems, schemaProblems(cause)...)
+	}
+
+	return problems
+}
+
+// typeError returns the type error of a validation error of a value, if the value does not have the type of the
+// schema, through the references and groups of errors that lead to it.
+func typeError(err *jsonschema.ValidationError) *kind.Type {
+	for {
+		if t, ok := err.ErrorKind.(*kind.Type); ok {
+			return t
+		}
+
+		if len(err.Causes) != 1 || !slices.Equal(err.Causes[0].InstanceLocation, err.InstanceLocation) {
+			return nil
+		}
+
+		err = err.Causes[0]
+	}
+}
+
+// schemaFindings returns the findings of a schema validation error, at the line of the value it is about.
+func schemaFindings(root *yaml.Node, err *jsonschema.ValidationError) []Finding {
+	node, path := root, ""
+
+	for _, key := range err.InstanceLocation {
+		node, path = schemaChild(node, path, key)
+	}
+
+	finding := func(line int, message string) Finding {
+		if path != "" {
+			message = path + ": " + message
+		}
+
+		return Finding{Line: line, Message: message}
+	}
+
+	switch k := err.ErrorKind.(type) {
+	case *kind.Type:
+		var types []string
+		for _, t := range k.Want {
+			types = append(types, article(t))
+		}
+
+		return []Finding{finding(node.Line, "must be "+strings.Join(types, " or "))}
+	case *kind.Enum:
+		var allowed []string
+		for _, value := range k.Want {
+			allowed = append(allowed, fmt.Sprint(value))
+		}
+
+		return []Finding{finding(node.Line, fmt.Sprintf("invalid value '%v' (allowed: %s)", k.Got, strings.Join(allowed, ", ")))}
+	case *kind.Required:
+		var findings []Finding
+		for _, field := range k.Missing {
+			findings = append(findings, finding(node.Line, fmt.Sprintf("missing required field '%s'", field)))
+		}
+
+		return findings
+	case *kind.AdditionalProperties:
+		var findings []Finding
+		for _, field := range k.Properties {
+			findings = append(findings, finding(keyLine(node, field), fmt.Sprintf("unknown field '%s'", field)))
+		}
+
+		return findings
+	default:
+		return []Finding{finding(node.Line, err.Error())}
+	}
+}
+
+// schemaChild returns the value of a field or list item of a node, and its location in the document, e.g.
+// tests[0].inputs. The node itself is returned if the value is not in the node, e.g. because it is merged.
+func schemaChild(node *yaml.Node, path, key string) (*yaml.Node, string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		if path != "" {
+			path += "."
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1], path + key
+			}
+		}
+
+		return node, path + key
+	case yaml.SequenceNode:
+		if i, err := strconv.Atoi(key); err == nil && i < len(node.Content) {
+			return node.Content[i], fmt.Sprintf("%s[%d]", path, i)
+		}
+	case yaml.DocumentNode, yaml.ScalarNode, yaml.AliasNode:
+	}
+
+	return node, path
+}
+
+// keyLine returns the line of a field of a mapping, or of the mapping if it does not have the field.
+func keyLine(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i].Line
+		}
+	}
+
+	return node.Line
+}
+
+// article returns a type name with its indefinite article, e.g. "an object".
+func article(t string) string {
+	if strings.ContainsRune("aeiou", rune(t[0])) {
+		return "an " + t
+	}
+
+	return "a " + t
+}
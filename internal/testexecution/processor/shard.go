@@ -0,0 +1,267 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/gertd/go-pluralize"
+	"github.com/spf13/afero"
+)
+
+// defaultShardWeight is the weight of every testsuite file when no durations are known.
+const defaultShardWeight = time.Second
+
+// Shard is the part of the testsuite files that a CI job runs (--shard-index, --shard-total). Testsuite files are
+// never split, so that the test cases of a testsuite file that use each other's results always run together.
+type Shard struct {
+	Index     int                      // 0-based index of the shard, lower than Total
+	Total     int                      // Number of shards
+	Durations map[string]time.Duration // Durations of the testsuite files in a previous run, to balance the shards
+}
+
+// ShardTestSuiteFiles returns the testsuite files of the targets (see FindTestSuiteFiles) that belong to a shard.
+func ShardTestSuiteFiles(fs afero.Fs, targets []string, shard *Shard, debug bool) ([]string, error) {
+	files, err := FindTestSuiteFiles(fs, targets, debug)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := shard.Select(files)
+
+	if debug {
+		plural := pluralize.NewClient()
+		utils.DebugPrintf("Shard %d of %d runs %d of %s\n", shard.Index, shard.Total, len(selected),
+			plural.Pluralize("testsuite file", len(files), true))
+	}
+
+	return selected, nil
+}
+
+// Select partitions the testsuite files into Total shards and returns the files of shard Index, in their original
+// order. The partition is deterministic: the files are assigned from the longest to the shortest (by path for
+// equal durations) to the shard with the lowest total duration so far. Files without a known duration weigh the
+// average of the known ones, so without durations the files are distributed round-robin by path.
+func (s *Shard) Select(files []string) []string {
+	var unique []string
+
+	seen := make(map[string]bool)
+
+	for _, file := range files {
+		key := shardKey(file)
+		if !seen[key] {
+			seen[key] = true
+
+			unique = append(unique, file)
+		}
+	}
+
+	weights := s.weights(unique)
+
+	ordered := slices.Clone(unique)
+	slices.SortStableFunc(ordered, func(a, b string) int {
+		if c := cmp.Compare(weights[b], weights[a]); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(shardKey(a), shardKey(b))
+	})
+
+	loads := make([]time.Duration, s.Total)
+	inShard := make(map[string]bool)
+
+	for _, file := range ordered {
+		shard := 0
+
+		for i := range loads {
+			if loads[i] < loads[shard] {
+				shard = i
+			}
+		}
+
+		loads[shard] += weights[file]
+
+		if shard == s.Index {
+			inShard[file] = true
+		}
+	}
+
+	selected := []string{}
+
+	for _, file := range unique {
+		if inShard[file] {
+			selected = append(selected, file)
+		}
+	}
+
+	return selected
+}
+
+// weights returns the weight of each testsuite file: its recorded duration, or the average of the recorded durations.
+func (s *Shard) weights(files []string) map[string]time.Duration {
+	var known time.Duration
+
+	var count int
+
+	for _, file := range files {
+		if duration, ok := s.Durations[shardKey(file)]; ok {
+			known += duration
+			count++
+		}
+	}
+
+	fallback := defaultShardWeight
+	if count > 0 && known > 0 {
+		fallback = known / time.Duration(count)
+	}
+
+	weights := make(map[string]time.Duration, len(files))
+
+	for _, file := range files {
+		duration, ok := s.Durations[shardKey(file)]
+		if !ok {
+			duration = fallback
+		}
+
+		weights[file] = duration
+	}
+
+	return weights
+}
+
+// shardKey returns the path of a testsuite file as it is compared with the files of a previous run.
+func shardKey(file string) string {
+	return filepath.ToSlash(filepath.Clean(file))
+}
+
+// LoadShardDurations reads the durations of the testsuite files of a previous run from a report: the manifest.json
+// written with --artifacts-dir, or a JUnit XML report with a testsuite per testsuite file.
+func LoadShardDurations(fs afero.Fs, file string) (map[string]time.Duration, error) {
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read durations report: %w", err)
+	}
+
+	var durations map[string]time.Duration
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		durations, err = junitDurations(data)
+	} else {
+		durations, err = manifestDurations(data)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse durations report %s: %w", file, err)
+	}
+
+	return durations, nil
+}
+
+// manifestDurations returns the durations of the testsuite files of an artifacts manifest.
+func manifestDurations(data []byte) (map[string]time.Duration, error) {
+	var report struct {
+		TestSuites []struct {
+			File     string  `json:"file"`
+			Duration float64 `json:"duration"` // In seconds
+		} `json:"testsuites"`
+	}
+
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+
+	durations := make(map[string]time.Duration, len(report.TestSuites))
+
+	for _, suite := range report.TestSuites {
+		durations[shardKey(suite.File)] = seconds(suite.Duration)
+	}
+
+	return durations, nil
+}
+
+// junitTestSuite is a testsuite of a JUnit XML report. Its name (or file attribute) is the testsuite file.
+type junitTestSuite struct {
+	Name      string `xml:"name,attr"`
+	File      string `xml:"file,attr"`
+	Time      string `xml:"time,attr"` // In seconds
+	TestCases []struct {
+		Time string `xml:"time,attr"`
+	} `xml:"testcase"`
+}
+
+// junitDurations returns the durations of the testsuites of a JUnit XML report, whose root is either <testsuites>
+// or a single <testsuite>. A testsuite without time takes the sum of the times of its test cases.
+func junitDurations(data []byte) (map[string]time.Duration, error) {
+	var report struct {
+		XMLName xml.Name
+		junitTestSuite
+
+		TestSuites []junitTestSuite `xml:"testsuite"`
+	}
+
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+
+	suites := report.TestSuites
+
+	switch report.XMLName.Local {
+	case "testsuite":
+		suites = []junitTestSuite{report.junitTestSuite}
+	case "testsuites":
+	default:
+		return nil, errors.New("not a JUnit report: the root element must be <testsuites> or <testsuite>")
+	}
+
+	durations := make(map[string]time.Duration, len(suites))
+
+	for _, suite := range suites {
+		file := cmp.Or(suite.File, suite.Name)
+		if file == "" {
+			continue
+		}
+
+		total, err := strconv.ParseFloat(suite.Time, 64)
+		if err != nil {
+			total = 0
+
+			for _, testCase := range suite.TestCases {
+				t, _ := strconv.ParseFloat(testCase.Time, 64)
+				total += t
+			}
+		}
+
+		durations[shardKey(file)] += seconds(total)
+	}
+
+	return durations, nil
+}
+
+// seconds converts a duration in seconds to a time.Duration.
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
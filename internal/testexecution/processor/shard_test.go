@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestShard_Select(t *testing.T) {
+	files := []string{"d_xprin.yaml", "a_xprin.yaml", "c_xprin.yaml", "b_xprin.yaml", "e_xprin.yaml"}
+
+	tests := []struct {
+		name      string
+		files     []string
+		total     int
+		durations map[string]time.Duration
+		want      [][]string
+	}{
+		{
+			name:  "round-robin by path without durations, in the original order",
+			files: files,
+			total: 2,
+			want: [][]string{
+				{"a_xprin.yaml", "c_xprin.yaml", "e_xprin.yaml"},
+				{"d_xprin.yaml", "b_xprin.yaml"},
+			},
+		},
+		{
+			name:  "more shards than files",
+			files: []string{"a_xprin.yaml"},
+			total: 3,
+			want:  [][]string{{"a_xprin.yaml"}, {}, {}},
+		},
+		{
+			name:  "duplicates are run once",
+			files: []string{"a_xprin.yaml", "./a_xprin.yaml", "b_xprin.yaml"},
+			total: 2,
+			want:  [][]string{{"a_xprin.yaml"}, {"b_xprin.yaml"}},
+		},
+		{
+			name:  "balanced by durations",
+			files: files,
+			total: 2,
+			durations: map[string]time.Duration{
+				"a_xprin.yaml": 10 * time.Second,
+				"b_xprin.yaml": 4 * time.Second,
+				"c_xprin.yaml": 3 * time.Second,
+				"d_xprin.yaml": 3 * time.Second,
+			},
+			// e weighs the average (5s): a (10s) | e, b (9s) | c (12s) | d (13s)
+			want: [][]string{
+				{"d_xprin.yaml", "a_xprin.yaml"},
+				{"c_xprin.yaml", "b_xprin.yaml", "e_xprin.yaml"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := range tt.total {
+				shard := &Shard{Index: i, Total: tt.total, Durations: tt.durations}
+				got := shard.Select(tt.files)
+
+				assert.Equal(t, tt.want[i], got, "shard %d", i)
+				assert.Equal(t, got, shard.Select(tt.files), "shard %d is not deterministic", i)
+			}
+		})
+	}
+}
+
+func TestLoadShardDurations(t *testing.T) {
+	tests := []struct {
+		name    string
+		report  string
+		want    map[string]time.Duration
+		wantErr string
+	}{
+		{
+			name:   "artifacts manifest",
+			report: `{"duration": 3, "testsuites": [{"file": "tests/a_xprin.yaml", "duration": 1.5}, {"file": "./tests/b_xprin.yaml", "duration": 0.25}]}`,
+			want: map[string]time.Duration{
+				"tests/a_xprin.yaml": 1500 * time.Millisecond,
+				"tests/b_xprin.yaml": 250 * time.Millisecond,
+			},
+		},
+		{
+			name: "JUnit testsuites",
+			report: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="tests/a_xprin.yaml" time="2">
+    <testcase name="one" time="1.5"/>
+  </testsuite>
+  <testsuite name="b" file="tests/b_xprin.yaml">
+    <testcase name="one" time="0.5"/>
+    <testcase name="two" time="1"/>
+  </testsuite>
+</testsuites>`,
+			want: map[string]time.Duration{
+				"tests/a_xprin.yaml": 2 * time.Second,
+				"tests/b_xprin.yaml": 1500 * time.Millisecond,
+			},
+		},
+		{
+			name:   "JUnit single testsuite",
+			report: `<testsuite name="tests/a_xprin.yaml" time="0.5"></testsuite>`,
+			want:   map[string]time.Duration{"tests/a_xprin.yaml": 500 * time.Millisecond},
+		},
+		{
+			name:    "not a JUnit report",
+			report:  `<html></html>`,
+			wantErr: "not a JUnit report",
+		},
+		{
+			name:    "invalid JSON",
+			report:  `{"testsuites": [`,
+			wantErr: "failed to parse durations report report.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "report.json", []byte(tt.report), 0o644))
+
+			got, err := LoadShardDurations(fs, "report.json")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestShardTestSuiteFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	for _, file := range []string{"/tests/a_xprin.yaml", "/tests/b_xprin.yaml", "/tests/sub/c_xprin.yaml", "/tests/notes.yaml"} {
+		require.NoError(t, afero.WriteFile(fs, file, []byte("tests: []\n"), 0o644))
+	}
+
+	shard0, err := ShardTestSuiteFiles(fs, []string{"/tests/..."}, &Shard{Index: 0, Total: 2}, false)
+	require.NoError(t, err)
+
+	shard1, err := ShardTestSuiteFiles(fs, []string{"/tests/..."}, &Shard{Index: 1, Total: 2}, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/tests/a_xprin.yaml", "/tests/sub/c_xprin.yaml"}, shard0)
+	assert.Equal(t, []string{"/tests/b_xprin.yaml"}, shard1)
+}
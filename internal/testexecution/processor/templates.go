@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	"github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"go.yaml.in/yaml/v3"
+)
+
+// unquotedTemplatePattern matches a line with a list item or a map value that starts with an unquoted template.
+var unquotedTemplatePattern = regexp.MustCompile(`^\s*(?:-\s+)?(?:[\w.-]+:\s+)?\{\{`)
+
+// templateActionPattern matches a template action on a line.
+var templateActionPattern = regexp.MustCompile(`\{\{.*?\}\}`)
+
+// templatePlaceholderPattern matches the placeholders that replace the template actions of unquoted templates.
+var templatePlaceholderPattern = regexp.MustCompile(`__XPRIN_TEMPLATE_(\d+)__`)
+
+// testSuiteDocument is a testsuite file or fragment parsed as YAML.
+type testSuiteDocument struct {
+	root yaml.Node
+	// data is the YAML of the document, to unmarshal it into an api.TestSuiteSpec
+	data []byte
+	// unquoted are the lines of the templates at the start of a value that are not quoted. They are deprecated,
+	// and read as strings by replacing their template actions with placeholders while the YAML is parsed.
+	unquoted []int
+}
+
+// parseTestSuiteDocument parses a testsuite file or fragment as YAML. Templates at the start of a value must be
+// quoted, since they are otherwise a YAML flow mapping. For compatibility with files written before, unquoted
+// templates are read as strings, and their lines are returned for a deprecation warning.
+func parseTestSuiteDocument(data []byte) (*testSuiteDocument, error) {
+	doc := &testSuiteDocument{data: data}
+
+	err := yaml.Unmarshal(data, &doc.root)
+	if err == nil && unquotedTemplateLine(&doc.root) == 0 {
+		return doc, nil
+	}
+
+	legacy, ok := parseUnquotedTemplates(data)
+	if !ok {
+		return nil, err
+	}
+
+	return legacy, nil
+}
+
+// parseUnquotedTemplates parses a YAML document after replacing the template actions on the lines that start a
+// value with a template with placeholders, and restores the templates in the parsed string values.
+// It returns false if the document is not valid YAML this way either, or has no unquoted templates.
+func parseUnquotedTemplates(data []byte) (*testSuiteDocument, bool) {
+	var templates []string
+
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		if !unquotedTemplatePattern.MatchString(line) || strings.HasPrefix(strings.TrimSpace(line), "{{") {
+			continue
+		}
+
+		lines[i] = templateActionPattern.ReplaceAllStringFunc(line, func(action string) string {
+			templates = append(templates, action)
+			return fmt.Sprintf("__XPRIN_TEMPLATE_%d__", len(templates)-1)
+		})
+	}
+
+	if len(templates) == 0 {
+		return nil, false
+	}
+
+	doc := &testSuiteDocument{}
+	if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &doc.root); err != nil {
+		return nil, false
+	}
+
+	utils.WalkScalars(&doc.root, func(node *yaml.Node, _ []string) {
+		node.Value = templatePlaceholderPattern.ReplaceAllStringFunc(node.Value, func(placeholder string) string {
+			index, _ := strconv.Atoi(templatePlaceholderPattern.FindStringSubmatch(placeholder)[1])
+			return templates[index]
+		})
+
+		if node.Style == 0 && strings.HasPrefix(node.Value, "{{") {
+			doc.unquoted = append(doc.unquoted, node.Line)
+		}
+	})
+
+	if len(doc.unquoted) == 0 {
+		return nil, false
+	}
+
+	data, err := yaml.Marshal(&doc.root)
+	if err != nil {
+		return nil, false
+	}
+
+	doc.data = data
+
+	return doc, true
+}
+
+// unquotedTemplateMessage is the message about a deprecated unquoted template.
+const unquotedTemplateMessage = "template at the start of a value is not quoted, which is deprecated and will be an error in a future release: quote the value"
+
+// checkTemplates checks the templates in the string values of a testsuite file or fragment:
+// that they parse, and that they only use the template variables available in the phase in which they are rendered.
+// Errors point to the line of the template.
+func checkTemplates(path string, root *yaml.Node) error {
+	var errs []string
+
+	for _, finding := range templateFindings(root, checkTemplate) {
+		errs = append(errs, fmt.Sprintf("%s:%d: %s", filepath.Base(path), finding.Line, finding.Message))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid templates:\n- %s", strings.Join(errs, "\n- "))
+	}
+
+	return nil
+}
+
+// checkTemplate checks that a template parses and only uses the template variables available in its phase.
+func checkTemplate(name, value string, keys []string) error {
+	return runner.CheckTemplate(name, value, utils.PhaseOf(keys))
+}
+
+// templateFindings checks the templates in the string values of a YAML document with check, and returns the
+// problems found at the lines of the templates.
+func templateFindings(root *yaml.Node, check func(name, value string, keys []string) error) []Finding {
+	var findings []Finding
+
+	utils.WalkScalars(root, func(node *yaml.Node, keys []string) {
+		if !utils.HasTemplate(node.Value) {
+			return
+		}
+
+		if err := check(strings.Join(keys, "."), node.Value, keys); err != nil {
+			findings = append(findings, Finding{Line: node.Line, Message: err.Error()})
+		}
+	})
+
+	return findings
+}
+
+// unquotedTemplateLine returns the line of the first unquoted template that was parsed as a YAML flow mapping,
+// e.g. xr: {{ .Vars.xr }}, or 0 if there is none.
+func unquotedTemplateLine(node *yaml.Node) int {
+	if node.Kind == yaml.MappingNode && node.Style&yaml.FlowStyle != 0 &&
+		len(node.Content) > 0 && node.Content[0].Kind == yaml.MappingNode && node.Content[0].Style&yaml.FlowStyle != 0 {
+		return node.Line
+	}
+
+	for _, child := range node.Content {
+		if line := unquotedTemplateLine(child); line > 0 {
+			return line
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crossplane-contrib/xprin/internal/testexecution/runner"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/fsnotify/fsnotify"
+	"github.com/gertd/go-pluralize"
+	"github.com/spf13/afero"
+	"golang.org/x/term"
+)
+
+// clearScreen clears the terminal and moves the cursor to its top left corner.
+const clearScreen = "\033[H\033[2J"
+
+// Mockable variables
+//
+//nolint:gochecknoglobals // Global variables for dependency injection in tests
+var (
+	watchDebounce = 300 * time.Millisecond // How long to wait for more changes, e.g. while an editor saves several files
+	isTerminal    = func() bool { return term.IsTerminal(int(os.Stdout.Fd())) }
+)
+
+// WatchTargets runs the testsuite files of the targets (see FindTestSuiteFiles), then watches them and re-runs the
+// ones affected by each change (see AffectedTestSuiteFiles) until ctx is done, e.g. on Ctrl-C. The directories of the
+// targets, of the testsuite files, of their included fragments and of the paths their test cases read are watched,
+// so that new testsuite files and files saved by replacing them are noticed. Only the affected test cases are re-run,
+// with the test cases they depend on, unless the testsuite files or their included fragments changed. Each run gets
+// its options from newOptions, clears the screen and ends with its summary; failed runs do not stop the watch.
+func WatchTargets(ctx context.Context, fs afero.Fs, targets []string, newOptions func() *testexecutionUtils.Options) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start watching files: %w", err)
+	}
+
+	defer func() { _ = watcher.Close() }()
+
+	options := newOptions()
+	plural := pluralize.NewClient()
+
+	files, err := FindTestSuiteFiles(fs, targets, options.Debug)
+	if err != nil {
+		return err
+	}
+
+	run := func(files []string, testCases map[string][]int, header string) {
+		if isTerminal() {
+			utils.OutputPrintf(clearScreen)
+		}
+
+		utils.OutputPrintf("[%s] %s", time.Now().Format(time.TimeOnly), header)
+
+		runOptions := newOptions()
+		runOptions.TestCases = testCases
+
+		// Failed and errored tests are reported by the summary of the run
+		_ = ProcessTargets(ctx, fs, files, runOptions)
+	}
+
+	run(files, nil, fmt.Sprintf("running %s\n", plural.Pluralize("testsuite file", len(files), true)))
+
+	for ctx.Err() == nil {
+		dirs := watchDirs(fs, targets, files, options)
+		syncWatcher(watcher, dirs, options.Debug)
+
+		utils.OutputPrintf("\nWatching %s for changes, press Ctrl-C to stop\n", plural.Pluralize("directory", len(dirs), true))
+
+		selections, current := waitForAffected(ctx, fs, watcher, targets, options)
+		if selections == nil {
+			break
+		}
+
+		files = current
+
+		var header strings.Builder
+
+		fmt.Fprintf(&header, "re-running %s affected by changes\n", plural.Pluralize("testsuite file", len(selections), true))
+
+		selected := make([]string, 0, len(selections))
+		testCases := make(map[string][]int)
+
+		for _, selection := range selections {
+			selected = append(selected, selection.File)
+
+			if selection.TestCases == nil {
+				fmt.Fprintf(&header, "    %s\n", selection)
+				continue
+			}
+
+			fmt.Fprintf(&header, "    %s (%s)\n", selection, plural.Pluralize("test case", len(selection.TestCases), true))
+
+			testCases[selection.File] = selection.TestCases
+		}
+
+		run(selected, testCases, header.String())
+	}
+
+	return nil
+}
+
+// waitForAffected waits for changes until they affect testsuite files of the targets, and returns them with the
+// testsuite files of the targets found again, or nil when ctx is done. Testsuite files that cannot be loaded are only
+// affected when they change themselves, so that their error is not reported again on every change.
+func waitForAffected(ctx context.Context, fs afero.Fs, watcher *fsnotify.Watcher, targets []string, options *testexecutionUtils.Options) ([]ChangedSelection, []string) {
+	for {
+		changed := waitForChanges(ctx, watcher, options.Debug)
+		if changed == nil {
+			return nil, nil
+		}
+
+		files, err := FindTestSuiteFiles(fs, targets, options.Debug)
+		if err != nil {
+			utils.WarningPrintf("%v\n", err)
+			continue
+		}
+
+		var selections []ChangedSelection
+
+		for _, selection := range AffectedTestSuiteFiles(fs, files, changed, options) {
+			selection.Reasons = slices.DeleteFunc(selection.Reasons, func(reason ChangedReason) bool {
+				return reason.Kind == ChangedReasonCannotBeLoaded
+			})
+			if len(selection.Reasons) > 0 {
+				selections = append(selections, selection)
+			}
+		}
+
+		if len(selections) > 0 {
+			return selections, files
+		}
+
+		if options.Debug {
+			utils.DebugPrintf("No testsuite file is affected by the changes to %s\n", strings.Join(changed, ", "))
+		}
+	}
+}
+
+// waitForChanges returns the paths changed (written, created, removed or renamed) in the watched directories, once
+// no further change happened for watchDebounce, or nil when ctx is done.
+func waitForChanges(ctx context.Context, watcher *fsnotify.Watcher, debug bool) []string {
+	changed := make(map[string]bool)
+
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Remove) && !event.Has(fsnotify.Rename) {
+				continue
+			}
+
+			if debug {
+				utils.DebugPrintf("Changed: %s (%s)\n", event.Name, event.Op)
+			}
+
+			changed[event.Name] = true
+			debounce = time.After(watchDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			utils.WarningPrintf("failed to watch files: %v\n", err)
+		case <-debounce:
+			paths := make([]string, 0, len(changed))
+			for path := range changed {
+				paths = append(paths, path)
+			}
+
+			slices.Sort(paths)
+
+			return paths
+		}
+	}
+}
+
+// watchDirs returns the absolute paths of the directories to watch for changes of the testsuite files of the targets:
+// the directories of the targets (recursively for targets ending with "..."), and the directories of the testsuite
+// files, of their included fragments and of the inputs, patches and golden files of their test cases (see
+// runner.Runner.InputFiles). Input directories, e.g. functions, are watched recursively.
+func watchDirs(fs afero.Fs, targets, files []string, options *testexecutionUtils.Options) []string {
+	seen := make(map[string]bool)
+
+	var dirs []string
+
+	addDir := func(dir string, recursive bool) {
+		subdirs := []string{dir}
+
+		if recursive {
+			if found, err := recursiveDirs(fs, dir); err == nil {
+				subdirs = found
+			}
+		}
+
+		for _, subdir := range subdirs {
+			absDir, err := filepath.Abs(subdir)
+			if err != nil || seen[absDir] {
+				continue
+			}
+
+			if info, err := fs.Stat(absDir); err != nil || !info.IsDir() {
+				continue
+			}
+
+			seen[absDir] = true
+
+			dirs = append(dirs, absDir)
+		}
+	}
+
+	addPath := func(path string, recursive bool) {
+		if info, err := fs.Stat(path); err == nil && info.IsDir() {
+			addDir(path, recursive)
+			return
+		}
+
+		addDir(filepath.Dir(path), false)
+	}
+
+	for _, target := range targets {
+		if strings.HasSuffix(target, "...") {
+			addDir(strings.TrimSuffix(strings.TrimSuffix(target, "..."), string(filepath.Separator)), true)
+			continue
+		}
+
+		addPath(target, false)
+	}
+
+	for _, file := range files {
+		addPath(file, false)
+
+		testSuiteSpec, included, err := loadTestSuite(fs, file)
+		if err != nil {
+			continue
+		}
+
+		for _, fragment := range included {
+			addPath(fragment, false)
+		}
+
+		for _, input := range runner.NewRunner(options, file, testSuiteSpec).InputFiles() {
+			addPath(input.Path, true)
+		}
+	}
+
+	slices.Sort(dirs)
+
+	return dirs
+}
+
+// syncWatcher makes the watcher watch exactly the directories, adding the new ones and removing the others.
+func syncWatcher(watcher *fsnotify.Watcher, dirs []string, debug bool) {
+	for _, dir := range watcher.WatchList() {
+		if !slices.Contains(dirs, dir) {
+			_ = watcher.Remove(dir)
+		}
+	}
+
+	watched := watcher.WatchList()
+
+	for _, dir := range dirs {
+		if slices.Contains(watched, dir) {
+			continue
+		}
+
+		if err := watcher.Add(dir); err != nil && debug {
+			utils.DebugPrintf("Failed to watch directory %s: %v\n", dir, err)
+		}
+	}
+}
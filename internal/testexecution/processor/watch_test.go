@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package processor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestWatchDirs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	files := map[string]string{
+		"/repo/tests/aws_xprin.yaml": `include:
+- path: ../shared/common.yaml
+tests:
+- name: network
+  inputs:
+    xr: xr.yaml
+`,
+		"/repo/shared/common.yaml": `common:
+  inputs:
+    composition: ../apis/composition.yaml
+    functions: ../functions
+`,
+		"/repo/tests/invalid_xprin.yaml":   "tests:\n- name: a\n  timeout: 5x\n",
+		"/repo/apis/composition.yaml":      "",
+		"/repo/functions/go/function.yaml": "",
+		"/repo/other/gcp_xprin.yaml":       "",
+	}
+
+	for file, content := range files {
+		require.NoError(t, afero.WriteFile(fs, file, []byte(content), 0o644))
+	}
+
+	dirs := watchDirs(fs, []string{"/repo/tests/...", "/repo/other/gcp_xprin.yaml"}, []string{"/repo/tests/aws_xprin.yaml", "/repo/tests/invalid_xprin.yaml"}, &testexecutionUtils.Options{})
+
+	assert.Equal(t, []string{"/repo/apis", "/repo/functions", "/repo/functions/go", "/repo/other", "/repo/shared", "/repo/tests"}, dirs)
+}
+
+func TestWatchTargets(t *testing.T) {
+	originalNewRunnerFunc, originalWatchDebounce, originalIsTerminal := newRunnerFunc, watchDebounce, isTerminal
+
+	defer func() {
+		newRunnerFunc, watchDebounce, isTerminal = originalNewRunnerFunc, originalWatchDebounce, originalIsTerminal
+	}()
+
+	watchDebounce = 10 * time.Millisecond
+	isTerminal = func() bool { return false }
+
+	dir := t.TempDir()
+	for file, content := range map[string]string{
+		"a_xprin.yaml": "tests:\n- name: a\n  inputs:\n    xr: a-xr.yaml\n",
+		"b_xprin.yaml": "tests:\n- name: b\n  inputs:\n    xr: b-xr.yaml\n- name: c\n  inputs:\n    xr: c-xr.yaml\n",
+		"a-xr.yaml":    "kind: XR\n",
+		"b-xr.yaml":    "kind: XR\n",
+		"c-xr.yaml":    "kind: XR\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o600))
+	}
+
+	runs := make(chan string, 10)
+	testCases := make(chan map[string][]int, 10)
+	newRunnerFunc = func(options *testexecutionUtils.Options, testSuiteFile string, _ *api.TestSuiteSpec) runnerInterface {
+		return &mockRunner{options: options, runTestsFunc: func() error {
+			runs <- filepath.Base(testSuiteFile)
+			testCases <- options.TestCases
+
+			return nil
+		}}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	output := unittestsUtils.CaptureStdout(func() {
+		go func() {
+			done <- WatchTargets(ctx, afero.NewOsFs(), []string{dir}, func() *testexecutionUtils.Options { return &testexecutionUtils.Options{} })
+		}()
+
+		assert.Equal(t, "a_xprin.yaml", waitForRun(t, runs))
+		assert.Nil(t, <-testCases, "the first run runs all test cases")
+		assert.Equal(t, "b_xprin.yaml", waitForRun(t, runs))
+		assert.Nil(t, <-testCases)
+
+		// Let the watcher start before changing the input of b
+		time.Sleep(100 * time.Millisecond)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "b-xr.yaml"), []byte("kind: Changed\n"), 0o600))
+
+		assert.Equal(t, "b_xprin.yaml", waitForRun(t, runs))
+		assert.Equal(t, map[string][]int{filepath.Join(dir, "b_xprin.yaml"): {0}}, <-testCases, "only the test case that reads b-xr.yaml runs again")
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	assert.Contains(t, output, "running 2 testsuite files")
+	assert.Contains(t, output, "re-running 1 testsuite file affected by changes\n    "+filepath.Join(dir, "b_xprin.yaml")+": XR b-xr.yaml changed (1 test case)\n")
+	assert.Contains(t, output, "Watching 1 directory for changes")
+	assert.Empty(t, runs, "unaffected testsuite files are not run again")
+}
+
+// waitForRun returns the testsuite file of the next run, or fails the test after a while.
+func waitForRun(t *testing.T, runs chan string) string {
+	t.Helper()
+
+	select {
+	case file := <-runs:
+		return file
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a testsuite file to run")
+		return ""
+	}
+}
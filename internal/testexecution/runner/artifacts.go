@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/spf13/afero"
+)
+
+// saveArtifacts persists the temporary directory of a test case (inputs and outputs) in the artifacts directory
+// (--artifacts-dir). The output of a failed render and of the hooks, which are not written to the outputs directory
+// while the test case runs, are written to it first.
+func (r *Runner) saveArtifacts(result *engine.TestCaseResult) error {
+	if r.Artifacts == nil || r.testCaseTmpDir == "" {
+		return nil
+	}
+
+	if result.HasFailedRender {
+		if err := afero.WriteFile(r.fs, filepath.Join(r.outputsDir, "render.txt"), result.RawRenderOutput, 0o600); err != nil {
+			return fmt.Errorf("failed to write render output: %w", err)
+		}
+	}
+
+	if err := r.writeHooksOutput(result.PreTestHooksResults, "pre-test"); err != nil {
+		return err
+	}
+
+	if err := r.writeHooksOutput(result.PostTestHooksResults, "post-test"); err != nil {
+		return err
+	}
+
+	dir := r.Artifacts.TestCaseDir(r.testSuiteFile, result.Name, result.ID)
+
+	// Replace the artifacts of a previous run
+	if err := r.fs.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove previous artifacts: %w", err)
+	}
+
+	if err := r.copy(r.testCaseTmpDir, dir); err != nil {
+		return fmt.Errorf("failed to copy test case directory to %s: %w", dir, err)
+	}
+
+	result.ArtifactsDir = dir
+
+	if r.Debug {
+		utils.DebugPrintf("Saved artifacts of test case '%s' to: %s\n", result.Name, dir)
+	}
+
+	return nil
+}
+
+// writeHooksOutput writes the command and output of each hook of a phase to hooks/<phase>-<n>.txt in the temporary
+// directory of the test case.
+func (r *Runner) writeHooksOutput(results []engine.HookResult, phase string) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	hooksDir := filepath.Join(r.testCaseTmpDir, "hooks")
+	if err := r.fs.MkdirAll(hooksDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	for i, hook := range results {
+		var buf bytes.Buffer
+
+		if hook.Name != "" {
+			fmt.Fprintf(&buf, "# %s\n", hook.Name)
+		}
+
+		fmt.Fprintf(&buf, "$ %s\n", hook.Command)
+		buf.Write(hook.Output)
+
+		if len(hook.Output) > 0 && !bytes.HasSuffix(hook.Output, []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+
+		if hook.Error != nil {
+			fmt.Fprintf(&buf, "error: %v\n", hook.Error)
+		}
+
+		file := filepath.Join(hooksDir, fmt.Sprintf("%s-%d.txt", phase, i+1))
+		if err := afero.WriteFile(r.fs, file, buf.Bytes(), 0o600); err != nil {
+			return fmt.Errorf("failed to write %s hook output: %w", phase, err)
+		}
+	}
+
+	return nil
+}
+
+// removeTmpDir removes a temporary directory, unless temporary directories are kept for debugging (--keep-tmp).
+func (r *Runner) removeTmpDir(dir string) {
+	if r.KeepTmp {
+		utils.WarningPrintf("kept temporary directory %s (--keep-tmp)\n", dir)
+		return
+	}
+
+	_ = r.fs.RemoveAll(dir)
+}
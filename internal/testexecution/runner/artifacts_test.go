@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	unittestsUtils "github.com/crossplane-contrib/xprin/internal/unittests/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+// newArtifactsRunner returns a runner that persists its test cases in artifactsDir, with a test case temporary
+// directory that contains an input and an output.
+func newArtifactsRunner(t *testing.T, artifactsDir string) *Runner {
+	t.Helper()
+
+	options := &testexecutionUtils.Options{Artifacts: testexecutionUtils.NewArtifacts(artifactsDir)}
+	r := NewRunner(options, "/tests/aws_xprin.yaml", &api.TestSuiteSpec{})
+
+	r.testCaseTmpDir = t.TempDir()
+	r.inputsDir = filepath.Join(r.testCaseTmpDir, "inputs")
+	r.outputsDir = filepath.Join(r.testCaseTmpDir, "outputs")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(r.inputsDir, "xr"), 0o750))
+	require.NoError(t, os.MkdirAll(r.outputsDir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(r.inputsDir, "xr", "xr.yaml"), []byte("kind: XR\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(r.outputsDir, "rendered.yaml"), []byte("kind: XR\n"), 0o600))
+
+	return r
+}
+
+func TestSaveArtifacts(t *testing.T) {
+	t.Run("persists inputs, outputs and hooks", func(t *testing.T) {
+		artifactsDir := t.TempDir()
+		r := newArtifactsRunner(t, artifactsDir)
+
+		result := engine.NewTestCaseResult("Basic test", "basic", false, false, false, false, false)
+		result.PreTestHooksResults = []engine.HookResult{
+			engine.NewHookResult("prepare", "echo hello", []byte("hello"), nil),
+		}
+		result.PostTestHooksResults = []engine.HookResult{
+			engine.NewHookResult("", "exit 1", nil, errors.New("exit status 1")),
+		}
+
+		require.NoError(t, r.saveArtifacts(result.Complete()))
+
+		dir := filepath.Join(artifactsDir, "aws_xprin", "basic")
+		assert.Equal(t, dir, result.ArtifactsDir)
+		assert.FileExists(t, filepath.Join(dir, "inputs", "xr", "xr.yaml"))
+		assert.FileExists(t, filepath.Join(dir, "outputs", "rendered.yaml"))
+
+		preTest, err := os.ReadFile(filepath.Join(dir, "hooks", "pre-test-1.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "# prepare\n$ echo hello\nhello\n", string(preTest))
+
+		postTest, err := os.ReadFile(filepath.Join(dir, "hooks", "post-test-1.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "$ exit 1\nerror: exit status 1\n", string(postTest))
+	})
+
+	t.Run("persists the output of a failed render", func(t *testing.T) {
+		artifactsDir := t.TempDir()
+		r := newArtifactsRunner(t, artifactsDir)
+
+		result := engine.NewTestCaseResult("Broken", "", false, false, false, false, false)
+		result.RawRenderOutput = []byte("crossplane: error: cannot render composite resource")
+
+		require.NoError(t, r.saveArtifacts(result.FailRender()))
+
+		render, err := os.ReadFile(filepath.Join(artifactsDir, "aws_xprin", "broken", "outputs", "render.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "crossplane: error: cannot render composite resource", string(render))
+	})
+
+	t.Run("persists nothing without artifacts directory", func(t *testing.T) {
+		r := newArtifactsRunner(t, "")
+		result := engine.NewTestCaseResult("Basic test", "basic", false, false, false, false, false).Complete()
+
+		require.NoError(t, r.saveArtifacts(result))
+		assert.Empty(t, result.ArtifactsDir)
+	})
+}
+
+func TestRemoveTmpDir(t *testing.T) {
+	r := newArtifactsRunner(t, "")
+
+	r.KeepTmp = true
+	stderr := unittestsUtils.CaptureStderr(func() {
+		r.removeTmpDir(r.testCaseTmpDir)
+	})
+	assert.Equal(t, "WARNING: kept temporary directory "+r.testCaseTmpDir+" (--keep-tmp)\n", stderr)
+	assert.DirExists(t, r.testCaseTmpDir, "temporary directory should be kept with --keep-tmp")
+
+	r.KeepTmp = false
+	r.removeTmpDir(r.testCaseTmpDir)
+	assert.NoDirExists(t, r.testCaseTmpDir)
+}
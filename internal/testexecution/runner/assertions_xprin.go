@@ -19,10 +19,12 @@ package runner
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/crossplane-contrib/xprin/internal/api"
 	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 	"github.com/spf13/afero"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
@@ -39,8 +41,12 @@ func (e *assertionExecutor) executeAssertionsXprin(assertions []api.AssertionXpr
 	return results
 }
 
-// executeAssertionXprin executes a single xprin assertion.
+// executeAssertionXprin executes a single xprin assertion, after checking it with CheckAssertionXprin.
 func (e *assertionExecutor) executeAssertionXprin(assertion api.AssertionXprin) (engine.AssertionResult, error) {
+	if err := CheckAssertionXprin(assertion); err != nil {
+		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), err.Error()), nil
+	}
+
 	switch assertion.Type {
 	case "Count":
 		return e.executeCountAssertion(assertion)
@@ -65,21 +71,99 @@ func (e *assertionExecutor) executeAssertionXprin(assertion api.AssertionXprin)
 	}
 }
 
+// CheckAssertionXprin checks that an xprin assertion has the fields required by its type, in the expected format.
+// It is used by xprin lint, and before an assertion is executed, so that the executors can rely on the fields.
+// Values that are templates are not checked, since they are only known when the test case runs.
+// The type and operator are not checked, since they are validated by the JSON schema of testsuite files.
+func CheckAssertionXprin(assertion api.AssertionXprin) error {
+	// description is the assertion type as used in the error messages, e.g. "field value" for FieldValue
+	description := map[string]string{
+		"Exists":         "exists",
+		"NotExists":      "not exists",
+		"FieldType":      "field type",
+		"FieldExists":    "field exists",
+		"FieldNotExists": "field not exists",
+		"FieldValue":     "field value",
+	}[assertion.Type]
+
+	switch assertion.Type {
+	case "Count":
+		switch value := assertion.Value.(type) {
+		case int, float64:
+		case string:
+			if !testexecutionUtils.HasTemplate(value) {
+				return fmt.Errorf("count assertion value must be a number, got %T", assertion.Value)
+			}
+		default:
+			return fmt.Errorf("count assertion value must be a number, got %T", assertion.Value)
+		}
+
+		return nil
+	case "Exists", "NotExists", "FieldType", "FieldExists", "FieldNotExists", "FieldValue":
+	default:
+		return nil
+	}
+
+	if assertion.Resource == "" {
+		return fmt.Errorf("%s assertion requires resource field", description)
+	}
+
+	if strings.HasPrefix(assertion.Type, "Field") && assertion.Field == "" {
+		return fmt.Errorf("%s assertion requires field", description)
+	}
+
+	if assertion.Type == "FieldValue" && assertion.Operator == "" {
+		return fmt.Errorf("%s assertion requires operator field", description)
+	}
+
+	if (assertion.Type == "FieldType" || assertion.Type == "FieldValue") && assertion.Value == nil {
+		return fmt.Errorf("%s assertion requires value field", description)
+	}
+
+	if assertion.Type == "FieldType" {
+		fieldTypes := []string{"string", "number", "boolean", "array", "object", "null"}
+
+		expectedType, ok := assertion.Value.(string)
+		if !ok {
+			return fmt.Errorf("%s assertion value must be a string, got %T", description, assertion.Value)
+		}
+
+		if !testexecutionUtils.HasTemplate(expectedType) && !slices.Contains(fieldTypes, expectedType) {
+			return fmt.Errorf("%s assertion value must be one of %s, got '%s'", description, strings.Join(fieldTypes, ", "), expectedType)
+		}
+	}
+
+	if testexecutionUtils.HasTemplate(assertion.Resource) {
+		return nil
+	}
+
+	parts := strings.Split(assertion.Resource, "/")
+
+	switch {
+	case assertion.Type == "NotExists" && len(parts) > 2:
+		return fmt.Errorf("not exists assertion value must be in format 'Kind' or 'Kind/name', got '%s'", assertion.Resource)
+	case assertion.Type == "Exists" && len(parts) != 2:
+		return fmt.Errorf("exists assertion value must be in format 'Kind/name', got '%s'", assertion.Resource)
+	case assertion.Type != "NotExists" && assertion.Type != "Exists" && len(parts) != 2:
+		return fmt.Errorf("%s assertion resource must be in format 'Kind/name', got '%s'", description, assertion.Resource)
+	}
+
+	return nil
+}
+
 // executeCountAssertion executes a count assertion.
 func (e *assertionExecutor) executeCountAssertion(assertion api.AssertionXprin) (engine.AssertionResult, error) {
-	// Get the expected count from the assertion value
-	expectedCount, ok := assertion.Value.(int)
-	if !ok {
-		// Try to convert from float64 (YAML numbers)
-		if floatVal, ok := assertion.Value.(float64); ok {
-			expectedCount = int(floatVal)
-		} else {
-			return engine.NewAssertionResult(
-				assertion.Name,
-				engine.StatusError(),
-				fmt.Sprintf("count assertion value must be a number, got %T", assertion.Value),
-			), nil
-		}
+	// Get the expected count from the assertion value, an int or a float64 (YAML numbers)
+	var expectedCount int
+
+	switch value := assertion.Value.(type) {
+	case int:
+		expectedCount = value
+	case float64:
+		expectedCount = int(value)
+	default:
+		// Only a template that was not rendered, which CheckAssertionXprin allows
+		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), fmt.Sprintf("count assertion value must be a number, got %T", assertion.Value)), nil
 	}
 
 	// Count the number of resources in the rendered output
@@ -104,20 +188,8 @@ func (e *assertionExecutor) executeCountAssertion(assertion api.AssertionXprin)
 
 // executeExistsAssertion executes an exists assertion.
 func (e *assertionExecutor) executeExistsAssertion(assertion api.AssertionXprin) (engine.AssertionResult, error) {
-	// Get the expected resource identifier from the assertion resource field
-	resourceIdentifier := assertion.Resource
-	if resourceIdentifier == "" {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "exists assertion requires resource field"), nil
-	}
-
-	// Parse the resource identifier (format: "Kind/name" or "Kind")
-	parts := strings.Split(resourceIdentifier, "/")
-	if len(parts) != 2 {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), fmt.Sprintf("exists assertion value must be in format 'Kind/name', got '%s'", resourceIdentifier)), nil
-	}
-
-	expectedKind := parts[0]
-	expectedName := parts[1]
+	// Parse the resource identifier (format: "Kind/name")
+	expectedKind, expectedName, _ := strings.Cut(assertion.Resource, "/")
 
 	// Search for the resource in rendered outputs
 	found := false
@@ -158,36 +230,9 @@ func (e *assertionExecutor) executeExistsAssertion(assertion api.AssertionXprin)
 }
 
 // executeNotExistsAssertion executes a not exists assertion.
-//
-
 func (e *assertionExecutor) executeNotExistsAssertion(assertion api.AssertionXprin) (engine.AssertionResult, error) {
-	// Get the resource identifier from the assertion resource field
-	resourceIdentifier := assertion.Resource
-	if resourceIdentifier == "" {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "not exists assertion requires resource field"), nil
-	}
-
-	// Parse the resource identifier (format: "Kind" or "Kind/name")
-	parts := strings.Split(resourceIdentifier, "/")
-
-	var (
-		expectedKind, expectedName string
-		checkSpecificName          bool
-	)
-
-	switch len(parts) {
-	case 1:
-		// Format: "Kind" - check for any resource of this kind
-		expectedKind = parts[0]
-		checkSpecificName = false
-	case 2:
-		// Format: "Kind/name" - check for specific resource
-		expectedKind = parts[0]
-		expectedName = parts[1]
-		checkSpecificName = true
-	default:
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), fmt.Sprintf("not exists assertion value must be in format 'Kind' or 'Kind/name', got '%s'", resourceIdentifier)), nil
-	}
+	// Parse the resource identifier (format: "Kind" to check for any resource of this kind, or "Kind/name")
+	expectedKind, expectedName, checkSpecificName := strings.Cut(assertion.Resource, "/")
 
 	// Search for the resource in rendered outputs
 	found := false
@@ -247,33 +292,10 @@ func (e *assertionExecutor) executeNotExistsAssertion(assertion api.AssertionXpr
 
 // executeFieldTypeAssertion executes a field type assertion.
 func (e *assertionExecutor) executeFieldTypeAssertion(assertion api.AssertionXprin) (engine.AssertionResult, error) {
-	// Validate required fields
-	if assertion.Resource == "" {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "field type assertion requires resource field"), nil
-	}
-
-	if assertion.Field == "" {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "field type assertion requires field"), nil
-	}
-
-	if assertion.Value == nil {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "field type assertion requires value field"), nil
-	}
-
-	// Get expected type
-	expectedType, ok := assertion.Value.(string)
-	if !ok {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), fmt.Sprintf("field type assertion value must be a string, got %T", assertion.Value)), nil
-	}
+	expectedType, _ := assertion.Value.(string)
 
 	// Parse the resource identifier (format: "Kind/name")
-	parts := strings.Split(assertion.Resource, "/")
-	if len(parts) != 2 {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), fmt.Sprintf("field type assertion resource must be in format 'Kind/name', got '%s'", assertion.Resource)), nil
-	}
-
-	expectedKind := parts[0]
-	expectedName := parts[1]
+	expectedKind, expectedName, _ := strings.Cut(assertion.Resource, "/")
 
 	// Find the resource in rendered outputs
 	resource, err := e.findResource(expectedKind, expectedName)
@@ -308,23 +330,8 @@ func (e *assertionExecutor) executeFieldTypeAssertion(assertion api.AssertionXpr
 
 // executeFieldExistsAssertion executes a field exists assertion.
 func (e *assertionExecutor) executeFieldExistsAssertion(assertion api.AssertionXprin) (engine.AssertionResult, error) {
-	// Validate required fields
-	if assertion.Resource == "" {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "field exists assertion requires resource field"), nil
-	}
-
-	if assertion.Field == "" {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "field exists assertion requires field"), nil
-	}
-
 	// Parse the resource identifier (format: "Kind/name")
-	parts := strings.Split(assertion.Resource, "/")
-	if len(parts) != 2 {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), fmt.Sprintf("field exists assertion resource must be in format 'Kind/name', got '%s'", assertion.Resource)), nil
-	}
-
-	expectedKind := parts[0]
-	expectedName := parts[1]
+	expectedKind, expectedName, _ := strings.Cut(assertion.Resource, "/")
 
 	// Find the resource in rendered outputs
 	resource, err := e.findResource(expectedKind, expectedName)
@@ -355,23 +362,8 @@ func (e *assertionExecutor) executeFieldExistsAssertion(assertion api.AssertionX
 
 // executeFieldNotExistsAssertion executes a field not exists assertion.
 func (e *assertionExecutor) executeFieldNotExistsAssertion(assertion api.AssertionXprin) (engine.AssertionResult, error) {
-	// Validate required fields
-	if assertion.Resource == "" {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "field not exists assertion requires resource field"), nil
-	}
-
-	if assertion.Field == "" {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "field not exists assertion requires field"), nil
-	}
-
 	// Parse the resource identifier (format: "Kind/name")
-	parts := strings.Split(assertion.Resource, "/")
-	if len(parts) != 2 {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), fmt.Sprintf("field not exists assertion resource must be in format 'Kind/name', got '%s'", assertion.Resource)), nil
-	}
-
-	expectedKind := parts[0]
-	expectedName := parts[1]
+	expectedKind, expectedName, _ := strings.Cut(assertion.Resource, "/")
 
 	// Find the resource in rendered outputs
 	resource, err := e.findResource(expectedKind, expectedName)
@@ -405,31 +397,8 @@ func (e *assertionExecutor) executeFieldNotExistsAssertion(assertion api.Asserti
 
 // executeFieldValueAssertion executes a field value assertion.
 func (e *assertionExecutor) executeFieldValueAssertion(assertion api.AssertionXprin) (engine.AssertionResult, error) {
-	// Validate required fields
-	if assertion.Resource == "" {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "field value assertion requires resource field"), nil
-	}
-
-	if assertion.Field == "" {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "field value assertion requires field"), nil
-	}
-
-	if assertion.Operator == "" {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "field value assertion requires operator field"), nil
-	}
-
-	if assertion.Value == nil {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), "field value assertion requires value field"), nil
-	}
-
 	// Parse the resource identifier (format: "Kind/name")
-	parts := strings.Split(assertion.Resource, "/")
-	if len(parts) != 2 {
-		return engine.NewAssertionResult(assertion.Name, engine.StatusError(), fmt.Sprintf("field value assertion resource must be in format 'Kind/name', got '%s'", assertion.Resource)), nil
-	}
-
-	expectedKind := parts[0]
-	expectedName := parts[1]
+	expectedKind, expectedName, _ := strings.Cut(assertion.Resource, "/")
 
 	// Find the resource in rendered outputs
 	resource, err := e.findResource(expectedKind, expectedName)
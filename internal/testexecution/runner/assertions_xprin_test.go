@@ -292,7 +292,7 @@ metadata:
 		executor := newAssertionExecutor(afero.NewMemMapFs(), outputs, false, "", nil, false)
 
 		assertion := api.AssertionXprin{Name: "exists-test", Type: "Exists", Resource: ""}
-		result, err := executor.executeExistsAssertion(assertion)
+		result, err := executor.executeAssertionXprin(assertion)
 
 		require.NoError(t, err)
 		assert.Equal(t, engine.StatusError(), result.Status)
@@ -307,7 +307,7 @@ metadata:
 		executor := newAssertionExecutor(afero.NewMemMapFs(), outputs, false, "", nil, false)
 
 		assertion := api.AssertionXprin{Name: "exists-test", Type: "Exists", Resource: "Pod/name/extra"}
-		result, err := executor.executeExistsAssertion(assertion)
+		result, err := executor.executeAssertionXprin(assertion)
 
 		require.NoError(t, err)
 		assert.Equal(t, engine.StatusError(), result.Status)
@@ -378,7 +378,7 @@ metadata:
 		executor := newAssertionExecutor(afero.NewMemMapFs(), outputs, false, "", nil, false)
 
 		assertion := api.AssertionXprin{Name: "not-exists-test", Type: "NotExists", Resource: ""}
-		result, err := executor.executeNotExistsAssertion(assertion)
+		result, err := executor.executeAssertionXprin(assertion)
 
 		require.NoError(t, err)
 		assert.Equal(t, engine.StatusError(), result.Status)
@@ -466,14 +466,14 @@ spec:
 
 		// Missing resource
 		assertion := api.AssertionXprin{Name: "field-type-test", Type: "FieldType", Field: "spec.replicas", Value: "int"}
-		result, err := executor.executeFieldTypeAssertion(assertion)
+		result, err := executor.executeAssertionXprin(assertion)
 		require.NoError(t, err)
 		assert.Equal(t, engine.StatusError(), result.Status)
 		assert.Contains(t, result.Message, "requires resource field")
 
 		// Missing field
 		assertion = api.AssertionXprin{Name: "field-type-test", Type: "FieldType", Resource: "Pod/test", Value: "int"}
-		result, err = executor.executeFieldTypeAssertion(assertion)
+		result, err = executor.executeAssertionXprin(assertion)
 		require.NoError(t, err)
 		assert.Equal(t, engine.StatusError(), result.Status)
 		assert.Contains(t, result.Message, "requires field")
@@ -699,28 +699,28 @@ spec:
 
 		// Missing resource
 		assertion := api.AssertionXprin{Name: "field-value-test", Type: "FieldValue", Field: "spec.replicas", Operator: "==", Value: float64(3)}
-		result, err := executor.executeFieldValueAssertion(assertion)
+		result, err := executor.executeAssertionXprin(assertion)
 		require.NoError(t, err)
 		assert.Equal(t, engine.StatusError(), result.Status)
 		assert.Contains(t, result.Message, "requires resource field")
 
 		// Missing field
 		assertion = api.AssertionXprin{Name: "field-value-test", Type: "FieldValue", Resource: "Pod/test", Operator: "==", Value: float64(3)}
-		result, err = executor.executeFieldValueAssertion(assertion)
+		result, err = executor.executeAssertionXprin(assertion)
 		require.NoError(t, err)
 		assert.Equal(t, engine.StatusError(), result.Status)
 		assert.Contains(t, result.Message, "requires field")
 
 		// Missing operator
 		assertion = api.AssertionXprin{Name: "field-value-test", Type: "FieldValue", Resource: "Pod/test", Field: "spec.replicas", Value: 3}
-		result, err = executor.executeFieldValueAssertion(assertion)
+		result, err = executor.executeAssertionXprin(assertion)
 		require.NoError(t, err)
 		assert.Equal(t, engine.StatusError(), result.Status)
 		assert.Contains(t, result.Message, "requires operator field")
 
 		// Missing value
 		assertion = api.AssertionXprin{Name: "field-value-test", Type: "FieldValue", Resource: "Pod/test", Field: "spec.replicas", Operator: "=="}
-		result, err = executor.executeFieldValueAssertion(assertion)
+		result, err = executor.executeAssertionXprin(assertion)
 		require.NoError(t, err)
 		assert.Equal(t, engine.StatusError(), result.Status)
 		assert.Contains(t, result.Message, "requires value field")
@@ -785,3 +785,70 @@ func TestAssertionExecutor_executeAssertionXprin(t *testing.T) {
 		assert.Contains(t, result.Message, "unsupported assertion type")
 	})
 }
+
+func TestCheckAssertionXprin(t *testing.T) {
+	tests := []struct {
+		name      string
+		assertion api.AssertionXprin
+		expectErr string
+	}{
+		{
+			name:      "count with a number",
+			assertion: api.AssertionXprin{Name: "count", Type: "Count", Value: 3},
+		},
+		{
+			name:      "count with a template",
+			assertion: api.AssertionXprin{Name: "count", Type: "Count", Value: "{{ .Vars.count }}"},
+		},
+		{
+			name:      "count with a string",
+			assertion: api.AssertionXprin{Name: "count", Type: "Count", Value: "three"},
+			expectErr: "count assertion value must be a number, got string",
+		},
+		{
+			name:      "exists without resource",
+			assertion: api.AssertionXprin{Name: "exists", Type: "Exists"},
+			expectErr: "exists assertion requires resource field",
+		},
+		{
+			name:      "exists with a kind",
+			assertion: api.AssertionXprin{Name: "exists", Type: "Exists", Resource: "Cluster"},
+			expectErr: "exists assertion value must be in format 'Kind/name', got 'Cluster'",
+		},
+		{
+			name:      "not exists with a kind",
+			assertion: api.AssertionXprin{Name: "not exists", Type: "NotExists", Resource: "Cluster"},
+		},
+		{
+			name:      "field exists without field",
+			assertion: api.AssertionXprin{Name: "field", Type: "FieldExists", Resource: "Cluster/rds"},
+			expectErr: "field exists assertion requires field",
+		},
+		{
+			name:      "field value without operator",
+			assertion: api.AssertionXprin{Name: "value", Type: "FieldValue", Resource: "Cluster/rds", Field: "spec.region", Value: "eu"},
+			expectErr: "field value assertion requires operator field",
+		},
+		{
+			name:      "field type with unknown type",
+			assertion: api.AssertionXprin{Name: "type", Type: "FieldType", Resource: "Cluster/rds", Field: "spec.region", Value: "int"},
+			expectErr: "field type assertion value must be one of string, number, boolean, array, object, null, got 'int'",
+		},
+		{
+			name:      "templated resource",
+			assertion: api.AssertionXprin{Name: "field", Type: "FieldNotExists", Resource: "{{ .Vars.resource }}", Field: "spec.region"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckAssertionXprin(tt.assertion)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expectErr)
+		})
+	}
+}
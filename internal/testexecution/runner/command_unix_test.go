@@ -0,0 +1,91 @@
+//go:build !windows
+
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestRunCommand_KillsProcessGroup(t *testing.T) {
+	runner := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The background sleep keeps the output pipe open, so the command only returns quickly if the whole group is killed
+	start := time.Now()
+	_, err := runner.runCommand(ctx, "sh", "-c", "sleep 30 & sleep 30")
+
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), commandWaitDelay)
+}
+
+func TestExecuteHook_Timeout(t *testing.T) {
+	runner := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{})
+	exec := newHookExecutor(nil, nil, nil, false, runner.runCommand, runner.renderTemplate)
+
+	tests := []struct {
+		name        string
+		hook        api.Hook
+		ctxTimeout  time.Duration
+		expectedErr string
+	}{
+		{
+			name:        "hook timeout",
+			hook:        api.Hook{Name: "slow", Run: "sleep 30", Timeout: "100ms"},
+			expectedErr: "pre-test hook 'slow' timed out: hook timeout of 100ms exceeded",
+		},
+		{
+			name:        "test case timeout",
+			hook:        api.Hook{Run: "sleep 30", Timeout: "1m"},
+			ctxTimeout:  100 * time.Millisecond,
+			expectedErr: "pre-test hook timed out: test case timeout of 100ms exceeded",
+		},
+		{
+			name: "hook within its timeout",
+			hook: api.Hook{Run: "true", Timeout: "1m"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := testexecutionUtils.WithTimeout(context.Background(), tt.ctxTimeout, "test case")
+			defer cancel()
+
+			result, err := exec.executeHook(ctx, tt.hook, "pre-test", api.Inputs{}, nil, nil)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				require.NoError(t, result.Error)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, tt.expectedErr, err.Error())
+			assert.Equal(t, tt.expectedErr, result.Error.Error())
+		})
+	}
+}
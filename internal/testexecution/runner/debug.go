@@ -18,7 +18,6 @@ package runner
 
 import (
 	"github.com/crossplane-contrib/xprin/internal/api"
-	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
 	"github.com/crossplane-contrib/xprin/internal/utils"
 )
 
@@ -42,6 +41,26 @@ func (r *Runner) debugPrintPatches(patches api.Patches) {
 		if patches.ConnectionSecretNamespace != "" {
 			utils.DebugPrintf("  - Connection Secret Namespace: %s\n", patches.ConnectionSecretNamespace)
 		}
+
+		if len(patches.Set) > 0 {
+			utils.DebugPrintf("  - Set:\n")
+
+			for _, set := range patches.Set {
+				utils.DebugPrintf("    - %s: %v\n", set.Path, set.Value)
+			}
+		}
+
+		if len(patches.Merge) > 0 {
+			utils.DebugPrintf("  - Merge: %d patches\n", len(patches.Merge))
+		}
+
+		if len(patches.JSONPatch) > 0 {
+			utils.DebugPrintf("  - JSON Patch:\n")
+
+			for _, op := range patches.JSONPatch {
+				utils.DebugPrintf("    - %s %s\n", op.Op, op.Path)
+			}
+		}
 	}
 }
 
@@ -53,10 +72,22 @@ func (r *Runner) debugPrintInputs(inputs api.Inputs) {
 		utils.DebugPrintf("  - XR: %s\n", inputs.XR)
 	}
 
+	if inputs.Inline.XR != nil {
+		utils.DebugPrintf("  - XR: (inline)\n")
+	}
+
+	if inputs.XRFromXRD != nil {
+		utils.DebugPrintf("  - XR from XRD: %s\n", inputs.XRFromXRD.XRD)
+	}
+
 	if inputs.Claim != "" {
 		utils.DebugPrintf("  - Claim: %s\n", inputs.Claim)
 	}
 
+	if inputs.Inline.Claim != nil {
+		utils.DebugPrintf("  - Claim: (inline)\n")
+	}
+
 	if inputs.Composition != "" {
 		utils.DebugPrintf("  - Composition: %s\n", inputs.Composition)
 	}
@@ -73,12 +104,16 @@ func (r *Runner) debugPrintInputs(inputs api.Inputs) {
 		}
 	}
 
-	if len(inputs.ContextFiles) > 0 {
+	if len(inputs.ContextFiles) > 0 || len(inputs.Inline.ContextFiles) > 0 {
 		utils.DebugPrintf("  - Context Files:\n")
 
 		for contextKey, contextFile := range inputs.ContextFiles {
 			utils.DebugPrintf("      %s: %s\n", contextKey, contextFile)
 		}
+
+		for contextKey := range inputs.Inline.ContextFiles {
+			utils.DebugPrintf("      %s: (inline)\n", contextKey)
+		}
 	}
 
 	if len(inputs.ContextValues) > 0 {
@@ -93,10 +128,18 @@ func (r *Runner) debugPrintInputs(inputs api.Inputs) {
 		utils.DebugPrintf("  - Observed Resources: %s\n", inputs.ObservedResources)
 	}
 
+	if inputs.Inline.ObservedResources != nil {
+		utils.DebugPrintf("  - Observed Resources: (inline, %d documents)\n", len(inputs.Inline.ObservedResources))
+	}
+
 	if inputs.ExtraResources != "" {
 		utils.DebugPrintf("  - Extra Resources: %s\n", inputs.ExtraResources)
 	}
 
+	if inputs.Inline.ExtraResources != nil {
+		utils.DebugPrintf("  - Extra Resources: (inline, %d documents)\n", len(inputs.Inline.ExtraResources))
+	}
+
 	if inputs.FunctionCredentials != "" {
 		utils.DebugPrintf("  - Function Credentials: %s\n", inputs.FunctionCredentials)
 	}
@@ -114,9 +157,7 @@ func (r *Runner) debugPrintHooks(hooks api.Hooks) {
 				if hook.Name != "" {
 					utils.DebugPrintf("    - name: %s\n", hook.Name)
 				}
-				// Show processed hook command instead of raw with placeholders
-				processedCommand := testexecutionUtils.RestoreTemplateVars(hook.Run)
-				utils.DebugPrintf("      run: %s\n", processedCommand)
+				utils.DebugPrintf("      run: %s\n", hook.Run)
 			}
 		}
 
@@ -127,9 +168,7 @@ func (r *Runner) debugPrintHooks(hooks api.Hooks) {
 				if hook.Name != "" {
 					utils.DebugPrintf("    - name: %s\n", hook.Name)
 				}
-				// Show processed hook command instead of raw with placeholders
-				processedCommand := testexecutionUtils.RestoreTemplateVars(hook.Run)
-				utils.DebugPrintf("      run: %s\n", processedCommand)
+				utils.DebugPrintf("      run: %s\n", hook.Run)
 			}
 		}
 	}
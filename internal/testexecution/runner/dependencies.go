@@ -0,0 +1,252 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"sigs.k8s.io/yaml"
+)
+
+// testsReferencePattern matches references to other test cases in templates: .Tests.<id> and index .Tests "<id>".
+var testsReferencePattern = regexp.MustCompile(`\.Tests\.([A-Za-z0-9_]+)|index\s+\.Tests\s+\\?"([A-Za-z0-9_-]+)\\?"`)
+
+// TestReference is a reference to another test case in a template, as .Tests.<id> or index .Tests "<id>".
+type TestReference struct {
+	ID    string
+	Start int // Byte offset of the ID in the text
+	End   int // Byte offset of the end of the ID in the text
+}
+
+// FindTestReferences returns the references to other test cases in a text.
+func FindTestReferences(text string) []TestReference {
+	var references []TestReference
+
+	for _, match := range testsReferencePattern.FindAllStringSubmatchIndex(text, -1) {
+		for group := 1; group <= 2; group++ {
+			if start, end := match[2*group], match[2*group+1]; start >= 0 {
+				references = append(references, TestReference{ID: text[start:end], Start: start, End: end})
+			}
+		}
+	}
+
+	return references
+}
+
+// plannedTestCase is a test case with the IDs of the test cases it depends on.
+type plannedTestCase struct {
+	testCase api.TestCase
+	needs    []string // IDs of the test cases that must pass before this one runs
+	cycle    []string // Dependency cycle this test case is part of, if any
+}
+
+// testCaseDependencies returns the IDs of the test cases a test case depends on: the ones listed in needs,
+// and the ones referenced as .Tests.<id> in its templates (including the ones inherited from common).
+// Only IDs of existing test cases are returned; a test case never depends on itself.
+func (r *Runner) testCaseDependencies(testCase api.TestCase, ids map[string]bool) []string {
+	var needs []string
+
+	add := func(id string) {
+		if id != "" && id != testCase.ID && ids[id] && !slices.Contains(needs, id) {
+			needs = append(needs, id)
+		}
+	}
+
+	for _, id := range testCase.Needs {
+		add(id)
+	}
+
+	merged := testCase
+	if r.testSuiteSpec.HasCommon() {
+		merged.MergeCommon(r.testSuiteSpec.Common)
+	}
+
+	yamlData, err := yaml.Marshal(merged)
+	if err != nil {
+		return needs
+	}
+
+	for _, match := range testsReferencePattern.FindAllStringSubmatch(string(yamlData), -1) {
+		add(match[1])
+		add(match[2])
+	}
+
+	return needs
+}
+
+// TestCaseDependencies returns the IDs of the test cases that each test case of the testsuite depends on, in file order:
+// the ones listed in needs and the ones referenced as .Tests.<id> in its templates, as RunTests orders them.
+func (r *Runner) TestCaseDependencies() [][]string {
+	ids := make(map[string]bool)
+
+	for _, testCase := range r.testSuiteSpec.Tests {
+		if testCase.ID != "" {
+			ids[testCase.ID] = true
+		}
+	}
+
+	dependencies := make([][]string, len(r.testSuiteSpec.Tests))
+	for i, testCase := range r.testSuiteSpec.Tests {
+		dependencies[i] = r.testCaseDependencies(testCase, ids)
+	}
+
+	return dependencies
+}
+
+// selectedTestCases returns the test cases to run: the ones of Options.TestCases for the testsuite file, with the test
+// cases they depend on, in file order, or all of them if the testsuite file has no selected test cases.
+func (r *Runner) selectedTestCases() []api.TestCase {
+	selected, ok := r.TestCases[r.testSuiteFile]
+	if !ok {
+		return r.testSuiteSpec.Tests
+	}
+
+	indexes := make(map[string]int)
+
+	for i, testCase := range r.testSuiteSpec.Tests {
+		if testCase.ID != "" {
+			indexes[testCase.ID] = i
+		}
+	}
+
+	dependencies := r.TestCaseDependencies()
+	keep := make([]bool, len(r.testSuiteSpec.Tests))
+
+	var add func(i int)
+
+	add = func(i int) {
+		if i < 0 || i >= len(keep) || keep[i] {
+			return
+		}
+
+		keep[i] = true
+
+		for _, id := range dependencies[i] {
+			add(indexes[id])
+		}
+	}
+
+	for _, i := range selected {
+		add(i)
+	}
+
+	var testCases []api.TestCase
+
+	for i, testCase := range r.testSuiteSpec.Tests {
+		if keep[i] {
+			testCases = append(testCases, testCase)
+		}
+	}
+
+	return testCases
+}
+
+// planTestCases orders the test cases so that every test case runs after the test cases it depends on.
+// Test cases keep their file order unless a dependency requires otherwise.
+// Test cases that are part of a dependency cycle are returned with their cycle.
+func (r *Runner) planTestCases(testCases []api.TestCase) []plannedTestCase {
+	ids := make(map[string]bool)
+
+	var orderedIDs []string
+
+	for _, testCase := range testCases {
+		if testCase.ID != "" {
+			ids[testCase.ID] = true
+			orderedIDs = append(orderedIDs, testCase.ID)
+		}
+	}
+
+	planned := make([]plannedTestCase, len(testCases))
+	edges := make(map[string][]string)
+
+	for i, testCase := range testCases {
+		planned[i] = plannedTestCase{testCase: testCase, needs: r.testCaseDependencies(testCase, ids)}
+		if testCase.ID != "" {
+			edges[testCase.ID] = planned[i].needs
+		}
+	}
+
+	for _, cycle := range api.FindCycles(orderedIDs, edges) {
+		for i := range planned {
+			if planned[i].cycle == nil && slices.Contains(cycle, planned[i].testCase.ID) {
+				planned[i].cycle = cycle
+			}
+		}
+	}
+
+	// Stable topological sort: repeatedly pick the first test case (in file order) whose dependencies have all been picked.
+	// Test cases in a cycle are always ready, since they fail without running.
+	var (
+		ordered = make([]plannedTestCase, 0, len(planned))
+		picked  = make(map[string]bool)
+		done    = make([]bool, len(planned))
+	)
+
+	for len(ordered) < len(planned) {
+		for i, p := range planned {
+			if done[i] || (p.cycle == nil && slices.ContainsFunc(p.needs, func(id string) bool { return !picked[id] })) {
+				continue
+			}
+
+			done[i] = true
+			picked[p.testCase.ID] = true
+			ordered = append(ordered, p)
+
+			break
+		}
+	}
+
+	return ordered
+}
+
+// dependencySkipReason returns why a test case must be skipped because of the results of the test cases it depends on,
+// or an empty string if all of them passed.
+func dependencySkipReason(needs []string, completed map[string]*engine.TestCaseResult) string {
+	for _, id := range needs {
+		result, ok := completed[id]
+		if !ok {
+			return fmt.Sprintf("needs test case '%s' which did not run", id)
+		}
+
+		if result.ExpectedFailure {
+			return fmt.Sprintf("needs test case '%s' which failed", id)
+		}
+
+		switch result.Status {
+		case engine.StatusPass():
+			continue
+		case engine.StatusSkip():
+			return fmt.Sprintf("needs test case '%s' which was skipped", id)
+		case engine.StatusError():
+			return fmt.Sprintf("needs test case '%s' which could not run", id)
+		default:
+			return fmt.Sprintf("needs test case '%s' which failed", id)
+		}
+	}
+
+	return ""
+}
+
+// dependencyCycleError returns the error for a test case that is part of a dependency cycle.
+func dependencyCycleError(cycle []string) error {
+	return fmt.Errorf("dependency cycle detected: %s", strings.Join(cycle, " -> "))
+}
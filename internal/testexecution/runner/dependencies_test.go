@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestPlanTestCases(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          *api.TestSuiteSpec
+		expectedOrder []string
+		expectedNeeds map[string][]string
+		expectedCycle map[string][]string
+	}{
+		{
+			name: "file order is kept without dependencies",
+			spec: &api.TestSuiteSpec{Tests: []api.TestCase{
+				{Name: "a", ID: "a"},
+				{Name: "b"},
+				{Name: "c", ID: "c"},
+			}},
+			expectedOrder: []string{"a", "b", "c"},
+		},
+		{
+			name: "explicit needs move dependencies first",
+			spec: &api.TestSuiteSpec{Tests: []api.TestCase{
+				{Name: "consumer", Needs: []string{"producer"}},
+				{Name: "other", ID: "other"},
+				{Name: "producer", ID: "producer"},
+			}},
+			expectedOrder: []string{"other", "producer", "consumer"},
+			expectedNeeds: map[string][]string{"consumer": {"producer"}},
+		},
+		{
+			name: "dependencies inferred from template references",
+			spec: &api.TestSuiteSpec{
+				Common: api.Common{Hooks: api.Hooks{PostTest: []api.Hook{
+					{Run: `echo {{ index .Tests "base-xr" .Outputs.XR }}`},
+				}}},
+				Tests: []api.TestCase{
+					{Name: "consumer", Inputs: api.Inputs{
+						XR: "{{ .Tests.producer.Outputs.XR }}",
+					}},
+					{Name: "producer", ID: "producer"},
+					{Name: "base", ID: "base-xr"},
+				},
+			},
+			expectedOrder: []string{"base", "producer", "consumer"},
+			expectedNeeds: map[string][]string{"consumer": {"base-xr", "producer"}, "producer": {"base-xr"}},
+		},
+		{
+			name: "unknown and self references are ignored",
+			spec: &api.TestSuiteSpec{Tests: []api.TestCase{
+				{Name: "a", ID: "a", Inputs: api.Inputs{
+					XR: "{{ .Tests.a.Outputs.XR }}{{ .Tests.missing.Outputs.XR }}",
+				}},
+			}},
+			expectedOrder: []string{"a"},
+		},
+		{
+			name: "inferred cycles are reported",
+			spec: &api.TestSuiteSpec{Tests: []api.TestCase{
+				{Name: "dependent", Needs: []string{"b"}},
+				{Name: "a", ID: "a", Inputs: api.Inputs{XR: "{{ .Tests.b.Outputs.XR }}"}},
+				{Name: "b", ID: "b", Needs: []string{"a"}},
+			}},
+			expectedOrder: []string{"a", "b", "dependent"},
+			expectedNeeds: map[string][]string{"dependent": {"b"}, "a": {"b"}, "b": {"a"}},
+			expectedCycle: map[string][]string{"a": {"a", "b", "a"}, "b": {"a", "b", "a"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, tt.spec)
+
+			planned := runner.planTestCases(tt.spec.Tests)
+
+			var order []string
+
+			for _, p := range planned {
+				order = append(order, p.testCase.Name)
+				assert.Equal(t, tt.expectedNeeds[p.testCase.Name], p.needs, "needs of %s", p.testCase.Name)
+				assert.Equal(t, tt.expectedCycle[p.testCase.Name], p.cycle, "cycle of %s", p.testCase.Name)
+			}
+
+			assert.Equal(t, tt.expectedOrder, order)
+		})
+	}
+}
+
+func TestFindTestReferences(t *testing.T) {
+	text := `echo {{ .Tests.setup.Outputs.XR }} {{ index .Tests "with-dash" }}`
+
+	assert.Equal(t, []TestReference{
+		{ID: "setup", Start: 15, End: 20},
+		{ID: "with-dash", Start: 52, End: 61},
+	}, FindTestReferences(text))
+	assert.Empty(t, FindTestReferences("echo {{ .Vars.region }}"))
+}
+
+func TestRunTests_Dependencies(t *testing.T) {
+	var buf bytes.Buffer
+
+	suite := &api.TestSuiteSpec{Tests: []api.TestCase{
+		{Name: "downstream", ID: "downstream", Needs: []string{"upstream"}},
+		{Name: "transitive", Needs: []string{"downstream"}},
+		{Name: "independent", Needs: []string{"ok"}},
+		{Name: "upstream", ID: "upstream"},
+		{Name: "ok", ID: "ok"},
+	}}
+
+	runner := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, suite)
+	runner.output = &buf
+
+	var ran []string
+
+	runner.runTestCaseFunc = func(tc api.TestCase) *engine.TestCaseResult {
+		ran = append(ran, tc.Name)
+
+		result := engine.NewTestCaseResult(tc.Name, tc.ID, false, false, false, false, false)
+		if tc.Name == "upstream" {
+			return result.Fail(errors.New("upstream failed"))
+		}
+
+		return result.Complete()
+	}
+
+	err := runner.RunTests(context.Background())
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"upstream", "ok", "independent"}, ran)
+
+	out := buf.String()
+	assert.Contains(t, out, "--- FAIL: upstream")
+	assert.Contains(t, out, "--- SKIP: downstream")
+	assert.Contains(t, out, "    needs test case 'upstream' which failed\n")
+	assert.Contains(t, out, "--- SKIP: transitive")
+	assert.Contains(t, out, "    needs test case 'downstream' which was skipped\n")
+	assert.NotContains(t, out, "independent")
+}
+
+func TestRunTests_DependencyCycle(t *testing.T) {
+	var buf bytes.Buffer
+
+	suite := &api.TestSuiteSpec{Tests: []api.TestCase{
+		{Name: "a", ID: "a", Inputs: api.Inputs{XR: "{{ .Tests.b.Outputs.XR }}"}},
+		{Name: "b", ID: "b", Inputs: api.Inputs{XR: "{{ .Tests.a.Outputs.XR }}"}},
+	}}
+
+	runner := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, suite)
+	runner.output = &buf
+	runner.runTestCaseFunc = func(_ api.TestCase) *engine.TestCaseResult {
+		t.Fatal("test cases in a dependency cycle must not run")
+		return nil
+	}
+
+	require.Error(t, runner.RunTests(context.Background()))
+	assert.Contains(t, buf.String(), "--- ERROR: a")
+	assert.Contains(t, buf.String(), "dependency cycle detected: a -> b -> a")
+}
+
+func TestRunTests_SelectedTestCases(t *testing.T) {
+	var buf bytes.Buffer
+
+	suite := &api.TestSuiteSpec{Tests: []api.TestCase{
+		{Name: "upstream", ID: "upstream"},
+		{Name: "unrelated", ID: "unrelated"},
+		{Name: "downstream", ID: "downstream", Inputs: api.Inputs{XR: "{{ .Tests.upstream.Outputs.XR }}"}},
+		{Name: "changed", Needs: []string{"downstream"}},
+		{Name: "other"},
+	}}
+
+	options := &testexecutionUtils.Options{TestCases: map[string][]int{testSuiteFile: {3}}}
+	runner := NewRunner(options, testSuiteFile, suite)
+	runner.output = &buf
+
+	var ran []string
+
+	runner.runTestCaseFunc = func(tc api.TestCase) *engine.TestCaseResult {
+		ran = append(ran, tc.Name)
+		return engine.NewTestCaseResult(tc.Name, tc.ID, false, false, false, false, false).Complete()
+	}
+
+	require.NoError(t, runner.RunTests(context.Background()))
+
+	assert.Equal(t, []string{"upstream", "downstream", "changed"}, ran, "the selected test case runs with the test cases it depends on")
+	assert.NotContains(t, buf.String(), "unrelated")
+	assert.NotContains(t, buf.String(), "other")
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+)
+
+// templateFuncs returns the functions available in test suite templates and hook commands.
+// Relative file paths are resolved relative to the testsuite file.
+func (r *Runner) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		// String helpers
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+		"join":       func(sep string, elems []string) string { return strings.Join(elems, sep) },
+		"quote":      func(s string) string { return fmt.Sprintf("%q", s) },
+
+		// Path helpers
+		"base":     filepath.Base,
+		"dir":      filepath.Dir,
+		"ext":      filepath.Ext,
+		"clean":    filepath.Clean,
+		"pathJoin": filepath.Join,
+
+		// File and YAML helpers
+		"readFile": r.readFile,
+		"fromYaml": fromYaml,
+		"toYaml":   toYaml,
+		"getField": r.getField,
+
+		// Default and required values
+		"default":  defaultValue,
+		"required": required,
+	}
+}
+
+// readFile returns the content of a file.
+func (r *Runner) readFile(path string) (string, error) {
+	absPath, err := testexecutionUtils.ExpandPathRelativeToTestSuiteFile(r.testSuiteFile, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand path %s: %w", path, err)
+	}
+
+	data, err := afero.ReadFile(r.fs, absPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	return string(data), nil
+}
+
+// getField returns the value of a field of the resource in a YAML file, given its Crossplane fieldpath.
+// Only the first resource of the file is read.
+func (r *Runner) getField(path, fieldPath string) (any, error) {
+	content, err := r.readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := fromYaml(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file %s: %w", path, err)
+	}
+
+	value, err := fieldpath.Pave(obj).GetValue(fieldPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get field %s from file %s: %w", fieldPath, path, err)
+	}
+
+	return value, nil
+}
+
+// fromYaml parses a YAML object.
+func fromYaml(content string) (map[string]any, error) {
+	obj := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(content), &obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// toYaml marshals a value to YAML, without the trailing newline.
+func toYaml(value any) (string, error) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// defaultValue returns the value, or the default value if the value is empty.
+func defaultValue(defaultVal, value any) any {
+	if isEmpty(value) {
+		return defaultVal
+	}
+
+	return value
+}
+
+// required returns the value, or an error with the given message if the value is empty.
+func required(message string, value any) (any, error) {
+	if isEmpty(value) {
+		return nil, errors.New(message)
+	}
+
+	return value, nil
+}
+
+// isEmpty returns true if a value is nil or the zero value of its type, or an empty slice or map.
+func isEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() { //nolint:exhaustive // all other kinds are only empty when they are the zero value
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/suite/bucket.yaml", []byte("apiVersion: s3.aws.upbound.io/v1beta1\nkind: Bucket\nmetadata:\n  name: my-bucket\nspec:\n  forProvider:\n    region: us-east-1\n    tags:\n    - a\n    - b\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/suite/name.txt", []byte("my-name\n"), 0o644))
+
+	runner := &Runner{fs: fs, testSuiteFile: "/suite/suite_xprin.yaml"}
+	inputs := api.Inputs{XR: "/path/to/xr.yaml", Composition: "composition.yaml"}
+	outputs := &engine.Outputs{Rendered: map[string]string{"Bucket/my-bucket": "/suite/bucket.yaml"}}
+
+	tests := []struct {
+		name      string
+		template  string
+		expected  string
+		expectErr string
+	}{
+		{name: "lower", template: `{{ "ABC" | lower }}`, expected: "abc"},
+		{name: "upper", template: `{{ "abc" | upper }}`, expected: "ABC"},
+		{name: "trim", template: `{{ "  abc  " | trim }}`, expected: "abc"},
+		{name: "trimPrefix", template: `{{ "xr-test" | trimPrefix "xr-" }}`, expected: "test"},
+		{name: "trimSuffix", template: `{{ .Inputs.Composition | trimSuffix ".yaml" }}`, expected: "composition"},
+		{name: "replace", template: `{{ "a-b-c" | replace "-" "_" }}`, expected: "a_b_c"},
+		{name: "contains", template: `{{ if .Inputs.XR | contains "xr" }}yes{{ end }}`, expected: "yes"},
+		{name: "hasPrefix", template: `{{ hasPrefix "/path" .Inputs.XR }}`, expected: "true"},
+		{name: "hasSuffix", template: `{{ hasSuffix ".json" .Inputs.XR }}`, expected: "false"},
+		{name: "split and join", template: `{{ "a,b,c" | split "," | join " " }}`, expected: "a b c"},
+		{name: "quote", template: `{{ "it's \"quoted\"" | quote }}`, expected: `"it's \"quoted\""`},
+		{name: "base", template: `{{ .Inputs.XR | base }}`, expected: "xr.yaml"},
+		{name: "dir", template: `{{ .Inputs.XR | dir }}`, expected: "/path/to"},
+		{name: "ext", template: `{{ .Inputs.XR | ext }}`, expected: ".yaml"},
+		{name: "clean", template: `{{ "a/../b/./c" | clean }}`, expected: "b/c"},
+		{name: "pathJoin", template: `{{ pathJoin "a" "b" "c.yaml" }}`, expected: "a/b/c.yaml"},
+		{name: "readFile relative to the testsuite file", template: `{{ readFile "name.txt" | trim }}`, expected: "my-name"},
+		{name: "readFile missing file", template: `{{ readFile "missing.txt" }}`, expectErr: "failed to read file missing.txt"},
+		{name: "fromYaml", template: `{{ (readFile "bucket.yaml" | fromYaml).metadata.name }}`, expected: "my-bucket"},
+		{name: "toYaml", template: `{{ (readFile "bucket.yaml" | fromYaml).spec.forProvider.tags | toYaml }}`, expected: "- a\n- b"},
+		{name: "getField", template: `{{ getField (index .Outputs.Rendered "Bucket/my-bucket") "spec.forProvider.region" }}`, expected: "us-east-1"},
+		{name: "getField array element", template: `{{ getField "bucket.yaml" "spec.forProvider.tags[1]" }}`, expected: "b"},
+		{name: "getField missing field", template: `{{ getField "bucket.yaml" "spec.missing" }}`, expectErr: "failed to get field spec.missing from file bucket.yaml"},
+		{name: "default with empty value", template: `{{ .Inputs.Claim | default "claim.yaml" }}`, expected: "claim.yaml"},
+		{name: "default with value", template: `{{ .Inputs.XR | default "other.yaml" }}`, expected: "/path/to/xr.yaml"},
+		{name: "required with value", template: `{{ .Inputs.XR | required "xr is required" }}`, expected: "/path/to/xr.yaml"},
+		{name: "required with empty value", template: `{{ .Inputs.Claim | required "claim is required" }}`, expectErr: "claim is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateContext := newTemplateContext(nil, nil, nil, inputs, outputs, nil)
+
+			got, err := runner.renderTemplate(tt.template, templateContext, "test")
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestTemplateFuncs_InHooks(t *testing.T) {
+	renderTemplate := (&Runner{}).renderTemplate
+	hookExecutor := newHookExecutor(nil, nil, nil, false, nil, renderTemplate)
+
+	hook := api.Hook{Run: "echo {{ .Inputs.XR | base | upper }}"}
+
+	finalCommand, _, err := hookExecutor.processHookTemplateVariables(hook, api.Inputs{XR: "/path/to/xr.yaml"}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "echo XR.YAML", finalCommand)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package runner
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os/exec"
@@ -31,45 +32,47 @@ import (
 // hookExecutor handles execution of hooks.
 type hookExecutor struct {
 	repositories   map[string]string
+	vars           map[string]string
+	env            map[string]string
 	debug          bool
-	runCommand     func(name string, args ...string) ([]byte, error)
+	runCommand     func(ctx context.Context, name string, args ...string) ([]byte, error)
 	renderTemplate func(content string, templateContext *templateContext, templateName string) (string, error)
 }
 
 // newHookExecutor creates a new hook executor.
 func newHookExecutor(
 	repositories map[string]string,
+	vars map[string]string,
+	env map[string]string,
 	debug bool,
-	runCommand func(name string, args ...string) ([]byte, error),
+	runCommand func(ctx context.Context, name string, args ...string) ([]byte, error),
 	renderTemplate func(content string, templateContext *templateContext, templateName string) (string, error),
 ) *hookExecutor {
 	return &hookExecutor{
 		repositories:   repositories,
+		vars:           vars,
+		env:            env,
 		debug:          debug,
 		runCommand:     runCommand,
 		renderTemplate: renderTemplate,
 	}
 }
 
-// processHookTemplateVariables converts the hook command (possibly with placeholders) into the executable form
-// and the form to store in HookResult. The three command forms are:
-//   - command with placeholders: hook.Run as in spec (e.g. __OPEN__.Repositories.myrepo__CLOSE__)
-//   - command with template vars: restored form (e.g. {{ .Repositories.myrepo }}) — stored in HookResult
-//   - final command: template vars rendered (e.g. /path/to/repo) — what we execute
+// processHookTemplateVariables renders the hook command. It returns the command to execute, and the command
+// with its template variables (as in the spec) to store in HookResult.
 func (e *hookExecutor) processHookTemplateVariables(hook api.Hook, inputs api.Inputs, outputs *engine.Outputs, tests map[string]*engine.TestCaseResult) (finalCommand, commandWithTemplateVars string, err error) {
-	if !strings.Contains(hook.Run, testexecutionUtils.PlaceholderOpen) {
+	if !testexecutionUtils.HasTemplate(hook.Run) {
 		return hook.Run, hook.Run, nil
 	}
 
-	commandWithTemplateVars = testexecutionUtils.RestoreTemplateVars(hook.Run)
-	context := newTemplateContext(e.repositories, inputs, outputs, tests)
+	context := newTemplateContext(e.repositories, e.vars, e.env, inputs, outputs, tests)
 
-	finalCommand, err = e.renderTemplate(commandWithTemplateVars, context, "hook")
+	finalCommand, err = e.renderTemplate(hook.Run, context, "hook")
 	if err != nil {
 		return "", "", err
 	}
 
-	return finalCommand, commandWithTemplateVars, nil
+	return finalCommand, hook.Run, nil
 }
 
 // buildHookFailureMessage builds the error message for a failed hook (exit code, optional output, hook name/command).
@@ -97,17 +100,13 @@ func buildHookFailureMessage(hookType, hookName, commandWithTemplateVars string,
 }
 
 // executeHook runs a single hook: prepare command (processHookTemplateVariables), run, return result. On template or run error returns the HookResult (for the failed hook) and a non-nil error.
-func (e *hookExecutor) executeHook(hook api.Hook, hookType string, inputs api.Inputs, outputs *engine.Outputs, tests map[string]*engine.TestCaseResult) (engine.HookResult, error) {
+// The hook is stopped when its timeout expires or ctx is done.
+func (e *hookExecutor) executeHook(ctx context.Context, hook api.Hook, hookType string, inputs api.Inputs, outputs *engine.Outputs, tests map[string]*engine.TestCaseResult) (engine.HookResult, error) {
 	finalCommand, commandWithTemplateVars, err := e.processHookTemplateVariables(hook, inputs, outputs, tests)
 	if err != nil {
 		templateErr := fmt.Errorf("failed to render hook template: %w", err)
 
-		commandWithTemplateVarsForResult := hook.Run
-		if strings.Contains(hook.Run, testexecutionUtils.PlaceholderOpen) {
-			commandWithTemplateVarsForResult = testexecutionUtils.RestoreTemplateVars(hook.Run)
-		}
-
-		hookResult := engine.NewHookResult(hook.Name, commandWithTemplateVarsForResult, nil, templateErr)
+		hookResult := engine.NewHookResult(hook.Name, hook.Run, nil, templateErr)
 
 		var errorMsg string
 		if hook.Name != "" {
@@ -127,7 +126,19 @@ func (e *hookExecutor) executeHook(hook api.Hook, hookType string, inputs api.In
 		}
 	}
 
-	output, err := e.runCommand("sh", "-c", finalCommand)
+	hookCtx, cancel := testexecutionUtils.WithTimeout(ctx, hook.TimeoutDuration(), "hook")
+	defer cancel()
+
+	output, err := e.runCommand(hookCtx, "sh", "-c", finalCommand)
+
+	stage := hookType + " hook"
+	if hook.Name != "" {
+		stage = fmt.Sprintf("%s hook '%s'", hookType, hook.Name)
+	}
+
+	if stageErr := testexecutionUtils.StageError(hookCtx, stage); stageErr != nil {
+		return engine.NewHookResult(hook.Name, commandWithTemplateVars, output, stageErr), stageErr
+	}
 
 	hookResult := engine.NewHookResult(hook.Name, commandWithTemplateVars, output, err)
 	if err != nil {
@@ -147,10 +158,10 @@ func (e *hookExecutor) executeHook(hook api.Hook, hookType string, inputs api.In
 }
 
 // executeHooks runs each hook in order via executeHook; on template or run error returns with results so far and a formatted error.
-func (e *hookExecutor) executeHooks(hooks []api.Hook, hookType string, inputs api.Inputs, outputs *engine.Outputs, tests map[string]*engine.TestCaseResult) ([]engine.HookResult, error) {
+func (e *hookExecutor) executeHooks(ctx context.Context, hooks []api.Hook, hookType string, inputs api.Inputs, outputs *engine.Outputs, tests map[string]*engine.TestCaseResult) ([]engine.HookResult, error) {
 	hookResults := make([]engine.HookResult, 0, len(hooks))
 	for _, hook := range hooks {
-		result, err := e.executeHook(hook, hookType, inputs, outputs, tests)
+		result, err := e.executeHook(ctx, hook, hookType, inputs, outputs, tests)
 
 		hookResults = append(hookResults, result)
 		if err != nil {
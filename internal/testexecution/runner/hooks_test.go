@@ -17,6 +17,7 @@ limitations under the License.
 package runner
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os/exec"
@@ -55,7 +56,7 @@ func TestExecuteHooks(t *testing.T) {
 	// Mock the runCommand function
 	var executedCommands []string
 
-	runCommand := func(_ string, args ...string) ([]byte, error) {
+	runCommand := func(_ context.Context, _ string, args ...string) ([]byte, error) {
 		// Extract just the command part (skip the shell and -c flags)
 		if len(args) >= 2 && args[0] == "-c" {
 			executedCommands = append(executedCommands, args[1])
@@ -64,14 +65,14 @@ func TestExecuteHooks(t *testing.T) {
 		return []byte("mock output"), nil
 	}
 
-	// Mock renderTemplate function (should not be called for pre-test hooks without placeholders)
+	// Mock renderTemplate function (should not be called for pre-test hooks without templates)
 	renderTemplate := func(content string, _ *templateContext, _ string) (string, error) {
 		return content, nil
 	}
 
 	// Execute hooks (pre-test hooks with outputs=nil)
-	hookExecutor := newHookExecutor(repositories, false, runCommand, renderTemplate)
-	results, err := hookExecutor.executeHooks(hooks, "test", inputs, nil, map[string]*engine.TestCaseResult{})
+	hookExecutor := newHookExecutor(repositories, nil, nil, false, runCommand, renderTemplate)
+	results, err := hookExecutor.executeHooks(context.Background(), hooks, "test", inputs, nil, map[string]*engine.TestCaseResult{})
 	require.NoError(t, err)
 	assert.Len(t, results, 2)
 
@@ -94,7 +95,7 @@ func TestExecuteHooks_Order(t *testing.T) {
 	// Mock the runCommand function to capture execution order
 	var executionOrder []string
 
-	runCommand := func(_ string, args ...string) ([]byte, error) {
+	runCommand := func(_ context.Context, _ string, args ...string) ([]byte, error) {
 		// Extract just the command part (skip the shell and -c flags)
 		if len(args) >= 2 && args[0] == "-c" {
 			executionOrder = append(executionOrder, args[1])
@@ -108,8 +109,8 @@ func TestExecuteHooks_Order(t *testing.T) {
 	}
 
 	// Execute hooks
-	hookExecutor := newHookExecutor(nil, false, runCommand, renderTemplate)
-	_, err := hookExecutor.executeHooks(hooks, "test", api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
+	hookExecutor := newHookExecutor(nil, nil, nil, false, runCommand, renderTemplate)
+	_, err := hookExecutor.executeHooks(context.Background(), hooks, "test", api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
 	require.NoError(t, err)
 
 	// Verify hooks were executed in order
@@ -136,7 +137,7 @@ func TestExecuteHooks_HookFailure(t *testing.T) {
 		}
 
 		// Mock the runCommand function to return an error
-		runCommand := func(_ string, _ ...string) ([]byte, error) {
+		runCommand := func(_ context.Context, _ string, _ ...string) ([]byte, error) {
 			return []byte("command failed"), errors.New("exit status 1")
 		}
 
@@ -145,8 +146,8 @@ func TestExecuteHooks_HookFailure(t *testing.T) {
 		}
 
 		// Execute hooks - should fail
-		hookExecutor := newHookExecutor(nil, false, runCommand, renderTemplate)
-		_, err := hookExecutor.executeHooks(hooks, "test", api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
+		hookExecutor := newHookExecutor(nil, nil, nil, false, runCommand, renderTemplate)
+		_, err := hookExecutor.executeHooks(context.Background(), hooks, "test", api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
 		require.Error(t, err)
 
 		// Validate complete error message format
@@ -161,7 +162,7 @@ func TestExecuteHooks_HookFailure(t *testing.T) {
 		}
 
 		// Mock the runCommand function to return an error
-		runCommand := func(_ string, _ ...string) ([]byte, error) {
+		runCommand := func(_ context.Context, _ string, _ ...string) ([]byte, error) {
 			return []byte("another error"), errors.New("exit status 2")
 		}
 
@@ -170,8 +171,8 @@ func TestExecuteHooks_HookFailure(t *testing.T) {
 		}
 
 		// Execute hooks - should fail
-		hookExecutor := newHookExecutor(nil, false, runCommand, renderTemplate)
-		_, err := hookExecutor.executeHooks(hooks, "pre-test", api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
+		hookExecutor := newHookExecutor(nil, nil, nil, false, runCommand, renderTemplate)
+		_, err := hookExecutor.executeHooks(context.Background(), hooks, "pre-test", api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
 		require.Error(t, err)
 
 		// Validate error message format (should contain the key components)
@@ -199,14 +200,14 @@ func TestExecuteHooks_PostTestHooks(t *testing.T) {
 
 	// Create hooks with template variables (post-test hooks)
 	hooks := []api.Hook{
-		{Name: "post-hook-1", Run: fmt.Sprintf("echo 'Repository: %s.Repositories.myrepo%s'", testexecutionUtils.PlaceholderOpen, testexecutionUtils.PlaceholderClose)},
-		{Name: "post-hook-2", Run: fmt.Sprintf("echo 'XR: %s.Outputs.XR%s'", testexecutionUtils.PlaceholderOpen, testexecutionUtils.PlaceholderClose)},
+		{Name: "post-hook-1", Run: "echo 'Repository: {{ .Repositories.myrepo }}'"},
+		{Name: "post-hook-2", Run: "echo 'XR: {{ .Outputs.XR }}'"},
 	}
 
 	// Mock the runCommand function
 	var executedCommands []string
 
-	runCommand := func(_ string, args ...string) ([]byte, error) {
+	runCommand := func(_ context.Context, _ string, args ...string) ([]byte, error) {
 		// Extract just the command part (skip the shell and -c flags)
 		if len(args) >= 2 && args[0] == "-c" {
 			executedCommands = append(executedCommands, args[1])
@@ -231,8 +232,8 @@ func TestExecuteHooks_PostTestHooks(t *testing.T) {
 	}
 
 	// Execute hooks (post-test hooks with outputs != nil)
-	hookExecutor := newHookExecutor(repositories, false, runCommand, renderTemplate)
-	results, err := hookExecutor.executeHooks(hooks, "post-test", inputs, outputs, map[string]*engine.TestCaseResult{})
+	hookExecutor := newHookExecutor(repositories, nil, nil, false, runCommand, renderTemplate)
+	results, err := hookExecutor.executeHooks(context.Background(), hooks, "post-test", inputs, outputs, map[string]*engine.TestCaseResult{})
 	require.NoError(t, err)
 	assert.Len(t, results, 2)
 
@@ -276,7 +277,7 @@ func TestExecuteHooks_PreTestHooks_InputsTemplateVariables(t *testing.T) {
 	// Mock the runCommand function
 	var executedCommands []string
 
-	runCommand := func(_ string, args ...string) ([]byte, error) {
+	runCommand := func(_ context.Context, _ string, args ...string) ([]byte, error) {
 		if len(args) >= 2 && args[0] == "-c" {
 			executedCommands = append(executedCommands, args[1])
 		}
@@ -289,8 +290,8 @@ func TestExecuteHooks_PreTestHooks_InputsTemplateVariables(t *testing.T) {
 	}
 
 	// Execute hooks (pre-test hooks with outputs=nil, inputs available)
-	hookExecutor := newHookExecutor(repositories, false, runCommand, renderTemplate)
-	results, err := hookExecutor.executeHooks(hooks, "pre-test", inputs, nil, map[string]*engine.TestCaseResult{})
+	hookExecutor := newHookExecutor(repositories, nil, nil, false, runCommand, renderTemplate)
+	results, err := hookExecutor.executeHooks(context.Background(), hooks, "pre-test", inputs, nil, map[string]*engine.TestCaseResult{})
 	require.NoError(t, err)
 	assert.Len(t, results, 3)
 
@@ -317,7 +318,7 @@ func TestExecuteHooks_PreTestHooks_WithoutTemplateVariables(t *testing.T) {
 
 	var executedCommands []string
 
-	runCommand := func(_ string, args ...string) ([]byte, error) {
+	runCommand := func(_ context.Context, _ string, args ...string) ([]byte, error) {
 		if len(args) >= 2 && args[0] == "-c" {
 			executedCommands = append(executedCommands, args[1])
 		}
@@ -329,8 +330,8 @@ func TestExecuteHooks_PreTestHooks_WithoutTemplateVariables(t *testing.T) {
 		return content, nil
 	}
 
-	hookExecutor := newHookExecutor(nil, false, runCommand, renderTemplate)
-	results, err := hookExecutor.executeHooks(hooks, "pre-test", inputs, nil, map[string]*engine.TestCaseResult{})
+	hookExecutor := newHookExecutor(nil, nil, nil, false, runCommand, renderTemplate)
+	results, err := hookExecutor.executeHooks(context.Background(), hooks, "pre-test", inputs, nil, map[string]*engine.TestCaseResult{})
 	require.NoError(t, err)
 	assert.Len(t, results, 2)
 
@@ -354,10 +355,10 @@ func TestExecuteHooks_PreTestHooks_OutputsTemplateVariables(t *testing.T) {
 	// Create hooks with Outputs template variables
 	// Since outputs=nil for pre-test hooks, these template variables cannot be resolved and should cause an error
 	hooks := []api.Hook{
-		{Name: "pre-hook-with-outputs", Run: fmt.Sprintf("echo 'Outputs XR: %s.Outputs.XR%s'", testexecutionUtils.PlaceholderOpen, testexecutionUtils.PlaceholderClose)},
+		{Name: "pre-hook-with-outputs", Run: "echo 'Outputs XR: {{ .Outputs.XR }}'"},
 	}
 
-	runCommand := func(_ string, _ ...string) ([]byte, error) {
+	runCommand := func(_ context.Context, _ string, _ ...string) ([]byte, error) {
 		return []byte("mock output"), nil
 	}
 
@@ -372,8 +373,8 @@ func TestExecuteHooks_PreTestHooks_OutputsTemplateVariables(t *testing.T) {
 
 	// Execute hooks with outputs=nil (pre-test scenario)
 	// This should fail because Outputs template variables cannot be resolved when outputs is nil
-	hookExecutor := newHookExecutor(repositories, false, runCommand, renderTemplate)
-	results, err := hookExecutor.executeHooks(hooks, "pre-test", inputs, nil, map[string]*engine.TestCaseResult{})
+	hookExecutor := newHookExecutor(repositories, nil, nil, false, runCommand, renderTemplate)
+	results, err := hookExecutor.executeHooks(context.Background(), hooks, "pre-test", inputs, nil, map[string]*engine.TestCaseResult{})
 	require.Error(t, err)
 	// Results should contain the HookResult for the template rendering failure
 	require.NotNil(t, results)
@@ -409,17 +410,17 @@ func TestExecuteHooks_PostTestHooks_InputsAndOutputsTemplateVariables(t *testing
 		},
 	}
 
-	// Create hooks with BOTH Inputs and Outputs template variables (using placeholders as they would appear after YAML loading)
+	// Create hooks with BOTH Inputs and Outputs template variables
 	hooks := []api.Hook{
-		{Name: "post-hook-1", Run: fmt.Sprintf("echo 'Input XR: %s.Inputs.XR%s, Output XR: %s.Outputs.XR%s'", testexecutionUtils.PlaceholderOpen, testexecutionUtils.PlaceholderClose, testexecutionUtils.PlaceholderOpen, testexecutionUtils.PlaceholderClose)},
-		{Name: "post-hook-2", Run: fmt.Sprintf("echo 'Input Composition: %s.Inputs.Composition%s, Output Render: %s.Outputs.Render%s'", testexecutionUtils.PlaceholderOpen, testexecutionUtils.PlaceholderClose, testexecutionUtils.PlaceholderOpen, testexecutionUtils.PlaceholderClose)},
-		{Name: "post-hook-3", Run: fmt.Sprintf("echo 'Output RenderCount: %s.Outputs.RenderCount%s, Repository: %s.Repositories.myrepo%s'", testexecutionUtils.PlaceholderOpen, testexecutionUtils.PlaceholderClose, testexecutionUtils.PlaceholderOpen, testexecutionUtils.PlaceholderClose)},
+		{Name: "post-hook-1", Run: "echo 'Input XR: {{ .Inputs.XR }}, Output XR: {{ .Outputs.XR }}'"},
+		{Name: "post-hook-2", Run: "echo 'Input Composition: {{ .Inputs.Composition }}, Output Render: {{ .Outputs.Render }}'"},
+		{Name: "post-hook-3", Run: "echo 'Output RenderCount: {{ .Outputs.RenderCount }}, Repository: {{ .Repositories.myrepo }}'"},
 	}
 
 	// Mock the runCommand function
 	var executedCommands []string
 
-	runCommand := func(_ string, args ...string) ([]byte, error) {
+	runCommand := func(_ context.Context, _ string, args ...string) ([]byte, error) {
 		if len(args) >= 2 && args[0] == "-c" {
 			executedCommands = append(executedCommands, args[1])
 		}
@@ -443,8 +444,8 @@ func TestExecuteHooks_PostTestHooks_InputsAndOutputsTemplateVariables(t *testing
 	}
 
 	// Execute hooks (post-test hooks with outputs != nil - this enables template processing)
-	hookExecutor := newHookExecutor(repositories, false, runCommand, renderTemplate)
-	results, err := hookExecutor.executeHooks(hooks, "post-test", inputs, outputs, map[string]*engine.TestCaseResult{})
+	hookExecutor := newHookExecutor(repositories, nil, nil, false, runCommand, renderTemplate)
+	results, err := hookExecutor.executeHooks(context.Background(), hooks, "post-test", inputs, outputs, map[string]*engine.TestCaseResult{})
 	require.NoError(t, err)
 	assert.Len(t, results, 3)
 
@@ -481,7 +482,7 @@ func TestExecuteHooks_WorkingDirectory(t *testing.T) {
 	// We don't need to actually run the command - just verify cmd.Dir is set
 	var capturedDir string
 
-	runner.runCommand = func(name string, args ...string) ([]byte, error) {
+	runner.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 		cmd := exec.Command(name, args...)
 		// Set Dir the same way the original does
 		cmd.Dir = runner.testSuiteFileDir
@@ -499,8 +500,8 @@ func TestExecuteHooks_WorkingDirectory(t *testing.T) {
 	}
 
 	// Execute hooks
-	hookExecutor := newHookExecutor(nil, false, runner.runCommand, runner.renderTemplate)
-	results, err := hookExecutor.executeHooks(hooks, "pre-test", api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
+	hookExecutor := newHookExecutor(nil, nil, nil, false, runner.runCommand, runner.renderTemplate)
+	results, err := hookExecutor.executeHooks(context.Background(), hooks, "pre-test", api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
 
 	require.NoError(t, err)
 	require.Len(t, results, 1)
@@ -513,9 +514,9 @@ func TestProcessHookTemplateVariables(t *testing.T) {
 	renderTemplate := func(content string, _ *templateContext, _ string) (string, error) {
 		return content, nil
 	}
-	exec := newHookExecutor(nil, false, nil, renderTemplate)
+	exec := newHookExecutor(nil, nil, nil, false, nil, renderTemplate)
 
-	t.Run("no placeholders returns command as-is", func(t *testing.T) {
+	t.Run("no templates returns command as-is", func(t *testing.T) {
 		hook := api.Hook{Name: "h", Run: "echo hello"}
 		final, cmdVars, err := exec.processHookTemplateVariables(hook, api.Inputs{}, nil, nil)
 		require.NoError(t, err)
@@ -523,28 +524,28 @@ func TestProcessHookTemplateVariables(t *testing.T) {
 		assert.Equal(t, "echo hello", cmdVars)
 	})
 
-	t.Run("with placeholders restores and calls renderTemplate", func(t *testing.T) {
+	t.Run("with templates calls renderTemplate", func(t *testing.T) {
 		var rendered string
 
 		renderTemplate := func(content string, _ *templateContext, _ string) (string, error) {
 			rendered = content
 			return "echo /path", nil
 		}
-		exec := newHookExecutor(map[string]string{"r": "/path"}, false, nil, renderTemplate)
-		hook := api.Hook{Run: testexecutionUtils.CreatePlaceholder(".Repositories.r")}
+		exec := newHookExecutor(map[string]string{"r": "/path"}, nil, nil, false, nil, renderTemplate)
+		hook := api.Hook{Run: "{{ .Repositories.r }}"}
 		final, cmdVars, err := exec.processHookTemplateVariables(hook, api.Inputs{}, nil, nil)
 		require.NoError(t, err)
 		assert.Equal(t, "echo /path", final)
-		assert.Equal(t, "{{.Repositories.r}}", cmdVars)
-		assert.Equal(t, "{{.Repositories.r}}", rendered)
+		assert.Equal(t, "{{ .Repositories.r }}", cmdVars)
+		assert.Equal(t, "{{ .Repositories.r }}", rendered)
 	})
 
 	t.Run("render error is returned", func(t *testing.T) {
 		renderTemplate := func(string, *templateContext, string) (string, error) {
 			return "", fmt.Errorf("render failed")
 		}
-		exec := newHookExecutor(nil, false, nil, renderTemplate)
-		hook := api.Hook{Run: testexecutionUtils.CreatePlaceholder(".X")}
+		exec := newHookExecutor(nil, nil, nil, false, nil, renderTemplate)
+		hook := api.Hook{Run: "{{ .X }}"}
 		_, _, err := exec.processHookTemplateVariables(hook, api.Inputs{}, nil, nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "render failed")
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"slices"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+)
+
+// InputFile is a file or directory that a test case of the testsuite reads.
+type InputFile struct {
+	Description string // What the file is to the test case, e.g. composition or crd
+	Value       string // The path as written in the testsuite file
+	Path        string // The absolute path
+	TestCases   []int  // The indexes of the test cases that read it, in file order
+}
+
+// InputFiles returns the files and directories that the test cases of the testsuite read, with common merged: their
+// inputs, patches and the expected files of their golden file assertions, each path once with all the test cases that
+// read it. Paths with templates are only returned when they use nothing but .Repositories, .Vars and .Env.
+func (r *Runner) InputFiles() []InputFile {
+	vars, _ := r.resolveVars()
+	templateContext := newTemplateContext(r.Repositories, vars, r.env, api.Inputs{}, nil, nil)
+
+	var files []InputFile
+
+	seen := make(map[string]int) // Index in files of each description and path
+
+	for i, testCase := range r.testSuiteSpec.Tests {
+		testCase.MergeCommon(r.testSuiteSpec.Common)
+
+		add := func(value, description string, _ ...any) {
+			if value == "" {
+				return
+			}
+
+			rendered := value
+
+			if testexecutionUtils.HasTemplate(value) {
+				var ok bool
+				if rendered, ok = r.renderStaticPath(value, templateContext); !ok {
+					return
+				}
+			}
+
+			path, err := r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, rendered)
+			if err != nil {
+				return
+			}
+
+			if j, ok := seen[description+"\x00"+path]; ok {
+				if !slices.Contains(files[j].TestCases, i) {
+					files[j].TestCases = append(files[j].TestCases, i)
+				}
+
+				return
+			}
+
+			seen[description+"\x00"+path] = len(files)
+
+			files = append(files, InputFile{Description: description, Value: value, Path: path, TestCases: []int{i}})
+		}
+
+		eachInputPath(testCase.Inputs, testCase.Patches, add)
+
+		for _, assertion := range slices.Concat(testCase.Assertions.Diff, testCase.Assertions.Dyff) {
+			add(assertion.Expected, "golden file")
+		}
+	}
+
+	return files
+}
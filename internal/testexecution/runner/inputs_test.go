@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/stretchr/testify/assert" //nolint:depguard // testify is widely used for testing
+)
+
+func TestInputFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	spec := &api.TestSuiteSpec{
+		Vars: map[string]string{"dir": "apis"},
+		Common: api.Common{
+			Inputs: api.Inputs{Composition: "{{ .Vars.dir }}/composition.yaml", Functions: "functions"},
+			Assertions: api.Assertions{
+				Diff: []api.AssertionGoldenFile{{Name: "golden", Expected: "golden/rendered.yaml"}},
+			},
+		},
+		Tests: []api.TestCase{
+			{
+				Name:    "first",
+				Inputs:  api.Inputs{XR: "xr.yaml", CRDs: []string{"crds"}},
+				Patches: api.Patches{XRD: "xrd.yaml"},
+			},
+			{
+				Name:   "second",
+				Inputs: api.Inputs{XR: "{{ .Tests.first.Outputs.XR }}", ContextFiles: map[string]string{"env": "context.json"}},
+				Assertions: api.Assertions{
+					Dyff: []api.AssertionGoldenFile{{Name: "dyff", Expected: "golden/second.yaml"}},
+				},
+			},
+		},
+	}
+
+	r := NewRunner(&testexecutionUtils.Options{}, filepath.Join(dir, "aws_xprin.yaml"), spec)
+
+	assert.Equal(t, []InputFile{
+		{Description: "XR", Value: "xr.yaml", Path: filepath.Join(dir, "xr.yaml"), TestCases: []int{0}},
+		{Description: "composition", Value: "{{ .Vars.dir }}/composition.yaml", Path: filepath.Join(dir, "apis", "composition.yaml"), TestCases: []int{0, 1}},
+		{Description: "functions", Value: "functions", Path: filepath.Join(dir, "functions"), TestCases: []int{0, 1}},
+		{Description: "crd", Value: "crds", Path: filepath.Join(dir, "crds"), TestCases: []int{0}},
+		{Description: "XRD", Value: "xrd.yaml", Path: filepath.Join(dir, "xrd.yaml"), TestCases: []int{0}},
+		{Description: "golden file", Value: "golden/rendered.yaml", Path: filepath.Join(dir, "golden", "rendered.yaml"), TestCases: []int{0, 1}},
+		{Description: "context file for key 'env'", Value: "context.json", Path: filepath.Join(dir, "context.json"), TestCases: []int{1}},
+		{Description: "golden file", Value: "golden/second.yaml", Path: filepath.Join(dir, "golden", "second.yaml"), TestCases: []int{1}},
+	}, r.InputFiles())
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+)
+
+// LintProblem is a problem of a testsuite file found without running it.
+type LintProblem struct {
+	Path    []any // Keys and list indexes leading to the problem in the testsuite file, e.g. tests, 0, inputs, crds, 1
+	Message string
+}
+
+// Lint checks the testsuite without running it: that the files of the inputs, patches and golden file assertions exist,
+// and that the xprin and golden file assertions are well-formed. Common, the assertion sets and each test case are
+// checked as written in the testsuite file, before includes and extends are resolved, so that the problems can be
+// located in it. Paths with templates are only checked when they use nothing but .Repositories, .Vars and .Env.
+// The expected files of the golden file assertions are only checked when the testsuite has no hooks, since hooks can
+// generate them.
+func (r *Runner) Lint() []LintProblem {
+	var problems []LintProblem
+
+	vars, err := r.resolveVars()
+	if err != nil {
+		problems = append(problems, LintProblem{Path: []any{"vars"}, Message: err.Error()})
+	}
+
+	templateContext := newTemplateContext(r.Repositories, vars, r.env, api.Inputs{}, nil, nil)
+	checkGoldenFiles := !r.hasHooks()
+
+	lintSection := func(path []any, inputs api.Inputs, patches api.Patches, assertions api.Assertions) {
+		problems = append(problems, r.lintPaths(path, inputs, patches, templateContext)...)
+		problems = append(problems, r.lintAssertions(append(slices.Clip(path), "assertions"), assertions, checkGoldenFiles, templateContext)...)
+	}
+
+	common := r.testSuiteSpec.Common
+	lintSection([]any{"common"}, common.Inputs, common.Patches, common.Assertions)
+
+	for _, name := range slices.Sorted(maps.Keys(r.testSuiteSpec.AssertionSets)) {
+		problems = append(problems, r.lintAssertions([]any{"assertion-sets", name}, r.testSuiteSpec.AssertionSets[name], checkGoldenFiles, templateContext)...)
+	}
+
+	for i, testCase := range r.testSuiteSpec.Tests {
+		lintSection([]any{"tests", i}, testCase.Inputs, testCase.Patches, testCase.Assertions)
+	}
+
+	return problems
+}
+
+// lintPaths checks that the files of the inputs and patches of a section exist.
+func (r *Runner) lintPaths(path []any, inputs api.Inputs, patches api.Patches, templateContext *templateContext) []LintProblem {
+	var problems []LintProblem
+
+	eachInputPath(inputs, patches, func(value, description string, keys ...any) {
+		if problem := r.lintPath(value, description, append(slices.Clip(path), keys...), templateContext); problem != nil {
+			problems = append(problems, *problem)
+		}
+	})
+
+	return problems
+}
+
+// eachInputPath calls fn with each file path of the inputs and patches of a section, with its description and its keys
+// in the section. Empty paths are included.
+func eachInputPath(inputs api.Inputs, patches api.Patches, fn func(value, description string, keys ...any)) {
+	fn(inputs.XR, "XR", "inputs", "xr")
+	fn(inputs.Claim, "Claim", "inputs", "claim")
+
+	if inputs.XRFromXRD != nil {
+		fn(inputs.XRFromXRD.XRD, "XRD of xr-from-xrd", "inputs", "xr-from-xrd", "xrd")
+	}
+
+	fn(inputs.Composition, "composition", "inputs", "composition")
+	fn(inputs.Functions, "functions", "inputs", "functions")
+
+	for i, crd := range inputs.CRDs {
+		fn(crd, "crd", "inputs", "crds", i)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(inputs.ContextFiles)) {
+		fn(inputs.ContextFiles[key], fmt.Sprintf("context file for key '%s'", key), "inputs", "context-files", key)
+	}
+
+	fn(inputs.ObservedResources, "observed resources", "inputs", "observed-resources")
+	fn(inputs.ExtraResources, "extra resources", "inputs", "extra-resources")
+	fn(inputs.FunctionCredentials, "function credentials", "inputs", "function-credentials")
+	fn(patches.XRD, "XRD", "patches", "xrd")
+}
+
+// lintPath checks that the file of a path of the testsuite file exists, relative to the testsuite file.
+// It returns nil if the file exists, or if the path is empty or a template that cannot be rendered before running.
+func (r *Runner) lintPath(value, description string, path []any, templateContext *templateContext) *LintProblem {
+	if value == "" {
+		return nil
+	}
+
+	if testexecutionUtils.HasTemplate(value) {
+		rendered, ok := r.renderStaticPath(value, templateContext)
+		if !ok {
+			return nil
+		}
+
+		value = rendered
+	}
+
+	expanded, err := r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, value)
+	if err == nil {
+		err = r.verifyPathExists(expanded)
+	}
+
+	if err != nil {
+		return &LintProblem{Path: path, Message: fmt.Sprintf("%s file not found: %v", description, err)}
+	}
+
+	return nil
+}
+
+// hasHooks returns true if common, a hook set or a test case of the testsuite has hooks.
+func (r *Runner) hasHooks() bool {
+	if r.testSuiteSpec.HasCommonHooks() || len(r.testSuiteSpec.HookSets) > 0 || len(r.testSuiteSpec.Common.Hooks.Sets) > 0 {
+		return true
+	}
+
+	for _, testCase := range r.testSuiteSpec.Tests {
+		if testCase.HasHooks() || len(testCase.Hooks.Sets) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renderStaticPath renders a path template that only uses the template variables known before any test case runs
+// (.Repositories, .Vars and .Env). It returns false if the template uses other variables or cannot be rendered.
+func (r *Runner) renderStaticPath(value string, templateContext *templateContext) (string, bool) {
+	tmpl, err := newTemplate("path", r.templateFuncs()).Parse(testexecutionUtils.EscapeLiteralBraces(value))
+	if err != nil {
+		return "", false
+	}
+
+	for _, variable := range usedTemplateVariables(tmpl.Root) {
+		if variable != "Repositories" && variable != "Vars" && variable != "Env" {
+			return "", false
+		}
+	}
+
+	rendered, err := r.renderTemplate(value, templateContext, "path")
+	if err != nil {
+		return "", false
+	}
+
+	return rendered, true
+}
+
+// lintAssertions checks that the xprin and golden file assertions of a section are well-formed,
+// and if checkGoldenFiles is true, that the expected files of the golden file assertions exist.
+func (r *Runner) lintAssertions(path []any, assertions api.Assertions, checkGoldenFiles bool, templateContext *templateContext) []LintProblem {
+	var problems []LintProblem
+
+	for i, assertion := range assertions.Xprin {
+		if err := CheckAssertionXprin(assertion); err != nil {
+			problems = append(problems, LintProblem{
+				Path:    append(slices.Clip(path), "xprin", i),
+				Message: fmt.Sprintf("assertion '%s': %v", assertion.Name, err),
+			})
+		}
+	}
+
+	for _, engineName := range []string{"diff", "dyff"} {
+		goldenFiles := assertions.Diff
+		if engineName == "dyff" {
+			goldenFiles = assertions.Dyff
+		}
+
+		for i, assertion := range goldenFiles {
+			assertionPath := append(slices.Clip(path), engineName, i)
+
+			if checkGoldenFiles {
+				if problem := r.lintPath(assertion.Expected, "expected", append(slices.Clip(assertionPath), "expected"), templateContext); problem != nil {
+					problem.Message = fmt.Sprintf("assertion '%s': %s", assertion.Name, problem.Message)
+					problems = append(problems, *problem)
+				}
+			}
+
+			if assertion.Resource == "" || testexecutionUtils.HasTemplate(assertion.Resource) {
+				continue
+			}
+
+			if kind, name, ok := strings.Cut(assertion.Resource, "/"); !ok || kind == "" || name == "" || strings.Contains(name, "/") {
+				problems = append(problems, LintProblem{
+					Path:    append(slices.Clip(assertionPath), "resource"),
+					Message: fmt.Sprintf("assertion '%s': resource must be in format 'Kind/name', got '%s'", assertion.Name, assertion.Resource),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+// TemplateReferences are the names that the templates of a testsuite file can reference.
+type TemplateReferences struct {
+	TestIDs      []string // IDs of the test cases of the testsuite file
+	Vars         []string // Names of the testsuite vars and of the vars set with --var
+	Repositories []string // Names of the configured repositories
+	Env          []string // Names of the environment variables listed in the env list of the testsuite file
+}
+
+// CheckTemplateReferences checks that a template of a testsuite file only references known template variables,
+// test case IDs, vars, repositories and environment variables, and existing fields of the test case results, inputs and outputs.
+func CheckTemplateReferences(name, content string, refs TemplateReferences) error {
+	tmpl, err := newTemplate(name, (&Runner{}).templateFuncs()).Parse(testexecutionUtils.EscapeLiteralBraces(content))
+	if err != nil {
+		return err
+	}
+
+	for _, fields := range usedTemplateFields(tmpl.Root) {
+		reference := "." + strings.Join(fields, ".")
+
+		switch fields[0] {
+		case "Tests":
+			if len(fields) < 2 {
+				continue
+			}
+
+			if !slices.Contains(refs.TestIDs, fields[1]) {
+				return fmt.Errorf("%s references unknown test case ID '%s'", reference, fields[1])
+			}
+
+			if len(fields) > 2 && !hasFieldOrMethod(engine.TestCaseResult{}, fields[2]) {
+				return fmt.Errorf("%s references unknown test case result field '%s'", reference, fields[2])
+			}
+
+			if len(fields) > 3 && fields[2] == "Outputs" && !hasFieldOrMethod(engine.Outputs{}, fields[3]) {
+				return fmt.Errorf("%s references unknown output '%s'", reference, fields[3])
+			}
+		case "Inputs":
+			if len(fields) > 1 && !hasFieldOrMethod(api.Inputs{}, fields[1]) {
+				return fmt.Errorf("%s references unknown input '%s'", reference, fields[1])
+			}
+		case "Outputs":
+			if len(fields) > 1 && !hasFieldOrMethod(engine.Outputs{}, fields[1]) {
+				return fmt.Errorf("%s references unknown output '%s'", reference, fields[1])
+			}
+		case "Vars":
+			if len(fields) > 1 && !slices.Contains(refs.Vars, fields[1]) {
+				return fmt.Errorf("%s references unknown var '%s'", reference, fields[1])
+			}
+		case "Repositories":
+			if len(fields) > 1 && !slices.Contains(refs.Repositories, fields[1]) {
+				return fmt.Errorf("%s references unknown repository '%s'", reference, fields[1])
+			}
+		case "Env":
+			if len(fields) > 1 && !slices.Contains(refs.Env, fields[1]) {
+				return fmt.Errorf("%s references environment variable '%s', which is not in the env list of the testsuite file", reference, fields[1])
+			}
+		default:
+			return fmt.Errorf("%s references unknown template variable .%s (available: .%s)", reference, fields[0], strings.Join(testexecutionUtils.TemplateVariables(), ", ."))
+		}
+	}
+
+	return nil
+}
+
+// hasFieldOrMethod returns true if a template can reference name on a value of the type of v.
+func hasFieldOrMethod(v any, name string) bool {
+	t := reflect.TypeOf(v)
+
+	if field, ok := t.FieldByName(name); ok && field.IsExported() {
+		return true
+	}
+
+	_, ok := reflect.PointerTo(t).MethodByName(name)
+
+	return ok
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestCheckTemplateReferences(t *testing.T) {
+	refs := TemplateReferences{
+		TestIDs:      []string{"base"},
+		Vars:         []string{"region"},
+		Repositories: []string{"myrepo"},
+		Env:          []string{"HOME"},
+	}
+
+	tests := []struct {
+		name      string
+		content   string
+		expectErr string
+	}{
+		{
+			name:    "known references",
+			content: "{{ .Repositories.myrepo }}/{{ .Vars.region }}/{{ .Env.HOME }} {{ .Inputs.XR }} {{ .Outputs.Rendered }}",
+		},
+		{
+			name:    "test case result",
+			content: "{{ .Tests.base.Outputs.XR }} {{ .Tests.base.Status.String }}",
+		},
+		{
+			name:      "unknown test case ID",
+			content:   "{{ .Tests.other.Outputs.XR }}",
+			expectErr: ".Tests.other.Outputs.XR references unknown test case ID 'other'",
+		},
+		{
+			name:      "unknown test case result field",
+			content:   "{{ .Tests.base.Output }}",
+			expectErr: "unknown test case result field 'Output'",
+		},
+		{
+			name:      "unknown output of a test case",
+			content:   "{{ .Tests.base.Outputs.Xr }}",
+			expectErr: "unknown output 'Xr'",
+		},
+		{
+			name:      "unknown input",
+			content:   "{{ .Inputs.Composite }}",
+			expectErr: "unknown input 'Composite'",
+		},
+		{
+			name:      "unknown var",
+			content:   "{{ $.Vars.zone }}",
+			expectErr: ".Vars.zone references unknown var 'zone'",
+		},
+		{
+			name:      "unknown repository",
+			content:   "{{ .Repositories.other }}",
+			expectErr: "unknown repository 'other'",
+		},
+		{
+			name:      "environment variable not in the env list",
+			content:   "{{ .Env.TOKEN }}",
+			expectErr: ".Env.TOKEN references environment variable 'TOKEN', which is not in the env list of the testsuite file",
+		},
+		{
+			name:      "indexed environment variable not in the env list",
+			content:   `{{ index .Env "TOKEN" }}`,
+			expectErr: "environment variable 'TOKEN', which is not in the env list",
+		},
+		{
+			name:      "unknown template variable",
+			content:   "{{ .Test.base }}",
+			expectErr: "unknown template variable .Test",
+		},
+		{
+			name:    "dot changed by range",
+			content: "{{ range .Inputs.CRDs }}{{ .Unknown }}{{ end }}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTemplateReferences("run", tt.content, refs)
+			if tt.expectErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectErr)
+		})
+	}
+}
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"composition.yaml", "functions.yaml", "xr.yaml", "golden.yaml"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte("kind: X\n"), 0o600))
+	}
+
+	spec := &api.TestSuiteSpec{
+		Vars: map[string]string{"dir": "."},
+		Common: api.Common{
+			Inputs: api.Inputs{Composition: "composition.yaml", Functions: "{{ .Vars.dir }}/functions.yaml"},
+		},
+		AssertionSets: map[string]api.Assertions{
+			"golden": {Diff: []api.AssertionGoldenFile{{Name: "missing golden", Expected: "missing.yaml", Resource: "Cluster"}}},
+		},
+		Tests: []api.TestCase{
+			{
+				Name: "valid",
+				Inputs: api.Inputs{
+					XR:   "xr.yaml",
+					CRDs: []string{"{{ .Tests.other.Outputs.XR }}"},
+				},
+				Assertions: api.Assertions{
+					Xprin: []api.AssertionXprin{{Name: "count", Type: "Count", Value: 2}},
+					Dyff:  []api.AssertionGoldenFile{{Name: "golden", Expected: "golden.yaml"}},
+				},
+			},
+			{
+				Name:    "invalid",
+				Inputs:  api.Inputs{XR: "missing-xr.yaml", CRDs: []string{"xr.yaml", "{{ .Vars.dir }}/missing-crd.yaml"}},
+				Patches: api.Patches{XRD: "missing-xrd.yaml"},
+				Assertions: api.Assertions{
+					Xprin: []api.AssertionXprin{{Name: "exists", Type: "Exists"}},
+				},
+			},
+		},
+	}
+
+	r := NewRunner(&testexecutionUtils.Options{}, filepath.Join(dir, "aws_xprin.yaml"), spec)
+
+	var got []LintProblem
+	for _, problem := range r.Lint() {
+		got = append(got, LintProblem{Path: problem.Path})
+		assert.NotEmpty(t, problem.Message)
+	}
+
+	assert.Equal(t, []LintProblem{
+		{Path: []any{"assertion-sets", "golden", "diff", 0, "expected"}},
+		{Path: []any{"assertion-sets", "golden", "diff", 0, "resource"}},
+		{Path: []any{"tests", 1, "inputs", "xr"}},
+		{Path: []any{"tests", 1, "inputs", "crds", 1}},
+		{Path: []any{"tests", 1, "patches", "xrd"}},
+		{Path: []any{"tests", 1, "assertions", "xprin", 0}},
+	}, got)
+
+	t.Run("golden files are not checked with hooks", func(t *testing.T) {
+		spec.Tests[0].Hooks = api.Hooks{PreTest: []api.Hook{{Run: "cp rendered.yaml missing.yaml"}}}
+
+		for _, problem := range r.Lint() {
+			assert.NotEqual(t, "expected", problem.Path[len(problem.Path)-1], problem.Message)
+		}
+	})
+}
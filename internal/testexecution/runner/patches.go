@@ -17,6 +17,7 @@ limitations under the License.
 package runner
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -53,6 +54,93 @@ func (r *Runner) copyInput(src, inputType string) (string, error) {
 	return dest, nil
 }
 
+// writeInlineInput writes inline content to the inputs directory organized by type and returns the destination path.
+func (r *Runner) writeInlineInput(content []byte, inputType, filename string) (string, error) {
+	typeDir := filepath.Join(r.inputsDir, inputType)
+	if err := r.fs.MkdirAll(typeDir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create %s directory: %w", inputType, err)
+	}
+
+	dest := filepath.Join(typeDir, filename)
+	if err := afero.WriteFile(r.fs, dest, content, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write inline %s: %w", inputType, err)
+	}
+
+	if r.Debug {
+		utils.DebugPrintf("Wrote inline %s to: %s\n", inputType, dest)
+	}
+
+	return dest, nil
+}
+
+// writeInlineInputs writes every inline input to the inputs directory, sets the corresponding path fields
+// and clears the inline inputs, so that the rest of the test case only deals with paths.
+func (r *Runner) writeInlineInputs(inputs *api.Inputs) error {
+	var err error
+
+	if inputs.Inline.XR != nil {
+		if inputs.XR, err = r.writeInlineDocuments([]map[string]any{inputs.Inline.XR}, "xr", "xr.yaml"); err != nil {
+			return err
+		}
+	}
+
+	if inputs.Inline.Claim != nil {
+		if inputs.Claim, err = r.writeInlineDocuments([]map[string]any{inputs.Inline.Claim}, "claim", "claim.yaml"); err != nil {
+			return err
+		}
+	}
+
+	if inputs.Inline.ObservedResources != nil {
+		if inputs.ObservedResources, err = r.writeInlineDocuments(inputs.Inline.ObservedResources, "observed-resources", "observed-resources.yaml"); err != nil {
+			return err
+		}
+	}
+
+	if inputs.Inline.ExtraResources != nil {
+		if inputs.ExtraResources, err = r.writeInlineDocuments(inputs.Inline.ExtraResources, "extra-resources", "extra-resources.yaml"); err != nil {
+			return err
+		}
+	}
+
+	for key, object := range inputs.Inline.ContextFiles {
+		// crossplane render expects context files to contain JSON
+		content, err := json.Marshal(object)
+		if err != nil {
+			return fmt.Errorf("failed to marshal inline context file for key '%s': %w", key, err)
+		}
+
+		if inputs.ContextFiles == nil {
+			inputs.ContextFiles = make(map[string]string)
+		}
+
+		inputs.ContextFiles[key], err = r.writeInlineInput(content, "context-files", fmt.Sprintf("inline-%s.json", strings.ReplaceAll(key, "/", "_")))
+		if err != nil {
+			return err
+		}
+	}
+
+	inputs.Inline = api.InlineInputs{}
+
+	return nil
+}
+
+// writeInlineDocuments marshals inline objects to a multi-document YAML file in the inputs directory.
+func (r *Runner) writeInlineDocuments(documents []map[string]any, inputType, filename string) (string, error) {
+	var content []byte
+
+	for i, document := range documents {
+		documentYAML, err := yaml.Marshal(document)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal inline %s document %d: %w", inputType, i+1, err)
+		}
+
+		content = append(content, []byte("---\n")...)
+		content = append(content, documentYAML...)
+	}
+
+	return r.writeInlineInput(content, inputType, filename)
+}
+
 // copyToPath copies a file or directory to the given destination path, creating parent directories as needed.
 // Use this when the destination path must be chosen by the caller (e.g. to avoid overwriting same-named files).
 func (r *Runner) copyToPath(src, dest string) (string, error) {
@@ -139,7 +227,7 @@ func (r *Runner) convertClaimToXR(claimPath, outputPath string) (string, error)
 	return xrPath, nil
 }
 
-// patchXR applies XRD defaults and connection secret patches to an XR using the patch-xr library.
+// patchXR applies XRD defaults, field patches and connection secret patches to an XR using the patch-xr library.
 func (r *Runner) patchXR(xrPath, outputPath string, patches api.Patches) (string, error) {
 	// Check connection secret configuration first
 	if err := patches.CheckConnectionSecret(); err != nil {
@@ -179,6 +267,13 @@ func (r *Runner) patchXR(xrPath, outputPath string, patches api.Patches) (string
 		}
 	}
 
+	// Apply field sets, merge patches and JSON patches (after XRD defaults, so they can override defaulted values)
+	if patches.HasFieldPatches() {
+		if err := r.applyFieldPatches(xr, patches); err != nil {
+			return "", err
+		}
+	}
+
 	// Add connection secret if requested
 	if patches.HasConnectionSecret() {
 		if r.Debug {
@@ -204,3 +299,48 @@ func (r *Runner) patchXR(xrPath, outputPath string, patches api.Patches) (string
 
 	return patchedXRPath, nil
 }
+
+// applyFieldPatches applies field sets, JSON merge patches and JSON patches to an XR, in this order.
+func (r *Runner) applyFieldPatches(xr *unstructured.Unstructured, patches api.Patches) error {
+	for _, set := range patches.Set {
+		if r.Debug {
+			utils.DebugPrintf("Patching XR: Setting %s\n", set.Path)
+		}
+
+		if err := patchxr.SetField(xr, set.Path, set.Value); err != nil {
+			return fmt.Errorf("failed to apply set patch: %w", err)
+		}
+	}
+
+	for i, merge := range patches.Merge {
+		if r.Debug {
+			utils.DebugPrintf("Patching XR: Applying merge patch %d\n", i+1)
+		}
+
+		patch, err := json.Marshal(merge)
+		if err != nil {
+			return fmt.Errorf("failed to marshal merge patch %d: %w", i+1, err)
+		}
+
+		if err := patchxr.MergePatch(xr, patch); err != nil {
+			return fmt.Errorf("failed to apply merge patch %d: %w", i+1, err)
+		}
+	}
+
+	if len(patches.JSONPatch) > 0 {
+		if r.Debug {
+			utils.DebugPrintf("Patching XR: Applying %d JSON patch operations\n", len(patches.JSONPatch))
+		}
+
+		patch, err := json.Marshal(patches.JSONPatch)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON patch: %w", err)
+		}
+
+		if err := patchxr.JSONPatch(xr, patch); err != nil {
+			return fmt.Errorf("failed to apply JSON patch: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
 	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	"sigs.k8s.io/yaml"
 )
 
 // boolPtr is a helper function to create a pointer to a boolean value.
@@ -150,6 +151,84 @@ spec:
 	}
 }
 
+// TestPatchXR_FieldPatches tests that set, merge and JSON patches are applied to the XR in order.
+func TestPatchXR_FieldPatches(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	xrContent := `apiVersion: example.org/v1
+kind: XExample
+metadata:
+  name: test-xr
+spec:
+  field: value
+  replicas: 1`
+	xrFile := "/xr.yaml"
+	require.NoError(t, afero.WriteFile(fs, xrFile, []byte(xrContent), 0o644))
+
+	outputDir := "/output"
+	require.NoError(t, fs.MkdirAll(outputDir, 0o755))
+
+	runner := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{Tests: []api.TestCase{}})
+	runner.fs = fs
+
+	tests := []struct {
+		name        string
+		patches     api.Patches
+		expected    map[string]any
+		errContains string
+	}{
+		{
+			name: "set, merge and jsonpatch applied in order",
+			patches: api.Patches{
+				Set: []api.FieldSet{
+					{Path: "spec.replicas", Value: 2},
+					{Path: "spec.tags[0]", Value: "team-a"},
+				},
+				Merge: []map[string]any{
+					{"spec": map[string]any{"field": nil, "region": "eu-west-1"}},
+				},
+				JSONPatch: []api.JSONPatchOperation{
+					{Op: "test", Path: "/spec/replicas", Value: 2},
+					{Op: "replace", Path: "/spec/replicas", Value: 5},
+				},
+			},
+			expected: map[string]any{
+				"replicas": float64(5),
+				"region":   "eu-west-1",
+				"tags":     []any{"team-a"},
+			},
+		},
+		{
+			name: "failing JSON patch test operation",
+			patches: api.Patches{
+				JSONPatch: []api.JSONPatchOperation{{Op: "test", Path: "/spec/field", Value: "other"}},
+			},
+			errContains: "failed to apply JSON patch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := runner.patchXR(xrFile, outputDir, tt.patches)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			data, err := afero.ReadFile(fs, result)
+			require.NoError(t, err)
+
+			var xr map[string]any
+			require.NoError(t, yaml.Unmarshal(data, &xr))
+			assert.Equal(t, tt.expected, xr["spec"])
+		})
+	}
+}
+
 // TestUniqueBaseNamesForPaths tests the pure function that maps paths to unique base filenames.
 func TestUniqueBaseNamesForPaths(t *testing.T) {
 	tests := []struct {
@@ -172,3 +251,50 @@ func TestUniqueBaseNamesForPaths(t *testing.T) {
 		})
 	}
 }
+
+// TestWriteInlineInputs tests that inline inputs are written to the inputs directory and replaced by paths.
+func TestWriteInlineInputs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	runner := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, &api.TestSuiteSpec{Tests: []api.TestCase{}})
+	runner.fs = fs
+	runner.inputsDir = "/tmp/inputs"
+
+	inputs := api.Inputs{
+		ContextFiles: map[string]string{"from-file": "/tmp/inputs/context-files/context.json"},
+		Inline: api.InlineInputs{
+			XR: map[string]any{"apiVersion": "example.org/v1", "kind": "XExample"},
+			ObservedResources: []map[string]any{
+				{"kind": "Bucket"},
+				{"kind": "Secret"},
+			},
+			ContextFiles: map[string]map[string]any{
+				"apiextensions.crossplane.io/environment": {"region": "eu-west-1"},
+			},
+		},
+	}
+
+	require.NoError(t, runner.writeInlineInputs(&inputs))
+
+	assert.Equal(t, "/tmp/inputs/xr/xr.yaml", inputs.XR)
+	assert.Equal(t, "/tmp/inputs/observed-resources/observed-resources.yaml", inputs.ObservedResources)
+	assert.Empty(t, inputs.Claim)
+	assert.Empty(t, inputs.ExtraResources)
+	assert.False(t, inputs.Inline.HasInlineInputs())
+	assert.Equal(t, map[string]string{
+		"from-file": "/tmp/inputs/context-files/context.json",
+		"apiextensions.crossplane.io/environment": "/tmp/inputs/context-files/inline-apiextensions.crossplane.io_environment.json",
+	}, inputs.ContextFiles)
+
+	xrContent, err := afero.ReadFile(fs, inputs.XR)
+	require.NoError(t, err)
+	assert.Equal(t, "---\napiVersion: example.org/v1\nkind: XExample\n", string(xrContent))
+
+	observedContent, err := afero.ReadFile(fs, inputs.ObservedResources)
+	require.NoError(t, err)
+	assert.Equal(t, "---\nkind: Bucket\n---\nkind: Secret\n", string(observedContent))
+
+	contextContent, err := afero.ReadFile(fs, inputs.ContextFiles["apiextensions.crossplane.io/environment"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"region": "eu-west-1"}`, string(contextContent))
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/gertd/go-pluralize"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+)
+
+// helpersCommand is the command of xprin-helpers in the commands of a prepared render.
+const helpersCommand = "xprin-helpers"
+
+// shellSafePattern matches the arguments that need no quoting in a shell command line.
+var shellSafePattern = regexp.MustCompile(`^[A-Za-z0-9@%+=:,./_-]+$`)
+
+// RenderCommand is a shell command of a prepared render, with what it does.
+type RenderCommand struct {
+	Description string   // e.g. "Convert the Claim to an XR"
+	Args        []string // The command and its arguments
+}
+
+// ShellCommand returns the command as a POSIX shell command line, quoting the arguments that need it.
+func (c RenderCommand) ShellCommand() string {
+	quoted := make([]string, 0, len(c.Args))
+
+	for _, arg := range c.Args {
+		if shellSafePattern.MatchString(arg) {
+			quoted = append(quoted, arg)
+			continue
+		}
+
+		quoted = append(quoted, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// PreparedRender is a test case prepared to be rendered on its own (xprin render): its inputs are copied to InputsDir,
+// with its Claim converted to an XR and the XR patched, exactly as when the test case runs.
+type PreparedRender struct {
+	TestCase  api.TestCase    // The test case with common merged, its templates rendered and its inputs in InputsDir
+	InputsDir string          // The directory of the inputs
+	Commands  []RenderCommand // The equivalent shell commands, ending with the render command
+}
+
+// PrepareRender prepares the test case with an ID, or else the only test case with a name, to be rendered with its inputs in
+// dir/inputs. Hooks and assertions are ignored. Test cases that use the results of other test cases cannot be rendered
+// on their own.
+func (r *Runner) PrepareRender(selector, dir string) (*PreparedRender, error) {
+	tests, err := r.renderableTests()
+	if err != nil {
+		return nil, err
+	}
+
+	testCase, err := selectTestCase(tests, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.prepareRender(testCase, tests, dir)
+}
+
+// RenderedResources renders each test case of the testsuite that is not skipped and can be rendered on its own (see
+// PrepareRender), and returns the resources they render as Kind/name, each once and sorted. Test cases that fail to
+// render are left out.
+func (r *Runner) RenderedResources(ctx context.Context) ([]string, error) {
+	tests, err := r.renderableTests()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+
+	for _, testCase := range tests {
+		if testCase.IsSkipped() || ctx.Err() != nil {
+			continue
+		}
+
+		output, err := r.renderInTempDir(ctx, testCase, tests)
+		if err != nil {
+			if r.Debug {
+				utils.DebugPrintf("Failed to render test case '%s': %v\n", testCase.Name, err)
+			}
+
+			continue
+		}
+
+		result := &engine.TestCaseResult{}
+		if err := result.ProcessRenderOutput(output); err != nil {
+			continue
+		}
+
+		for _, resource := range result.RenderedResources {
+			seen[resource.GetKind()+"/"+resource.GetName()] = true
+		}
+	}
+
+	return slices.Sorted(maps.Keys(seen)), nil
+}
+
+// renderInTempDir prepares and renders a test case with its inputs in a temporary directory, and returns the rendered
+// resources.
+func (r *Runner) renderInTempDir(ctx context.Context, testCase api.TestCase, tests []api.TestCase) ([]byte, error) {
+	dir, err := afero.TempDir(r.fs, "", "xprin-render-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	defer r.removeTmpDir(dir)
+
+	prepared, err := r.prepareRender(testCase, tests, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.RunRender(ctx, prepared)
+}
+
+// renderableTests resolves the vars of the testsuite and returns its test cases, with one test case per XR of
+// xr-from-xrd (see expandXRFromXRD).
+func (r *Runner) renderableTests() ([]api.TestCase, error) {
+	var err error
+
+	r.vars, err = r.resolveVars()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve vars: %w", err)
+	}
+
+	return r.expandXRFromXRD(r.testSuiteSpec.Tests)
+}
+
+// prepareRender prepares a test case of the testsuite to be rendered, with its inputs in dir/inputs.
+func (r *Runner) prepareRender(testCase api.TestCase, tests []api.TestCase, dir string) (*PreparedRender, error) {
+	if needs := r.renderDependencies(testCase, tests); len(needs) > 0 {
+		plural := pluralize.NewClient()
+
+		return nil, fmt.Errorf("test case '%s' uses the results of %s %s, so it can only run with xprin test", testCase.Name,
+			plural.Pluralize("test case", len(needs), false), strings.Join(needs, ", "))
+	}
+
+	r.inputsDir = filepath.Join(dir, "inputs")
+	if err := r.fs.MkdirAll(r.inputsDir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create inputs directory: %w", err)
+	}
+
+	if r.Debug {
+		utils.DebugPrintf("Preparing test case '%s' in %s\n", testCase.Name, r.inputsDir)
+	}
+
+	if err := r.prepareTestCase(&testCase, engine.NewTestSuiteResult(r.testSuiteFile, false)); err != nil {
+		return nil, err
+	}
+
+	commands, err := r.helpersCommands(testCase)
+	if err != nil {
+		return nil, err
+	}
+
+	renderArgs, err := r.renderArgs(testCase)
+	if err != nil {
+		return nil, err
+	}
+
+	commands = append(commands, RenderCommand{
+		Description: "Render the test case",
+		Args:        append([]string{r.Dependencies["crossplane"]}, renderArgs...),
+	})
+
+	return &PreparedRender{TestCase: testCase, InputsDir: r.inputsDir, Commands: commands}, nil
+}
+
+// RunRender runs the render command of a prepared test case and returns the rendered resources.
+func (r *Runner) RunRender(ctx context.Context, prepared *PreparedRender) ([]byte, error) {
+	render := prepared.Commands[len(prepared.Commands)-1].Args
+
+	if r.Debug {
+		utils.DebugPrintf("Running render command: %s\n", strings.Join(render, " "))
+	}
+
+	output, err := r.runCommand(ctx, render[0], render[1:]...)
+	if err != nil {
+		if isExitError(err) {
+			return nil, fmt.Errorf("render of test case '%s' failed: %w\n%s", prepared.TestCase.Name, err, strings.TrimSpace(string(output)))
+		}
+
+		return nil, fmt.Errorf("failed to run render: %w", err)
+	}
+
+	return output, nil
+}
+
+// selectTestCase returns the test case with an ID, or else the only test case with a name.
+func selectTestCase(tests []api.TestCase, selector string) (api.TestCase, error) {
+	var matches []api.TestCase
+
+	for _, testCase := range tests {
+		if testCase.ID != "" && testCase.ID == selector {
+			return testCase, nil
+		}
+
+		if testCase.Name == selector {
+			matches = append(matches, testCase)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		names := make([]string, 0, len(tests))
+		for _, testCase := range tests {
+			names = append(names, fmt.Sprintf("'%s'", testCase.Name))
+		}
+
+		return api.TestCase{}, fmt.Errorf("no test case with id or name '%s' (test cases: %s)", selector, strings.Join(names, ", "))
+	case 1:
+		return matches[0], nil
+	default:
+		return api.TestCase{}, fmt.Errorf("%d test cases are named '%s', select one by its id (add an id to it if it has none)", len(matches), selector)
+	}
+}
+
+// renderDependencies returns the IDs of the test cases whose results the rendering of a test case uses: the ones
+// referenced as .Tests.<id> outside of its hooks, which do not run.
+func (r *Runner) renderDependencies(testCase api.TestCase, tests []api.TestCase) []string {
+	ids := make(map[string]bool)
+
+	for _, test := range tests {
+		if test.ID != "" {
+			ids[test.ID] = true
+		}
+	}
+
+	merged := testCase
+	if r.testSuiteSpec.HasCommon() {
+		merged.MergeCommon(r.testSuiteSpec.Common)
+	}
+
+	merged.Hooks = api.Hooks{}
+	merged.Needs = nil
+
+	return r.testCaseDependencies(merged, ids)
+}
+
+// helpersCommands returns the xprin-helpers commands that convert the Claim of a prepared test case to an XR and patch
+// the XR, as the test case does before rendering. The inline merge and JSON patches are written to the inputs
+// directory, so that the commands can read them.
+func (r *Runner) helpersCommands(testCase api.TestCase) ([]RenderCommand, error) {
+	var commands []RenderCommand
+
+	xr := testCase.Inputs.XR
+
+	if !testCase.HasXR() {
+		xr = filepath.Join(r.inputsDir, "xr.yaml")
+
+		commands = append(commands, RenderCommand{
+			Description: "Convert the Claim to an XR",
+			Args:        []string{helpersCommand, "convert-claim-to-xr", testCase.Inputs.Claim, "-o", xr},
+		})
+	}
+
+	if !testCase.HasPatches() {
+		return commands, nil
+	}
+
+	patches := testCase.Patches
+	args := []string{helpersCommand, "patch-xr", xr}
+
+	if patches.XRD != "" {
+		args = append(args, "--xrd", patches.XRD)
+	}
+
+	for _, set := range patches.Set {
+		value, err := yaml.Marshal(set.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal value of set patch %s: %w", set.Path, err)
+		}
+
+		args = append(args, "--set", fmt.Sprintf("%s=%s", set.Path, strings.TrimSpace(string(value))))
+	}
+
+	for i, merge := range patches.Merge {
+		path, err := r.writePatch(merge, fmt.Sprintf("merge-%d.yaml", i+1))
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, "--merge", path)
+	}
+
+	if len(patches.JSONPatch) > 0 {
+		path, err := r.writePatch(patches.JSONPatch, "jsonpatch.yaml")
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, "--jsonpatch", path)
+	}
+
+	if patches.HasConnectionSecret() {
+		args = append(args, "--add-connection-secret")
+
+		if patches.ConnectionSecretName != "" {
+			args = append(args, "--connection-secret-name", patches.ConnectionSecretName)
+		}
+
+		if patches.ConnectionSecretNamespace != "" {
+			args = append(args, "--connection-secret-namespace", patches.ConnectionSecretNamespace)
+		}
+	}
+
+	args = append(args, "-o", filepath.Join(r.inputsDir, "patched-xr.yaml"))
+
+	return append(commands, RenderCommand{Description: "Patch the XR", Args: args}), nil
+}
+
+// writePatch writes an inline patch to the patches directory of the inputs directory and returns its path.
+func (r *Runner) writePatch(patch any, filename string) (string, error) {
+	content, err := yaml.Marshal(patch)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal patch %s: %w", filename, err)
+	}
+
+	return r.writeInlineInput(content, "patches", filename)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestPrepareRender(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"claim.yaml", "composition.yaml", "functions.yaml"} {
+		content := "apiVersion: example.com/v1\nkind: Bucket\nmetadata:\n  name: bucket\n  namespace: default\nspec: {}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o600))
+	}
+
+	connectionSecret := true
+	spec := &api.TestSuiteSpec{
+		Common: api.Common{Inputs: api.Inputs{Composition: "composition.yaml", Functions: "functions.yaml"}},
+		Tests: []api.TestCase{
+			{
+				Name:   "patched claim",
+				ID:     "claim",
+				Inputs: api.Inputs{Claim: "claim.yaml"},
+				Patches: api.Patches{
+					Set:              []api.FieldSet{{Path: "spec.region", Value: "eu west"}},
+					Merge:            []map[string]any{{"metadata": map[string]any{"labels": map[string]any{"a": "b"}}}},
+					ConnectionSecret: &connectionSecret,
+				},
+				Hooks: api.Hooks{PreTest: []api.Hook{{Name: "not run", Run: "exit 1"}}},
+			},
+			{Name: "dependent", Inputs: api.Inputs{XR: "{{ .Tests.claim.Outputs.XR }}"}},
+			{Name: "same", Inputs: api.Inputs{Claim: "claim.yaml"}},
+			{Name: "same", Inputs: api.Inputs{Claim: "claim.yaml"}},
+		},
+	}
+
+	r := NewRunner(&testexecutionUtils.Options{Dependencies: map[string]string{"crossplane": "crossplane"}, Render: []string{"render"}},
+		filepath.Join(dir, "bucket_xprin.yaml"), spec)
+
+	outputDir := t.TempDir()
+	inputsDir := filepath.Join(outputDir, "inputs")
+
+	prepared, err := r.PrepareRender("claim", outputDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "patched claim", prepared.TestCase.Name)
+	assert.Equal(t, inputsDir, prepared.InputsDir)
+	assert.Equal(t, []RenderCommand{
+		{
+			Description: "Convert the Claim to an XR",
+			Args:        []string{"xprin-helpers", "convert-claim-to-xr", filepath.Join(inputsDir, "claim", "claim.yaml"), "-o", filepath.Join(inputsDir, "xr.yaml")},
+		},
+		{
+			Description: "Patch the XR",
+			Args: []string{
+				"xprin-helpers", "patch-xr", filepath.Join(inputsDir, "xr.yaml"), "--set", "spec.region=eu west",
+				"--merge", filepath.Join(inputsDir, "patches", "merge-1.yaml"), "--add-connection-secret", "-o", filepath.Join(inputsDir, "patched-xr.yaml"),
+			},
+		},
+		{
+			Description: "Render the test case",
+			Args: []string{
+				"crossplane", "render", filepath.Join(inputsDir, "patched-xr.yaml"),
+				filepath.Join(inputsDir, "composition", "composition.yaml"), filepath.Join(inputsDir, "functions", "functions.yaml"),
+			},
+		},
+	}, prepared.Commands)
+
+	for _, file := range []string{"xr.yaml", "patched-xr.yaml", filepath.Join("patches", "merge-1.yaml")} {
+		assert.FileExists(t, filepath.Join(inputsDir, file))
+	}
+
+	r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
+		assert.Equal(t, prepared.Commands[2].Args, append([]string{name}, args...))
+		return []byte("---\nkind: Bucket\n"), nil
+	}
+
+	output, err := r.RunRender(context.Background(), prepared)
+	require.NoError(t, err)
+	assert.Equal(t, "---\nkind: Bucket\n", string(output))
+
+	r.runCommand = func(_ context.Context, _ string, _ ...string) ([]byte, error) {
+		return []byte("crossplane: error: function failed\n"), &exec.ExitError{}
+	}
+
+	_, err = r.RunRender(context.Background(), prepared)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "render of test case 'patched claim' failed")
+	assert.Contains(t, err.Error(), "crossplane: error: function failed")
+
+	_, err = r.PrepareRender("dependent", t.TempDir())
+	require.EqualError(t, err, "test case 'dependent' uses the results of test case claim, so it can only run with xprin test")
+
+	_, err = r.PrepareRender("same", t.TempDir())
+	require.EqualError(t, err, "2 test cases are named 'same', select one by its id (add an id to it if it has none)")
+
+	_, err = r.PrepareRender("missing", t.TempDir())
+	require.EqualError(t, err, "no test case with id or name 'missing' (test cases: 'patched claim', 'dependent', 'same', 'same')")
+}
+
+func TestRenderCommandShellCommand(t *testing.T) {
+	command := RenderCommand{Args: []string{"xprin-helpers", "patch-xr", "/tmp/xr.yaml", "--set", "spec.name=it's here", "--set", "spec.empty="}}
+
+	assert.Equal(t, `xprin-helpers patch-xr /tmp/xr.yaml --set 'spec.name=it'\''s here' --set spec.empty=`, command.ShellCommand())
+	assert.Equal(t, "''", RenderCommand{Args: []string{""}}.ShellCommand())
+}
@@ -18,13 +18,18 @@ package runner
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
-	"text/template"
+	"time"
 
 	"github.com/crossplane-contrib/xprin/internal/api"
 	"github.com/crossplane-contrib/xprin/internal/engine"
@@ -36,6 +41,9 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// commandWaitDelay is how long to wait for the output of a command after it was killed, see exec.Cmd.WaitDelay.
+const commandWaitDelay = 5 * time.Second
+
 // Runner handles test execution.
 type Runner struct {
 	*testexecutionUtils.Options
@@ -50,12 +58,15 @@ type Runner struct {
 	outputsDir            string
 	testCaseTmpDir        string
 	testSuiteArtifactsDir string
+	// Template variables
+	vars map[string]string // Testsuite vars overridden by --var, resolved when the tests run
+	env  map[string]string // Environment variables
 	// Mockable function fields
 	runTestsFunc                      func() error
 	runTestCaseFunc                   func(api.TestCase) *engine.TestCaseResult
 	expandPathRelativeToTestSuiteFile func(base, path string) (string, error)
 	verifyPathExists                  func(path string) error
-	runCommand                        func(name string, args ...string) ([]byte, error)
+	runCommand                        func(ctx context.Context, name string, args ...string) ([]byte, error)
 	copy                              func(src, dest string, opts ...cp.Options) error
 	convertClaimToXRFunc              func(r *Runner, claimPath, outputPath string) (string, error)
 	patchXRFunc                       func(r *Runner, xrPath, outputPath string, patches api.Patches) (string, error)
@@ -65,6 +76,10 @@ type Runner struct {
 type templateContext struct {
 	// Repository variables (for compatibility with existing {{ .Repositories.name }} syntax)
 	Repositories map[string]string // Repository name to path mapping
+	// Testsuite variables, overridden by --var
+	Vars map[string]string
+	// Environment variables
+	Env map[string]string
 	// Input variables (available in hooks)
 	Inputs api.Inputs
 	// Output variables (available in post-test hooks)
@@ -77,6 +92,11 @@ type templateContext struct {
 func NewRunner(options *testexecutionUtils.Options, testSuiteFile string, testSuiteSpec *api.TestSuiteSpec) *Runner {
 	testSuiteFileDir := filepath.Dir(testSuiteFile)
 
+	var envNames []string
+	if testSuiteSpec != nil {
+		envNames = testSuiteSpec.Env
+	}
+
 	return &Runner{
 		fs:               afero.NewOsFs(),
 		output:           os.Stdout, // Default output to stdout
@@ -84,13 +104,16 @@ func NewRunner(options *testexecutionUtils.Options, testSuiteFile string, testSu
 		testSuiteFile:    testSuiteFile,
 		testSuiteFileDir: testSuiteFileDir,
 		testSuiteSpec:    testSuiteSpec,
+		env:              environ(envNames),
 		// Initialize mockable function fields with default implementations
 		runTestCaseFunc:                   nil, // will set default below
 		expandPathRelativeToTestSuiteFile: testexecutionUtils.ExpandPathRelativeToTestSuiteFile,
 		verifyPathExists:                  utils.VerifyPathExists,
-		runCommand: func(name string, args ...string) ([]byte, error) {
-			cmd := exec.Command(name, args...)
+		runCommand: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			cmd := exec.CommandContext(ctx, name, args...)
 			cmd.Dir = testSuiteFileDir
+			cmd.WaitDelay = commandWaitDelay
+			setProcessGroup(cmd)
 
 			var combined bytes.Buffer
 
@@ -107,21 +130,32 @@ func NewRunner(options *testexecutionUtils.Options, testSuiteFile string, testSu
 }
 
 // newTemplateContext creates a new template context with the given parameters.
-func newTemplateContext(repositories map[string]string, inputs api.Inputs, outputs *engine.Outputs, tests map[string]*engine.TestCaseResult) *templateContext {
+func newTemplateContext(repositories, vars, env map[string]string, inputs api.Inputs, outputs *engine.Outputs, tests map[string]*engine.TestCaseResult) *templateContext {
 	if repositories == nil {
 		repositories = make(map[string]string)
 	}
 
+	if vars == nil {
+		vars = make(map[string]string)
+	}
+
+	if env == nil {
+		env = make(map[string]string)
+	}
+
 	return &templateContext{
 		Repositories: repositories,
+		Vars:         vars,
+		Env:          env,
 		Inputs:       inputs,
 		Outputs:      outputs,
 		Tests:        tests,
 	}
 }
 
-// RunTests runs all tests in a test suite.
-func (r *Runner) RunTests() error {
+// RunTests runs all tests in a test suite. When ctx is done or the testsuite timeout expires, the running command is stopped;
+// the remaining test cases fail on a timeout and are not run on an interruption.
+func (r *Runner) RunTests(ctx context.Context) error {
 	if r.runTestsFunc != nil {
 		return r.runTestsFunc()
 	}
@@ -135,17 +169,25 @@ func (r *Runner) RunTests() error {
 		return fmt.Errorf("testsuite specification is required")
 	}
 
-	// Create testsuite artifacts directory (always created, cleaned up when testsuite finishes)
 	var err error
 
+	r.vars, err = r.resolveVars()
+	if err != nil {
+		return fmt.Errorf("failed to resolve vars: %w", err)
+	}
+
+	tests, err := r.expandXRFromXRD(r.selectedTestCases())
+	if err != nil {
+		return err
+	}
+
+	// Create testsuite artifacts directory (always created, cleaned up when testsuite finishes)
 	r.testSuiteArtifactsDir, err = afero.TempDir(r.fs, "", "xprin-testsuite-artifacts-")
 	if err != nil {
 		return fmt.Errorf("failed to create testsuite artifacts directory: %w", err)
 	}
 
-	defer func() {
-		_ = r.fs.RemoveAll(r.testSuiteArtifactsDir)
-	}()
+	defer r.removeTmpDir(r.testSuiteArtifactsDir)
 
 	if r.Debug {
 		utils.DebugPrintf("Created testsuite artifacts directory: %s\n", r.testSuiteArtifactsDir)
@@ -164,54 +206,110 @@ func (r *Runner) RunTests() error {
 		utils.DebugPrintf("Found %s\n", plural.Pluralize("test case", len(r.testSuiteSpec.Tests), true))
 	}
 
+	ctx, cancel := testexecutionUtils.WithTimeout(ctx, r.testSuiteSpec.TimeoutDuration(), "testsuite")
+	defer cancel()
+
 	// Create test suite result
 	testSuiteResult := engine.NewTestSuiteResult(r.testSuiteFile, r.Verbose)
 
-	// Loop through all test cases and run them directly
-	for _, testCase := range r.testSuiteSpec.Tests {
-		// Run the test and let the engine handle everything
-		testCaseResult := r.runTestCase(testCase, testSuiteResult)
+	// Loop through all test cases in dependency order and run them directly
+	for _, planned := range r.planTestCases(tests) {
+		// Stop on an interruption; on a timeout, the remaining test cases are reported as failed
+		if testexecutionUtils.IsCanceled(ctx) {
+			break
+		}
+
+		var testCaseResult *engine.TestCaseResult
+
+		if planned.testCase.IsSkipped() {
+			if r.Debug {
+				utils.DebugPrintf("Skipping test case '%s': %s\n", planned.testCase.Name, planned.testCase.Skip)
+			}
+
+			testCaseResult = r.newTestCaseResult(planned.testCase).SkipWithReason(planned.testCase.Skip)
+		} else if r.FailureBudget.Exhausted() {
+			testCaseResult = r.newTestCaseResult(planned.testCase).SkipWithReason(r.FailureBudget.SkipReason())
+		} else if planned.cycle != nil {
+			testCaseResult = r.newTestCaseResult(planned.testCase).Errored(dependencyCycleError(planned.cycle))
+		} else if reason := dependencySkipReason(planned.needs, testSuiteResult.GetCompletedTests()); reason != "" {
+			if r.Debug {
+				utils.DebugPrintf("Skipping test case '%s': %s\n", planned.testCase.Name, reason)
+			}
+
+			testCaseResult = r.newTestCaseResult(planned.testCase).SkipWithReason(reason)
+		} else if ctx.Err() != nil {
+			testCaseResult = stoppedResult(r.newTestCaseResult(planned.testCase), context.Cause(ctx), fmt.Errorf("not run: %w", context.Cause(ctx)))
+		} else {
+			// Run the test and let the engine handle everything
+			testCaseResult = r.runTestCase(ctx, planned.testCase, testSuiteResult)
+		}
+
+		if planned.testCase.IsExpectedToFail() {
+			testCaseResult.ExpectFailure(planned.testCase.XFail)
+		}
+
+		if testCaseResult.HasFailed() {
+			r.FailureBudget.AddFailure()
+		}
+
 		testCaseResult.Print(r.output) // Print immediately as test completes
 		testSuiteResult.AddResult(testCaseResult)
 	}
 
 	// Complete the test suite result
 	testSuiteResult.Complete()
+	r.Summary.AddTestSuite(testSuiteResult)
 
 	// Print only the file summary (not individual test results)
 	testSuiteResult.Print(r.output)
 
-	// Return error if any tests failed
+	if testexecutionUtils.IsCanceled(ctx) {
+		return fmt.Errorf("testsuite %s was canceled: %w", filepath.Base(r.testSuiteFile), context.Cause(ctx))
+	}
+
+	// Return error if any tests could not run or failed
+	if testSuiteResult.HasErrors() {
+		return fmt.Errorf("%w in testsuite %s", testexecutionUtils.ErrTestsErrored, filepath.Base(r.testSuiteFile))
+	}
+
 	if testSuiteResult.HasFailures() {
-		return fmt.Errorf("tests failed in testsuite %s", filepath.Base(r.testSuiteFile))
+		return fmt.Errorf("%w in testsuite %s", testexecutionUtils.ErrTestsFailed, filepath.Base(r.testSuiteFile))
 	}
 
 	return nil
 }
 
-// runTestCase executes a single test case and returns a complete TestCaseResult
+// runTestCase executes a single test case and returns a complete TestCaseResult.
+// The commands of the test case are stopped when its timeout expires or ctx is done.
 //
 //nolint:gocognit // Complex test case execution with multiple validation and execution phases
-func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.TestSuiteResult) *engine.TestCaseResult {
+func (r *Runner) runTestCase(ctx context.Context, testCase api.TestCase, testSuiteResult *engine.TestSuiteResult) *engine.TestCaseResult {
 	if r.runTestCaseFunc != nil {
 		return r.runTestCaseFunc(testCase)
 	}
 
+	ctx, cancel := testexecutionUtils.WithTimeout(ctx, testCase.TimeoutDuration(), "test case")
+	defer cancel()
+
 	if r.Debug {
 		utils.DebugPrintf("Starting test case '%s'\n", testCase.Name)
 	}
 
-	result := engine.NewTestCaseResult(testCase.Name, testCase.ID, r.Verbose, r.ShowRender, r.ShowValidate, r.ShowHooks, r.ShowAssertions)
+	result := r.newTestCaseResult(testCase)
 	// Create a temporary directory for the test case (with inputs and outputs subdirectories)
 	var err error
 
 	r.testCaseTmpDir, err = afero.TempDir(r.fs, "", "xprin-testcase-")
 	if err != nil {
-		return result.Fail(fmt.Errorf("failed to create temporary directory: %w", err))
+		return result.Errored(fmt.Errorf("failed to create temporary directory: %w", err))
 	}
 
 	defer func() {
-		_ = r.fs.RemoveAll(r.testCaseTmpDir)
+		if err := r.saveArtifacts(result); err != nil {
+			utils.WarningPrintf("failed to save artifacts of test case '%s': %v\n", testCase.Name, err)
+		}
+
+		r.removeTmpDir(r.testCaseTmpDir)
 	}()
 
 	// Create subdirectories for inputs and outputs
@@ -219,11 +317,11 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 
 	r.outputsDir = filepath.Join(r.testCaseTmpDir, "outputs")
 	if err := r.fs.MkdirAll(r.inputsDir, 0o750); err != nil {
-		return result.Fail(fmt.Errorf("failed to create inputs directory: %w", err))
+		return result.Errored(fmt.Errorf("failed to create inputs directory: %w", err))
 	}
 
 	if err := r.fs.MkdirAll(r.outputsDir, 0o750); err != nil {
-		return result.Fail(fmt.Errorf("failed to create outputs directory: %w", err))
+		return result.Errored(fmt.Errorf("failed to create outputs directory: %w", err))
 	}
 
 	if r.Debug {
@@ -232,23 +330,299 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		utils.DebugPrintf("- Outputs: %s\n", r.outputsDir)
 	}
 
+	if err := r.prepareTestCase(&testCase, testSuiteResult); err != nil {
+		return result.Errored(err)
+	}
+
+	// Execute pre-test hooks
+	if testCase.HasPreTestHooks() {
+		hookExecutor := newHookExecutor(r.Repositories, r.vars, r.env, r.Debug, r.runCommand, r.renderTemplate)
+
+		result.PreTestHooksResults, err = hookExecutor.executeHooks(ctx, testCase.Hooks.PreTest, "pre-test", testCase.Inputs, nil, testSuiteResult.GetCompletedTests())
+		result.ProcessPreTestHooksOutput()
+
+		if testexecutionUtils.IsStopped(err) {
+			return stoppedResult(result, err, nil)
+		}
+
+		if err != nil {
+			return result.Fail(nil)
+		}
+	}
+
+	renderArgs, err := r.renderArgs(testCase)
+	if err != nil {
+		return result.Errored(err)
+	}
+
+	// Run crossplane render command
+	if r.Debug {
+		utils.DebugPrintf("Running render command: %s %s\n", r.Dependencies["crossplane"], strings.Join(renderArgs, " "))
+	}
+
+	result.RawRenderOutput, err = r.runCommand(ctx, r.Dependencies["crossplane"], renderArgs...)
+	if stageErr := testexecutionUtils.StageError(ctx, "render"); stageErr != nil {
+		return stoppedResult(result, stageErr, stageErr)
+	}
+
+	if err != nil {
+		if !isExitError(err) {
+			return result.Errored(fmt.Errorf("failed to run render: %w", err))
+		}
+
+		if isEnvironmentRenderError(result.RawRenderOutput) {
+			return result.ErrorRender()
+		}
+
+		return result.FailRender()
+	}
+
+	// Write rendered output to the outputs directory
+	result.Outputs.Render = filepath.Join(r.outputsDir, "rendered.yaml")
+	if err := afero.WriteFile(r.fs, result.Outputs.Render, result.RawRenderOutput, 0o600); err != nil {
+		return result.Errored(fmt.Errorf("failed to write rendered output to temporary file: %w", err))
+	}
+
+	if r.Debug {
+		utils.DebugPrintf("Wrote rendered output to: %s\n", result.Outputs.Render)
+	}
+
+	// Process render output - this sets RenderedResources and FormattedRenderOutput
+	if err := result.ProcessRenderOutput(result.RawRenderOutput); err != nil {
+		return result.Errored(fmt.Errorf("failed to process render output: %w", err))
+	}
+
+	result.Outputs.RenderCount = len(result.RenderedResources)
+
+	if len(result.RenderedResources) > 0 {
+		// Create separate XR file with just the first resource
+		result.Outputs.XR = filepath.Join(r.outputsDir, "xr.yaml")
+
+		xrYAML, err := yaml.Marshal(result.RenderedResources[0])
+		if err != nil {
+			return result.Errored(fmt.Errorf("failed to marshal XR resource: %w", err))
+		}
+
+		if err := afero.WriteFile(r.fs, result.Outputs.XR, xrYAML, 0o600); err != nil {
+			return result.Errored(fmt.Errorf("failed to write XR file: %w", err))
+		}
+	}
+
+	// Process all resources for Rendered map (including XR)
+	for i, resource := range result.RenderedResources {
+		kind := resource.GetKind()
+		name := resource.GetName()
+
+		// Create filename: rendered-{kind}-{name}.yaml
+		filename := fmt.Sprintf("rendered-%s-%s.yaml", strings.ToLower(kind), name)
+		filepath := filepath.Join(r.outputsDir, filename)
+
+		// Marshal and write
+		resourceYAML, err := yaml.Marshal(resource)
+		if err != nil {
+			return result.Errored(fmt.Errorf("failed to marshal rendered resource %d: %w", i+1, err))
+		}
+
+		if err := afero.WriteFile(r.fs, filepath, resourceYAML, 0o600); err != nil {
+			return result.Errored(fmt.Errorf("failed to write rendered resource %d: %w", i+1, err))
+		}
+
+		// Add to Rendered map with string key containing slash
+		result.Outputs.Rendered[fmt.Sprintf("%s/%s", kind, name)] = filepath
+	}
+
+	var finalError []string
+	if len(testCase.Inputs.CRDs) >= 1 {
+		validateArgs := make([]string, 0, len(r.Validate)+3)
+		validateArgs = append(validateArgs, r.Validate...)
+		validateArgs = append(validateArgs, filepath.Join(r.inputsDir, "crds"), result.Outputs.Render)
+		// Run crossplane beta validate command
+		if r.Debug {
+			utils.DebugPrintf("Running validate command: %s %s\n", r.Dependencies["crossplane"], strings.Join(validateArgs, " "))
+		}
+
+		result.RawValidateOutput, err = r.runCommand(ctx, r.Dependencies["crossplane"], validateArgs...)
+		if stageErr := testexecutionUtils.StageError(ctx, "validate"); stageErr != nil {
+			return stoppedResult(result, stageErr, stageErr)
+		}
+
+		if err != nil && !isExitError(err) {
+			return result.Errored(fmt.Errorf("failed to run validate: %w", err))
+		}
+
+		if err != nil {
+			_ = result.MarkValidateFailed()
+		}
+
+		result.ProcessValidateOutput()
+
+		// Write validation output to the outputs directory
+		validateOutputFile := filepath.Join(r.outputsDir, "validate.txt")
+		if err := afero.WriteFile(r.fs, validateOutputFile, result.RawValidateOutput, 0o600); err != nil {
+			return result.Errored(fmt.Errorf("failed to write validation output to file: %w", err))
+		}
+
+		result.Outputs.Validate = &validateOutputFile
+
+		if r.Debug {
+			utils.DebugPrintf("Wrote validation output to: %s\n", validateOutputFile)
+		}
+	} else { //nolint:gocritic // keep the else block for visibility
+		if r.Debug {
+			utils.DebugPrintf("Skipped validate command \"%s %s\" because no CRDs were specified\n", r.Dependencies["crossplane"], strings.Join(r.Validate, " "))
+		}
+	}
+
+	// Execute assertions if any are defined (collect errors but don't fail immediately)
+	if testCase.HasAssertions() {
+		exec := newAssertionExecutor(
+			r.fs,
+			&result.Outputs,
+			r.Debug,
+			r.testSuiteFile,
+			r.expandPathRelativeToTestSuiteFile,
+			r.Color,
+		)
+
+		result.AssertionsResults = nil
+
+		if testCase.HasAssertionsXprin() {
+			if r.Debug {
+				utils.DebugPrintf("Executing %d xprin assertions for test case '%s'\n", len(testCase.Assertions.Xprin), testCase.Name)
+			}
+
+			result.AssertionsResults = append(result.AssertionsResults, exec.executeAssertionsXprin(testCase.Assertions.Xprin)...)
+		}
+
+		if testCase.HasAssertionsDiff() {
+			if r.Debug {
+				utils.DebugPrintf("Executing %d diff assertions for test case '%s'\n", len(testCase.Assertions.Diff), testCase.Name)
+			}
+
+			result.AssertionsResults = append(result.AssertionsResults, exec.executeAssertionsDiff(testCase.Assertions.Diff)...)
+		}
+
+		if testCase.HasAssertionsDyff() {
+			if r.Debug {
+				utils.DebugPrintf("Executing %d dyff assertions for test case '%s'\n", len(testCase.Assertions.Dyff), testCase.Name)
+			}
+
+			result.AssertionsResults = append(result.AssertionsResults, exec.executeAssertionsDyff(testCase.Assertions.Dyff)...)
+		}
+
+		// Format assertions output and set hasFailedAssertions
+		result.ProcessAssertionsOutput()
+
+		if result.HasFailedAssertions {
+			_ = result.MarkAssertionsFailed()
+		}
+
+		if r.Debug {
+			utils.DebugPrintf("Assertions executed\n")
+		}
+
+		// Write raw assertion results to assertions.txt (raw == all assertions, regardless of the Verbose or ShowAssertions flags)
+		assertionsFile := filepath.Join(r.outputsDir, "assertions.txt")
+		if err := afero.WriteFile(r.fs, assertionsFile, []byte(result.RawAssertionsOutput), 0o600); err != nil {
+			return result.Errored(fmt.Errorf("failed to write assertions output to file: %w", err))
+		}
+
+		result.Outputs.Assertions = &assertionsFile
+
+		if r.Debug {
+			utils.DebugPrintf("Wrote assertions output to: %s\n", assertionsFile)
+		}
+	}
+
+	// Execute post-test hooks (after assertions)
+	if testCase.HasPostTestHooks() {
+		hookExecutor := newHookExecutor(r.Repositories, r.vars, r.env, r.Debug, r.runCommand, r.renderTemplate)
+
+		result.PostTestHooksResults, err = hookExecutor.executeHooks(ctx, testCase.Hooks.PostTest, "post-test", testCase.Inputs, &result.Outputs, testSuiteResult.GetCompletedTests())
+		result.ProcessPostTestHooksOutput()
+
+		if testexecutionUtils.IsStopped(err) {
+			return stoppedResult(result, err, nil)
+		}
+		// On post-test hook failure, section shows failed hooks; HasPipelineFailure() is true from results
+	}
+
+	// Copy outputs to testsuite artifacts directory
+	if testCase.ID != "" {
+		artifactsDir := filepath.Join(r.testSuiteArtifactsDir, testCase.ID)
+		if err := r.copy(r.outputsDir, artifactsDir); err != nil {
+			return result.Errored(fmt.Errorf("failed to copy outputs to testsuite artifacts directory: %w", err))
+		}
+
+		if r.Debug {
+			utils.DebugPrintf("Copied outputs to testsuite artifacts directory: %s\n", artifactsDir)
+		}
+
+		// Update Outputs paths to point to artifact paths for cross-test references
+		result.Outputs.Render = filepath.Join(artifactsDir, "rendered.yaml")
+
+		result.Outputs.XR = filepath.Join(artifactsDir, "xr.yaml")
+		if result.Outputs.Validate != nil {
+			*result.Outputs.Validate = filepath.Join(artifactsDir, "validate.txt")
+		}
+
+		if result.Outputs.Assertions != nil {
+			*result.Outputs.Assertions = filepath.Join(artifactsDir, "assertions.txt")
+		}
+
+		// Update Rendered map paths to point to artifact paths
+		for key, path := range result.Outputs.Rendered {
+			filename := filepath.Base(path)
+			result.Outputs.Rendered[key] = filepath.Join(artifactsDir, filename)
+		}
+	}
+
+	// Fail with infrastructure/non-section errors if any; otherwise fail with nil when pipeline failed
+	if len(finalError) > 0 {
+		return result.Fail(fmt.Errorf("%s", strings.Join(finalError, "\n")))
+	}
+
+	if result.HasPipelineFailure() {
+		return result.Fail(nil)
+	}
+
+	// Complete the test case result
+	if r.Debug {
+		utils.DebugPrintf("Test case '%s' completed with status: %s\n", testCase.Name, result.Status.String())
+	}
+
+	return result.Complete()
+}
+
+// prepareTestCase merges common into a test case, renders its templates, and expands, verifies and copies its inputs to
+// the inputs directory, so that every input of the test case is a path in it.
+//
+//nolint:gocognit // Expands, verifies and copies every kind of input
+func (r *Runner) prepareTestCase(testCase *api.TestCase, testSuiteResult *engine.TestSuiteResult) error {
+	var err error
+
 	if r.testSuiteSpec.HasCommon() {
 		testCase.MergeCommon(r.testSuiteSpec.Common)
 	}
 
 	// Process template variables for this test case
-	if err := r.processTemplateVariables(&testCase, testSuiteResult); err != nil {
-		return result.Fail(fmt.Errorf("failed to process template variables: %w", err))
+	if err := r.processTemplateVariables(testCase, testSuiteResult); err != nil {
+		return fmt.Errorf("failed to process template variables: %w", err)
 	}
 
 	if err := testCase.CheckMandatoryFields(); err != nil {
-		return result.Fail(err)
+		return err
 	}
 
 	if r.Debug {
-		r.debugPrintTestCase(testCase, "Test specification:")
+		r.debugPrintTestCase(*testCase, "Test specification:")
 	}
 
+	// The paths are rewritten in place below, so copy the lists of paths the test case shares with common and other
+	// test cases, e.g. the ones generated by xr-from-xrd
+	testCase.Inputs.CRDs = slices.Clone(testCase.Inputs.CRDs)
+	testCase.Inputs.ContextFiles = maps.Clone(testCase.Inputs.ContextFiles)
+
 	// Always resolve compositionPath, functionPath and all the crdPaths relative to the testsuite file and verify they exist
 	// Only resolve Claim or XR path based on which input type is being used
 	var (
@@ -257,9 +631,9 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		anyPathExpanded     bool
 	)
 
-	// Expand input path based on type (Claim or XR)
+	// Expand input path based on type (Claim or XR); inline inputs have no path to expand
 
-	if testCase.HasXR() {
+	if testCase.Inputs.XR != "" {
 		if !filepath.IsAbs(testCase.Inputs.XR) {
 			anyPathExpanded = true
 		}
@@ -272,7 +646,7 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		if err := r.verifyPathExists(testCase.Inputs.XR); err != nil {
 			unverifiedPaths = append(unverifiedPaths, fmt.Sprintf("XR file not found: %v", err))
 		}
-	} else {
+	} else if testCase.Inputs.Claim != "" {
 		if !filepath.IsAbs(testCase.Inputs.Claim) {
 			anyPathExpanded = true
 		}
@@ -409,34 +783,34 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 
 	// Throw combined error if any paths failed to expand or verify
 	if len(failedExpandedPaths) > 0 || len(unverifiedPaths) > 0 {
-		return result.Fail(fmt.Errorf("failed to expand or verify paths: %s\n\t%s", strings.Join(failedExpandedPaths, "\n\t"), strings.Join(unverifiedPaths, "\n\t")))
+		return fmt.Errorf("failed to expand or verify paths: %s\n\t%s", strings.Join(failedExpandedPaths, "\n\t"), strings.Join(unverifiedPaths, "\n\t"))
 	}
 
 	if r.Debug && anyPathExpanded {
-		r.debugPrintTestCase(testCase, "Test specification with expanded input paths:")
+		r.debugPrintTestCase(*testCase, "Test specification with expanded input paths:")
 	}
 
 	// Copy all inputs to the temporary inputs directory
-	if testCase.HasXR() {
+	if testCase.Inputs.XR != "" {
 		testCase.Inputs.XR, err = r.copyInput(testCase.Inputs.XR, "xr")
 		if err != nil {
-			return result.Fail(err)
+			return err
 		}
-	} else {
+	} else if testCase.Inputs.Claim != "" {
 		testCase.Inputs.Claim, err = r.copyInput(testCase.Inputs.Claim, "claim")
 		if err != nil {
-			return result.Fail(err)
+			return err
 		}
 	}
 
 	testCase.Inputs.Composition, err = r.copyInput(testCase.Inputs.Composition, "composition")
 	if err != nil {
-		return result.Fail(err)
+		return err
 	}
 
 	testCase.Inputs.Functions, err = r.copyInput(testCase.Inputs.Functions, "functions")
 	if err != nil {
-		return result.Fail(err)
+		return err
 	}
 
 	crdsDir := filepath.Join(r.inputsDir, "crds")
@@ -447,57 +821,60 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 
 		testCase.Inputs.CRDs[i], err = r.copyToPath(crdPath, dest)
 		if err != nil {
-			return result.Fail(err)
+			return err
 		}
 	}
 
 	for key, contextFile := range testCase.Inputs.ContextFiles {
 		testCase.Inputs.ContextFiles[key], err = r.copyInput(contextFile, "context-files")
 		if err != nil {
-			return result.Fail(err)
+			return err
 		}
 	}
 
 	if testCase.Inputs.ObservedResources != "" {
 		testCase.Inputs.ObservedResources, err = r.copyInput(testCase.Inputs.ObservedResources, "observed-resources")
 		if err != nil {
-			return result.Fail(err)
+			return err
 		}
 	}
 
 	if testCase.Inputs.ExtraResources != "" {
 		testCase.Inputs.ExtraResources, err = r.copyInput(testCase.Inputs.ExtraResources, "extra-resources")
 		if err != nil {
-			return result.Fail(err)
+			return err
 		}
 	}
 
 	if testCase.Inputs.FunctionCredentials != "" {
 		testCase.Inputs.FunctionCredentials, err = r.copyInput(testCase.Inputs.FunctionCredentials, "function-credentials")
 		if err != nil {
-			return result.Fail(err)
+			return err
 		}
 	}
 
 	if testCase.Patches.XRD != "" {
 		testCase.Patches.XRD, err = r.copyInput(testCase.Patches.XRD, "xrd")
 		if err != nil {
-			return result.Fail(err)
+			return err
 		}
 	}
 
-	// Execute pre-test hooks
-	if testCase.HasPreTestHooks() {
-		hookExecutor := newHookExecutor(r.Repositories, r.Debug, r.runCommand, r.renderTemplate)
-
-		result.PreTestHooksResults, err = hookExecutor.executeHooks(testCase.Hooks.PreTest, "pre-test", testCase.Inputs, nil, testSuiteResult.GetCompletedTests())
-		result.ProcessPreTestHooksOutput()
-
-		if err != nil {
-			return result.Fail(nil)
+	// Write inline inputs to the temporary inputs directory, so that from here on every input is a path
+	if testCase.Inputs.Inline.HasInlineInputs() {
+		if err := r.writeInlineInputs(&testCase.Inputs); err != nil {
+			return err
 		}
 	}
 
+	return nil
+}
+
+// renderArgs returns the arguments of the render command of a prepared test case, after converting its Claim to an XR
+// and patching the XR in the inputs directory if needed.
+func (r *Runner) renderArgs(testCase api.TestCase) ([]string, error) {
+	var err error
+
 	// Handle XR input - either convert Claim to XR or use provided XR file
 	var inputXR string
 	if testCase.HasXR() {
@@ -510,7 +887,7 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		// Convert Claim to XR
 		inputXR, err = r.convertClaimToXRFunc(r, testCase.Inputs.Claim, r.inputsDir)
 		if err != nil {
-			return result.Fail(fmt.Errorf("failed to convert Claim: %w", err))
+			return nil, fmt.Errorf("failed to convert Claim: %w", err)
 		}
 	}
 
@@ -518,7 +895,7 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 	if testCase.HasPatches() {
 		inputXR, err = r.patchXRFunc(r, inputXR, r.inputsDir, testCase.Patches)
 		if err != nil {
-			return result.Fail(fmt.Errorf("failed to patch XR: %w", err))
+			return nil, fmt.Errorf("failed to patch XR: %w", err)
 		}
 	}
 
@@ -551,305 +928,111 @@ func (r *Runner) runTestCase(testCase api.TestCase, testSuiteResult *engine.Test
 		renderArgs = append(renderArgs, "--function-credentials", testCase.Inputs.FunctionCredentials)
 	}
 
-	// Run crossplane render command
-	if r.Debug {
-		utils.DebugPrintf("Running render command: %s %s\n", r.Dependencies["crossplane"], strings.Join(renderArgs, " "))
-	}
-
-	result.RawRenderOutput, err = r.runCommand(r.Dependencies["crossplane"], renderArgs...)
-	if err != nil {
-		return result.FailRender()
-	}
-
-	// Write rendered output to the outputs directory
-	result.Outputs.Render = filepath.Join(r.outputsDir, "rendered.yaml")
-	if err := afero.WriteFile(r.fs, result.Outputs.Render, result.RawRenderOutput, 0o600); err != nil {
-		return result.Fail(fmt.Errorf("failed to write rendered output to temporary file: %w", err))
-	}
-
-	if r.Debug {
-		utils.DebugPrintf("Wrote rendered output to: %s\n", result.Outputs.Render)
-	}
-
-	// Process render output - this sets RenderedResources and FormattedRenderOutput
-	if err := result.ProcessRenderOutput(result.RawRenderOutput); err != nil {
-		return result.Fail(fmt.Errorf("failed to process render output: %w", err))
-	}
-
-	result.Outputs.RenderCount = len(result.RenderedResources)
-
-	if len(result.RenderedResources) > 0 {
-		// Create separate XR file with just the first resource
-		result.Outputs.XR = filepath.Join(r.outputsDir, "xr.yaml")
-
-		xrYAML, err := yaml.Marshal(result.RenderedResources[0])
-		if err != nil {
-			return result.Fail(fmt.Errorf("failed to marshal XR resource: %w", err))
-		}
-
-		if err := afero.WriteFile(r.fs, result.Outputs.XR, xrYAML, 0o600); err != nil {
-			return result.Fail(fmt.Errorf("failed to write XR file: %w", err))
-		}
-	}
-
-	// Process all resources for Rendered map (including XR)
-	for i, resource := range result.RenderedResources {
-		kind := resource.GetKind()
-		name := resource.GetName()
-
-		// Create filename: rendered-{kind}-{name}.yaml
-		filename := fmt.Sprintf("rendered-%s-%s.yaml", strings.ToLower(kind), name)
-		filepath := filepath.Join(r.outputsDir, filename)
-
-		// Marshal and write
-		resourceYAML, err := yaml.Marshal(resource)
-		if err != nil {
-			return result.Fail(fmt.Errorf("failed to marshal rendered resource %d: %w", i+1, err))
-		}
-
-		if err := afero.WriteFile(r.fs, filepath, resourceYAML, 0o600); err != nil {
-			return result.Fail(fmt.Errorf("failed to write rendered resource %d: %w", i+1, err))
-		}
-
-		// Add to Rendered map with string key containing slash
-		result.Outputs.Rendered[fmt.Sprintf("%s/%s", kind, name)] = filepath
-	}
-
-	var finalError []string
-	if len(testCase.Inputs.CRDs) >= 1 {
-		validateArgs := make([]string, 0, len(r.Validate)+3)
-		validateArgs = append(validateArgs, r.Validate...)
-		validateArgs = append(validateArgs, filepath.Join(r.inputsDir, "crds"), result.Outputs.Render)
-		// Run crossplane beta validate command
-		if r.Debug {
-			utils.DebugPrintf("Running validate command: %s %s\n", r.Dependencies["crossplane"], strings.Join(validateArgs, " "))
-		}
-
-		result.RawValidateOutput, err = r.runCommand(r.Dependencies["crossplane"], validateArgs...)
-		if err != nil {
-			_ = result.MarkValidateFailed()
-		}
-
-		result.ProcessValidateOutput()
-
-		// Write validation output to the outputs directory
-		validateOutputFile := filepath.Join(r.outputsDir, "validate.txt")
-		if err := afero.WriteFile(r.fs, validateOutputFile, result.RawValidateOutput, 0o600); err != nil {
-			return result.Fail(fmt.Errorf("failed to write validation output to file: %w", err))
-		}
-
-		result.Outputs.Validate = &validateOutputFile
-
-		if r.Debug {
-			utils.DebugPrintf("Wrote validation output to: %s\n", validateOutputFile)
-		}
-	} else { //nolint:gocritic // keep the else block for visibility
-		if r.Debug {
-			utils.DebugPrintf("Skipped validate command \"%s %s\" because no CRDs were specified\n", r.Dependencies["crossplane"], strings.Join(r.Validate, " "))
-		}
-	}
-
-	// Execute assertions if any are defined (collect errors but don't fail immediately)
-	if testCase.HasAssertions() {
-		exec := newAssertionExecutor(
-			r.fs,
-			&result.Outputs,
-			r.Debug,
-			r.testSuiteFile,
-			r.expandPathRelativeToTestSuiteFile,
-			r.Color,
-		)
-
-		result.AssertionsResults = nil
-
-		if testCase.HasAssertionsXprin() {
-			if r.Debug {
-				utils.DebugPrintf("Executing %d xprin assertions for test case '%s'\n", len(testCase.Assertions.Xprin), testCase.Name)
-			}
-
-			result.AssertionsResults = append(result.AssertionsResults, exec.executeAssertionsXprin(testCase.Assertions.Xprin)...)
-		}
-
-		if testCase.HasAssertionsDiff() {
-			if r.Debug {
-				utils.DebugPrintf("Executing %d diff assertions for test case '%s'\n", len(testCase.Assertions.Diff), testCase.Name)
-			}
-
-			result.AssertionsResults = append(result.AssertionsResults, exec.executeAssertionsDiff(testCase.Assertions.Diff)...)
-		}
-
-		if testCase.HasAssertionsDyff() {
-			if r.Debug {
-				utils.DebugPrintf("Executing %d dyff assertions for test case '%s'\n", len(testCase.Assertions.Dyff), testCase.Name)
-			}
-
-			result.AssertionsResults = append(result.AssertionsResults, exec.executeAssertionsDyff(testCase.Assertions.Dyff)...)
-		}
-
-		// Format assertions output and set hasFailedAssertions
-		result.ProcessAssertionsOutput()
-
-		if result.HasFailedAssertions {
-			_ = result.MarkAssertionsFailed()
-		}
-
-		if r.Debug {
-			utils.DebugPrintf("Assertions executed\n")
-		}
-
-		// Write raw assertion results to assertions.txt (raw == all assertions, regardless of the Verbose or ShowAssertions flags)
-		assertionsFile := filepath.Join(r.outputsDir, "assertions.txt")
-		if err := afero.WriteFile(r.fs, assertionsFile, []byte(result.RawAssertionsOutput), 0o600); err != nil {
-			return result.Fail(fmt.Errorf("failed to write assertions output to file: %w", err))
-		}
+	return renderArgs, nil
+}
 
-		result.Outputs.Assertions = &assertionsFile
+// isExitError returns true if err is the error of a command that ran and exited with a non-zero exit code, and false if
+// the command could not be executed (e.g. crossplane is not installed).
+func isExitError(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr)
+}
 
-		if r.Debug {
-			utils.DebugPrintf("Wrote assertions output to: %s\n", assertionsFile)
+// isEnvironmentRenderError returns true if the render output shows that the functions could not be run because of
+// the environment rather than the composition (see renderEnvironmentErrors).
+func isEnvironmentRenderError(output []byte) bool {
+	for _, message := range renderEnvironmentErrors() {
+		if bytes.Contains(output, []byte(message)) {
+			return true
 		}
 	}
 
-	// Execute post-test hooks (after assertions)
-	if testCase.HasPostTestHooks() {
-		hookExecutor := newHookExecutor(r.Repositories, r.Debug, r.runCommand, r.renderTemplate)
-
-		result.PostTestHooksResults, _ = hookExecutor.executeHooks(testCase.Hooks.PostTest, "post-test", testCase.Inputs, &result.Outputs, testSuiteResult.GetCompletedTests())
-		result.ProcessPostTestHooksOutput()
-		// On post-test hook failure, section shows failed hooks; HasPipelineFailure() is true from results
-	}
-
-	// Copy outputs to testsuite artifacts directory
-	if testCase.ID != "" {
-		artifactsDir := filepath.Join(r.testSuiteArtifactsDir, testCase.ID)
-		if err := r.copy(r.outputsDir, artifactsDir); err != nil {
-			return result.Fail(fmt.Errorf("failed to copy outputs to testsuite artifacts directory: %w", err))
-		}
-
-		if r.Debug {
-			utils.DebugPrintf("Copied outputs to testsuite artifacts directory: %s\n", artifactsDir)
-		}
-
-		// Update Outputs paths to point to artifact paths for cross-test references
-		result.Outputs.Render = filepath.Join(artifactsDir, "rendered.yaml")
-
-		result.Outputs.XR = filepath.Join(artifactsDir, "xr.yaml")
-		if result.Outputs.Validate != nil {
-			*result.Outputs.Validate = filepath.Join(artifactsDir, "validate.txt")
-		}
-
-		if result.Outputs.Assertions != nil {
-			*result.Outputs.Assertions = filepath.Join(artifactsDir, "assertions.txt")
-		}
+	return false
+}
 
-		// Update Rendered map paths to point to artifact paths
-		for key, path := range result.Outputs.Rendered {
-			filename := filepath.Base(path)
-			result.Outputs.Rendered[key] = filepath.Join(artifactsDir, filename)
-		}
+// renderEnvironmentErrors returns the messages of the errors of crossplane render and of the Docker client it runs the
+// functions with, which show that the function runtime could not be reached (e.g. no Docker daemon or Podman socket),
+// or that the image of a function could not be pulled, created or started (e.g. a registry error or a missing image).
+func renderEnvironmentErrors() []string {
+	return []string{
+		// crossplane render
+		"cannot create Docker client",
+		"cannot start Function",
+		"cannot dial Function",
+		// Docker client
+		"Cannot connect to the Docker daemon",
+		"permission denied while trying to connect to the Docker daemon socket",
+		"error during connect",
 	}
+}
 
-	// Fail with infrastructure/non-section errors if any; otherwise fail with nil when pipeline failed
-	if len(finalError) > 0 {
-		return result.Fail(fmt.Errorf("%s", strings.Join(finalError, "\n")))
+// stoppedResult returns the result of a test case with a stage that returned err because it was stopped (see
+// testexecutionUtils.IsStopped): failed with reason if a timeout expired, since a test case that takes too long is a
+// failed test case, and errored with reason otherwise, e.g. because the run was interrupted.
+func stoppedResult(result *engine.TestCaseResult, err, reason error) *engine.TestCaseResult {
+	if testexecutionUtils.IsTimeout(err) {
+		return result.Fail(reason)
 	}
 
-	if result.HasPipelineFailure() {
-		return result.Fail(nil)
-	}
-
-	// Complete the test case result
-	if r.Debug {
-		utils.DebugPrintf("Test case '%s' completed with status: %s\n", testCase.Name, result.Status.String())
-	}
+	return result.Errored(reason)
+}
 
-	return result.Complete()
+// newTestCaseResult creates a new result for a test case, with the runner's formatting flags.
+func (r *Runner) newTestCaseResult(testCase api.TestCase) *engine.TestCaseResult {
+	return engine.NewTestCaseResult(testCase.Name, testCase.ID, r.Verbose, r.ShowRender, r.ShowValidate, r.ShowHooks, r.ShowAssertions)
 }
 
-// renderTemplate renders Go template syntax with the given context.
+// renderTemplate renders a template of the testsuite file with the given context.
+// Errors are prefixed with the location of the template in the testsuite file, when it is found there.
 func (r *Runner) renderTemplate(content string, templateContext *templateContext, templateName string) (string, error) {
 	// Parse and execute template
-	tmpl, err := template.New(templateName).Option("missingkey=error").Parse(content)
+	tmpl, err := newTemplate(templateName, r.templateFuncs()).Parse(testexecutionUtils.EscapeLiteralBraces(content))
 	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
+		return "", r.templateError(content, fmt.Errorf("failed to parse template: %w", err))
+	}
+
+	if err := r.checkEnvReferences(tmpl.Root); err != nil {
+		return "", r.templateError(content, err)
 	}
 
 	var buf strings.Builder
 	if err := tmpl.Execute(&buf, templateContext); err != nil {
-		return "", fmt.Errorf("failed to execute template: %w", err)
+		return "", r.templateError(content, fmt.Errorf("failed to execute template: %w", err))
 	}
 
 	return buf.String(), nil
 }
 
-// processTemplateVariables processes template variables for a test case.
+// processTemplateVariables renders the templates in the string values of a test case (pre-render phase).
+// Hooks are rendered when they are executed.
 func (r *Runner) processTemplateVariables(testCase *api.TestCase, testSuiteResult *engine.TestSuiteResult) error {
+	withoutHooks := *testCase
+	withoutHooks.Hooks = api.Hooks{}
+
 	// Check if there are any template variables by converting to YAML temporarily
-	yamlData, err := yaml.Marshal(testCase)
+	yamlData, err := yaml.Marshal(withoutHooks)
 	if err != nil {
 		return fmt.Errorf("failed to marshal test case to YAML: %w", err)
 	}
 
-	content := string(yamlData)
-
-	// Check if there are any template variables
-	if !strings.Contains(content, testexecutionUtils.PlaceholderOpen) {
+	if !testexecutionUtils.HasTemplate(string(yamlData)) {
 		return nil // No template variables to process
 	}
 
-	// Preserve hooks from testCase before removeHooks modifies them
-	originalHooks := testCase.Hooks
-
-	content, err = r.removeHooks(testCase)
-	if err != nil {
-		return fmt.Errorf("failed to remove hooks from YAML: %w", err)
+	// Render a copy of the test case, so that the values it shares with common and other test cases are not modified
+	var rendered api.TestCase
+	if err := yaml.Unmarshal(yamlData, &rendered); err != nil {
+		return fmt.Errorf("failed to copy test case: %w", err)
 	}
 
-	content = testexecutionUtils.RestoreTemplateVars(content)
-
-	// Render template
-	templateContext := newTemplateContext(r.Repositories, testCase.Inputs, nil, testSuiteResult.GetCompletedTests())
-
-	content, err = r.renderTemplate(content, templateContext, "testcase")
-	if err != nil {
-		return fmt.Errorf("failed to render template: %w", err)
-	}
-
-	// Parse the processed YAML back to test case and restore hooks
-	if err := r.restoreHooks(content, testCase, originalHooks); err != nil {
-		return fmt.Errorf("failed to restore hooks: %w", err)
-	}
-
-	return nil
-}
-
-// removeHooks removes all hooks from the test case.
-func (r *Runner) removeHooks(testCase *api.TestCase) (string, error) {
-	if testCase.Hooks.PreTest != nil {
-		testCase.Hooks.PreTest = nil
-	}
-
-	if testCase.Hooks.PostTest != nil {
-		testCase.Hooks.PostTest = nil
-	}
-
-	yamlData, err := yaml.Marshal(testCase)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal test case to YAML: %w", err)
-	}
-
-	return string(yamlData), nil
-}
+	templateContext := newTemplateContext(r.Repositories, r.vars, r.env, testCase.Inputs, nil, testSuiteResult.GetCompletedTests())
 
-// restoreHooks parses the processed YAML back to test case and restores hooks.
-func (r *Runner) restoreHooks(content string, testCase *api.TestCase, originalHooks api.Hooks) error {
-	// Parse the processed YAML back to test case
-	if err := yaml.Unmarshal([]byte(content), testCase); err != nil {
-		return fmt.Errorf("failed to parse processed YAML: %w", err)
+	if err := r.renderValues(reflect.ValueOf(&rendered).Elem(), "", templateContext); err != nil {
+		return err
 	}
 
-	// Restore hooks (they will be processed in executeHooks with the correct context)
-	testCase.Hooks = originalHooks
+	rendered.Hooks = testCase.Hooks
+	*testCase = rendered
 
 	return nil
 }
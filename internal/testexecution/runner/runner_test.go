@@ -18,6 +18,7 @@ package runner
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -35,7 +36,6 @@ import (
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
 	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
-	"sigs.k8s.io/yaml"
 )
 
 const testSuiteFile = "/suite_xprin.yaml"
@@ -176,7 +176,7 @@ func TestNewRunner_SetsWorkingDirectory(t *testing.T) {
 	// Verify that runCommand sets cmd.Dir correctly by capturing it
 	var capturedDir string
 
-	runner.runCommand = func(name string, args ...string) ([]byte, error) {
+	runner.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 		cmd := exec.Command(name, args...)
 		// The original runCommand sets cmd.Dir = testSuiteFileDir (captured in closure)
 		// We verify this by checking that the closure has access to the correct value
@@ -187,7 +187,7 @@ func TestNewRunner_SetsWorkingDirectory(t *testing.T) {
 	}
 
 	// Execute a command through runCommand
-	_, _ = runner.runCommand("echo", "test")
+	_, _ = runner.runCommand(context.Background(), "echo", "test")
 
 	assert.Equal(t, expectedDir, capturedDir, "runCommand should set cmd.Dir to testsuite file directory")
 
@@ -204,7 +204,7 @@ func TestNewTemplateContext(t *testing.T) {
 		inputs := api.Inputs{XR: "test-xr.yaml"}
 		outputs := &engine.Outputs{Render: "rendered.yaml"}
 
-		context := newTemplateContext(repos, inputs, outputs, map[string]*engine.TestCaseResult{})
+		context := newTemplateContext(repos, nil, nil, inputs, outputs, map[string]*engine.TestCaseResult{})
 
 		assert.Equal(t, repos, context.Repositories)
 		assert.Equal(t, inputs, context.Inputs)
@@ -214,7 +214,7 @@ func TestNewTemplateContext(t *testing.T) {
 	t.Run("with nil repositories", func(t *testing.T) {
 		inputs := api.Inputs{XR: "test-xr.yaml"}
 
-		context := newTemplateContext(nil, inputs, nil, map[string]*engine.TestCaseResult{})
+		context := newTemplateContext(nil, nil, nil, inputs, nil, map[string]*engine.TestCaseResult{})
 
 		assert.NotNil(t, context.Repositories)
 		assert.Empty(t, context.Repositories)
@@ -226,7 +226,7 @@ func TestNewTemplateContext(t *testing.T) {
 		repos := map[string]string{"myrepo": "/path/to/repo"}
 		inputs := api.Inputs{XR: "test-xr.yaml"}
 
-		context := newTemplateContext(repos, inputs, nil, map[string]*engine.TestCaseResult{})
+		context := newTemplateContext(repos, nil, nil, inputs, nil, map[string]*engine.TestCaseResult{})
 
 		assert.Equal(t, repos, context.Repositories)
 		assert.Equal(t, inputs, context.Inputs)
@@ -305,7 +305,7 @@ func TestRunTests(t *testing.T) {
 				return createTestCaseResult(testCase.Name, false, err)
 			}
 
-			err := runner.RunTests()
+			err := runner.RunTests(context.Background())
 			if tc.wantFails > 0 {
 				require.Error(t, err)
 			} else {
@@ -456,7 +456,7 @@ func TestRunTestsIntegration(t *testing.T) {
 			runner.runTestCaseFunc = tc.runFunc
 			runner.Verbose = tc.verbose // set per-test verbosity
 
-			err := runner.RunTests()
+			err := runner.RunTests(context.Background())
 			if tc.wantError {
 				require.Error(t, err)
 			} else {
@@ -521,7 +521,7 @@ func TestRunTestsIntegration(t *testing.T) {
 
 			return createTestCaseResult(tc.Name, runner.Verbose, err)
 		}
-		err := runner.RunTests()
+		err := runner.RunTests(context.Background())
 		require.Error(t, err)
 
 		out := buf.String()
@@ -571,7 +571,7 @@ func TestRunTestsIntegration(t *testing.T) {
 
 			return createTestCaseResult(tc.Name, false, err)
 		}
-		err := runner.RunTests()
+		err := runner.RunTests(context.Background())
 		require.Error(t, err)
 
 		out := buf.String()
@@ -608,10 +608,11 @@ func TestRunTestCase(t *testing.T) {
 	}
 
 	cases := []struct {
-		name      string
-		testCase  api.TestCase
-		setup     func(*Runner)
-		wantError string
+		name       string
+		testCase   api.TestCase
+		setup      func(*Runner)
+		wantError  string
+		wantStatus engine.Status
 	}{
 		// Validation Tests
 		{
@@ -623,7 +624,7 @@ func TestRunTestCase(t *testing.T) {
 					Functions:   "functions.yaml",
 				},
 			},
-			wantError: "missing mandatory field: either 'claim' or 'xr' must be specified",
+			wantError: "missing mandatory field: either 'claim', 'xr' or 'xr-from-xrd' must be specified",
 		},
 		{
 			name: "missing composition and not in common",
@@ -694,7 +695,7 @@ func TestRunTestCase(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock the runCommand function to return an error for convert-claim-to-xr
-				r.runCommand = func(name string, _ ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, _ ...string) ([]byte, error) {
 					if name == "convert-claim-to-xr" {
 						return []byte("convert fail"), fmt.Errorf("fail")
 					}
@@ -716,9 +717,9 @@ func TestRunTestCase(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock the runCommand function to return an error for crossplane render
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
-						return []byte("render fail"), fmt.Errorf("fail")
+						return []byte("render fail"), exec.Command("sh", "-c", "exit 1").Run()
 					}
 
 					return []byte{}, nil
@@ -726,6 +727,44 @@ func TestRunTestCase(t *testing.T) {
 			},
 			wantError: "", // Render failure: Error is nil, failure shown in section
 		},
+		{
+			name: "render cannot be executed",
+			testCase: api.TestCase{
+				Name: "test",
+				Inputs: api.Inputs{
+					XR:          "xr.yaml",
+					Composition: "comp.yaml",
+					Functions:   "functions.yaml",
+				},
+			},
+			setup: func(r *Runner) {
+				r.runCommand = func(_ context.Context, _ string, _ ...string) ([]byte, error) {
+					return nil, exec.ErrNotFound
+				}
+			},
+			wantError: "failed to run render: executable file not found",
+		},
+		{
+			name: "render without docker daemon",
+			testCase: api.TestCase{
+				Name: "test",
+				Inputs: api.Inputs{
+					XR:          "xr.yaml",
+					Composition: "comp.yaml",
+					Functions:   "functions.yaml",
+				},
+			},
+			setup: func(r *Runner) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
+					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
+						return []byte("crossplane: error: cannot start Function runtime: Cannot connect to the Docker daemon at unix:///var/run/docker.sock"), exec.Command("sh", "-c", "exit 1").Run()
+					}
+
+					return []byte{}, nil
+				}
+			},
+			wantStatus: engine.StatusError(),
+		},
 		{
 			name: "validate fails",
 			testCase: api.TestCase{
@@ -739,9 +778,9 @@ func TestRunTestCase(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock the runCommand function to return an error for crossplane validate
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 1 && args[0] == config.ValidateSubcommand {
-						return []byte("validate fail"), fmt.Errorf("fail")
+						return []byte("validate fail"), exec.Command("sh", "-c", "exit 1").Run()
 					}
 
 					return validRenderYAML, nil
@@ -767,9 +806,9 @@ func TestRunTestCase(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock the runCommand function to return an error for crossplane validate
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 1 && args[0] == config.ValidateSubcommand {
-						return []byte("validate fail"), fmt.Errorf("fail")
+						return []byte("validate fail"), exec.Command("sh", "-c", "exit 1").Run()
 					}
 					// Mock successful hook execution
 					if name == "sh" && len(args) > 0 && args[0] == "-c" {
@@ -799,9 +838,9 @@ func TestRunTestCase(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock the runCommand function
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 1 && args[0] == config.ValidateSubcommand {
-						return []byte("validate fail"), fmt.Errorf("fail")
+						return []byte("validate fail"), exec.Command("sh", "-c", "exit 1").Run()
 					}
 					// Mock failing hook execution
 					if name == "sh" && len(args) > 0 && args[0] == "-c" {
@@ -826,13 +865,13 @@ func TestRunTestCase(t *testing.T) {
 					PostTest: []api.Hook{
 						{
 							Name: "templated-cleanup",
-							Run:  fmt.Sprintf("echo 'value: %s.Outputs.UnknownField%s'", testexecutionUtils.PlaceholderOpen, testexecutionUtils.PlaceholderClose),
+							Run:  "echo 'value: {{ .Outputs.UnknownField }}'",
 						},
 					},
 				},
 			},
 			setup: func(r *Runner) {
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -860,7 +899,7 @@ func TestRunTestCase(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock the runCommand function to return success
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -891,7 +930,7 @@ func TestRunTestCase(t *testing.T) {
 					return filepath.Join(outputPath, "xr.yaml"), nil
 				}
 				// Mock the runCommand function to return success for all operations
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -930,7 +969,7 @@ func TestRunTestCase(t *testing.T) {
 					return filepath.Join(outputPath, "patched-xr.yaml"), nil
 				}
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -971,7 +1010,7 @@ func TestRunTestCase(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock the runCommand function to return success for all operations
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -1005,7 +1044,7 @@ func TestRunTestCase(t *testing.T) {
 					return filepath.Join(outputPath, "patched-xr.yaml"), nil
 				}
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -1040,7 +1079,7 @@ func TestRunTestCase(t *testing.T) {
 					return filepath.Join(outputPath, "patched-xr.yaml"), nil
 				}
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -1091,7 +1130,7 @@ func TestRunTestCase(t *testing.T) {
 					return filepath.Join(outputPath, "patched-xr.yaml"), nil
 				}
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -1122,7 +1161,7 @@ func TestRunTestCase(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -1157,7 +1196,7 @@ func TestRunTestCase(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -1195,7 +1234,7 @@ func TestRunTestCase(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -1230,7 +1269,7 @@ func TestRunTestCase(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results for crossplane commands
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -1266,7 +1305,7 @@ func TestRunTestCase(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results for crossplane commands
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -1300,12 +1339,14 @@ func TestRunTestCase(t *testing.T) {
 			}
 
 			testSuiteResult := engine.NewTestSuiteResult("test-suite.yaml", false)
-			result := testRunner.runTestCase(tc.testCase, testSuiteResult)
+			result := testRunner.runTestCase(context.Background(), tc.testCase, testSuiteResult)
 
 			if tc.wantError != "" {
-				assert.Equal(t, engine.StatusFail(), result.Status)
+				assert.Equal(t, engine.StatusError(), result.Status)
 				require.Error(t, result.Error)
 				assert.Contains(t, result.Error.Error(), tc.wantError)
+			} else if tc.wantStatus != (engine.Status{}) {
+				assert.Equal(t, tc.wantStatus, result.Status)
 			} else if result.Status != engine.StatusFail() || result.Error != nil {
 				assert.Equal(t, engine.StatusPass(), result.Status)
 				require.NoError(t, result.Error)
@@ -1828,10 +1869,10 @@ func TestRunTestCase_CommonPathExpansionAndVerification(t *testing.T) {
 			}
 
 			testSuiteResult := engine.NewTestSuiteResult("test-suite.yaml", false)
-			result := testRunner.runTestCase(tc.testCase, testSuiteResult)
+			result := testRunner.runTestCase(context.Background(), tc.testCase, testSuiteResult)
 
 			if tc.wantError != "" {
-				assert.Equal(t, engine.StatusFail(), result.Status)
+				assert.Equal(t, engine.StatusError(), result.Status)
 				require.Error(t, result.Error)
 				assert.Contains(t, result.Error.Error(), tc.wantError)
 			} else {
@@ -2319,10 +2360,10 @@ func TestRunTestCase_LocalPathExpansionAndVerification(t *testing.T) {
 			}
 
 			testSuiteResult := engine.NewTestSuiteResult("test-suite.yaml", false)
-			result := testRunner.runTestCase(tc.testCase, testSuiteResult)
+			result := testRunner.runTestCase(context.Background(), tc.testCase, testSuiteResult)
 
 			if tc.wantError != "" {
-				assert.Equal(t, engine.StatusFail(), result.Status)
+				assert.Equal(t, engine.StatusError(), result.Status)
 				require.Error(t, result.Error)
 				assert.Contains(t, result.Error.Error(), tc.wantError)
 			} else {
@@ -2379,7 +2420,7 @@ func TestRunTestCase_MergeCommon(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -2416,7 +2457,7 @@ func TestRunTestCase_MergeCommon(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -2454,7 +2495,7 @@ func TestRunTestCase_MergeCommon(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -2492,7 +2533,7 @@ func TestRunTestCase_MergeCommon(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -2530,7 +2571,7 @@ func TestRunTestCase_MergeCommon(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -2568,7 +2609,7 @@ func TestRunTestCase_MergeCommon(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -2606,7 +2647,7 @@ func TestRunTestCase_MergeCommon(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -2646,7 +2687,7 @@ func TestRunTestCase_MergeCommon(t *testing.T) {
 			},
 			setup: func(r *Runner) {
 				// Mock runCommand to return successful results
-				r.runCommand = func(name string, args ...string) ([]byte, error) {
+				r.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 					if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 						return validRenderYAML, nil
 					}
@@ -2687,10 +2728,10 @@ func TestRunTestCase_MergeCommon(t *testing.T) {
 			}
 
 			testSuiteResult := engine.NewTestSuiteResult("test-suite.yaml", false)
-			result := testRunner.runTestCase(tc.testCase, testSuiteResult)
+			result := testRunner.runTestCase(context.Background(), tc.testCase, testSuiteResult)
 
 			if tc.wantError != "" {
-				assert.Equal(t, engine.StatusFail(), result.Status)
+				assert.Equal(t, engine.StatusError(), result.Status)
 				require.Error(t, result.Error)
 				assert.Contains(t, result.Error.Error(), tc.wantError)
 			} else {
@@ -2714,7 +2755,7 @@ func TestRunTestCase_UsesTestResultWithStartTime(t *testing.T) {
 	runner.testSuiteSpec = &api.TestSuiteSpec{}
 
 	// Mock runCommand to return successful results
-	runner.runCommand = func(name string, args ...string) ([]byte, error) {
+	runner.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 		if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 			return []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n"), nil
 		}
@@ -2736,7 +2777,7 @@ func TestRunTestCase_UsesTestResultWithStartTime(t *testing.T) {
 		},
 	}
 	testSuiteResult := engine.NewTestSuiteResult("test-suite.yaml", false)
-	result := runner.runTestCase(testCase, testSuiteResult)
+	result := runner.runTestCase(context.Background(), testCase, testSuiteResult)
 	assert.Equal(t, engine.StatusPass(), result.Status)
 	require.NoError(t, result.Error)
 	assert.False(t, result.StartTime.IsZero(), "TestCaseResult should have StartTime set")
@@ -2771,7 +2812,7 @@ func TestRunTestCase_SkipsValidateWhenNoCRDs(t *testing.T) {
 	}
 
 	// Mock runCommand to return successful results
-	runner.runCommand = func(name string, args ...string) ([]byte, error) {
+	runner.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 		if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 			return validRenderYAML, nil
 		}
@@ -2790,11 +2831,104 @@ func TestRunTestCase_SkipsValidateWhenNoCRDs(t *testing.T) {
 	}
 
 	testSuiteResult := engine.NewTestSuiteResult("test-suite.yaml", false)
-	result := runner.runTestCase(testCase, testSuiteResult)
+	result := runner.runTestCase(context.Background(), testCase, testSuiteResult)
 	assert.Equal(t, engine.StatusPass(), result.Status)
 	assert.NoError(t, result.Error)
 }
 
+func TestRunTests_SkipAndXFail(t *testing.T) {
+	var buf bytes.Buffer
+
+	suite := &api.TestSuiteSpec{Tests: []api.TestCase{
+		{Name: "skipped", ID: "skipped", Skip: "not ready"},
+		{Name: "needs skipped", Needs: []string{"skipped"}},
+		{Name: "expected failure", ID: "xfail", XFail: "known bug"},
+		{Name: "needs expected failure", Needs: []string{"xfail"}},
+		{Name: "passing", XFail: "fixed bug"},
+	}}
+
+	runner := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, suite)
+	runner.output = &buf
+
+	var ran []string
+
+	runner.runTestCaseFunc = func(tc api.TestCase) *engine.TestCaseResult {
+		ran = append(ran, tc.Name)
+
+		result := engine.NewTestCaseResult(tc.Name, tc.ID, false, false, false, false, false)
+		if tc.Name == "expected failure" {
+			return result.Fail(errors.New("known bug"))
+		}
+
+		return result.Complete()
+	}
+
+	err := runner.RunTests(context.Background())
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"expected failure", "passing"}, ran)
+
+	out := buf.String()
+	assert.Contains(t, out, "--- SKIP: skipped")
+	assert.Contains(t, out, "    not ready\n")
+	assert.Contains(t, out, "    needs test case 'skipped' which was skipped\n")
+	assert.Contains(t, out, "    needs test case 'xfail' which failed\n")
+	assert.NotContains(t, out, "--- FAIL: expected failure")
+	assert.Contains(t, out, "--- FAIL: passing")
+	assert.Contains(t, out, "expected to fail but passed: fixed bug")
+	assert.Contains(t, out, "skipped: skipped (not ready)\n")
+	assert.Contains(t, out, "expected failure: expected failure (known bug)\n")
+	assert.Contains(t, out, "unexpected pass: passing (fixed bug)\n")
+}
+
+func TestRunTests_FailureBudget(t *testing.T) {
+	var buf bytes.Buffer
+
+	options := &testexecutionUtils.Options{FailureBudget: testexecutionUtils.NewFailureBudget(2)}
+
+	var ran []string
+
+	runTestCase := func(tc api.TestCase) *engine.TestCaseResult {
+		ran = append(ran, tc.Name)
+
+		result := engine.NewTestCaseResult(tc.Name, tc.ID, false, false, false, false, false)
+		if strings.HasPrefix(tc.Name, "failing") || tc.XFail != "" {
+			return result.Fail(errors.New("broken composition"))
+		}
+
+		return result.Complete()
+	}
+
+	first := NewRunner(options, testSuiteFile, &api.TestSuiteSpec{Tests: []api.TestCase{
+		{Name: "failing 1"},
+		{Name: "expected failure", XFail: "known bug"},
+		{Name: "passing"},
+	}})
+	first.output = &buf
+	first.runTestCaseFunc = runTestCase
+
+	second := NewRunner(options, testSuiteFile, &api.TestSuiteSpec{Tests: []api.TestCase{
+		{Name: "failing 2"},
+		{Name: "failing 3"},
+		{Name: "skipped", Skip: "not ready"},
+	}})
+	second.output = &buf
+	second.runTestCaseFunc = runTestCase
+
+	require.Error(t, first.RunTests(context.Background()))
+	assert.False(t, options.FailureBudget.Exhausted(), "expected failures do not count")
+
+	require.Error(t, second.RunTests(context.Background()))
+	assert.True(t, options.FailureBudget.Exhausted())
+
+	assert.Equal(t, []string{"failing 1", "expected failure", "passing", "failing 2"}, ran)
+
+	out := buf.String()
+	assert.Contains(t, out, "--- SKIP: failing 3")
+	assert.Contains(t, out, "    not run: stopped after 2 failed test cases\n")
+	assert.Contains(t, out, "skipped: skipped (not ready)\n")
+}
+
 func TestRunTestsFileResults(t *testing.T) {
 	options := &testexecutionUtils.Options{
 		ShowRender:   false,
@@ -2886,7 +3020,7 @@ common:
 			"otherrepo": "/path/to/otherrepo",
 		}
 
-		templateContext := newTemplateContext(repos, api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(repos, nil, nil, api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -2908,7 +3042,7 @@ hooks:
 			Composition: "my-composition.yaml",
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, inputs, nil, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, nil, inputs, nil, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -2933,7 +3067,7 @@ hooks:
 			FunctionCredentials: "my-creds.yaml",
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, inputs, nil, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, nil, inputs, nil, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -2963,7 +3097,7 @@ hooks:
 			},
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, inputs, nil, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, nil, inputs, nil, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -2986,7 +3120,7 @@ hooks:
 			Render: "rendered-resources.yaml",
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3010,7 +3144,7 @@ hooks:
 			RenderCount: 5,
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3033,7 +3167,7 @@ hooks:
 			RenderCount: 3,
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3058,7 +3192,7 @@ hooks:
 			},
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3081,7 +3215,7 @@ hooks:
 			Assertions: &assertionsPath,
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3102,7 +3236,7 @@ hooks:
 			Assertions: nil, // No assertions run
 		}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3126,7 +3260,7 @@ hooks:
 		inputs := api.Inputs{XR: "test-xr.yaml"}
 		outputs := &engine.Outputs{XR: "rendered-xr.yaml"}
 
-		templateContext := newTemplateContext(repos, inputs, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(repos, nil, nil, inputs, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		out, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.NoError(t, err)
@@ -3148,7 +3282,7 @@ hooks:
 		yaml := "functions: {{ .Repositories.unknownrepo }}/foo"
 		repos := map[string]string{"myrepo": "/some/path"}
 
-		templateContext := newTemplateContext(repos, api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(repos, nil, nil, api.Inputs{}, nil, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		_, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.Error(t, err)
@@ -3159,7 +3293,7 @@ hooks:
 		yaml := "hooks:\n  pre-test:\n  - run: \"echo '{{ .Inputs.UnknownField }}'\""
 		inputs := api.Inputs{XR: "test-xr.yaml"}
 
-		templateContext := newTemplateContext(map[string]string{}, inputs, nil, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, nil, inputs, nil, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		_, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.Error(t, err)
@@ -3170,7 +3304,7 @@ hooks:
 		yaml := "hooks:\n  post-test:\n  - run: \"echo '{{ .Outputs.UnknownField }}'\""
 		outputs := &engine.Outputs{XR: "rendered-xr.yaml"}
 
-		templateContext := newTemplateContext(map[string]string{}, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
+		templateContext := newTemplateContext(map[string]string{}, nil, nil, api.Inputs{}, outputs, map[string]*engine.TestCaseResult{})
 		runner := &Runner{}
 		_, err := runner.renderTemplate(yaml, templateContext, "test")
 		require.Error(t, err)
@@ -3190,7 +3324,7 @@ func TestProcessTemplateVariables(t *testing.T) {
 		},
 		Hooks: api.Hooks{
 			PreTest: []api.Hook{
-				{Name: "pre-test", Run: fmt.Sprintf("echo 'Setting up %s'", testexecutionUtils.CreatePlaceholder(".Repositories.myrepo"))},
+				{Name: "pre-test", Run: "echo 'Setting up {{ .Repositories.myrepo }}'"},
 			},
 		},
 	}
@@ -3265,87 +3399,6 @@ metadata:
 	assert.Equal(t, "echo 'Setting up without templates'", testCase.Hooks.PreTest[0].Run)
 }
 
-// TestRemoveHooks tests the removeHooks function.
-func TestRemoveHooks(t *testing.T) {
-	runner := &Runner{}
-
-	// Create a test case with hooks
-	testCase := api.TestCase{
-		Name: "test",
-		ID:   "test-id",
-		Inputs: api.Inputs{
-			XR:          "xr.yaml",
-			Composition: "comp.yaml",
-		},
-		Hooks: api.Hooks{
-			PreTest: []api.Hook{
-				{Name: "pre-test", Run: "echo 'pre-test'"},
-			},
-			PostTest: []api.Hook{
-				{Name: "post-test", Run: "echo 'post-test'"},
-			},
-		},
-	}
-
-	// Remove all hooks
-	_, err := runner.removeHooks(&testCase)
-	require.NoError(t, err)
-
-	// Verify both pre-test and post-test hooks were removed
-	assert.Nil(t, testCase.Hooks.PreTest)
-	assert.Nil(t, testCase.Hooks.PostTest)
-}
-
-// TestRestoreHooks tests the restoreHooks function.
-func TestRestoreHooks(t *testing.T) {
-	runner := &Runner{}
-
-	// Original test case with hooks
-	originalTestCase := api.TestCase{
-		Name: "test",
-		ID:   "test-id",
-		Inputs: api.Inputs{
-			XR:          "xr.yaml",
-			Composition: "comp.yaml",
-		},
-		Hooks: api.Hooks{
-			PreTest: []api.Hook{
-				{Name: "pre-test", Run: "echo 'pre-test'"},
-			},
-			PostTest: []api.Hook{
-				{Name: "post-test", Run: "echo 'post-test'"},
-			},
-		},
-	}
-
-	// Create processed YAML without hooks (as would happen after template processing)
-	processedTestCase := api.TestCase{
-		Name: "test",
-		ID:   "test-id",
-		Inputs: api.Inputs{
-			XR:          "xr.yaml",
-			Composition: "comp.yaml",
-			// Hooks removed for template processing
-		},
-	}
-
-	processedYAML, err := yaml.Marshal(processedTestCase)
-	require.NoError(t, err)
-
-	// Restore hooks
-	originalHooks := originalTestCase.Hooks
-	err = runner.restoreHooks(string(processedYAML), &originalTestCase, originalHooks)
-	require.NoError(t, err)
-
-	// Verify hooks were restored
-	assert.Len(t, originalTestCase.Hooks.PreTest, 1)
-	assert.Equal(t, "pre-test", originalTestCase.Hooks.PreTest[0].Name)
-	assert.Len(t, originalTestCase.Hooks.PostTest, 1)
-	assert.Equal(t, "post-test", originalTestCase.Hooks.PostTest[0].Name)
-	assert.Equal(t, "echo 'pre-test'", originalTestCase.Hooks.PreTest[0].Run)
-	assert.Equal(t, "echo 'post-test'", originalTestCase.Hooks.PostTest[0].Run)
-}
-
 // TestRunTestCase_Outputs tests that output files are written to the outputs directory.
 func TestRunTestCase_Outputs(t *testing.T) {
 	fs := afero.NewMemMapFs()
@@ -3419,7 +3472,7 @@ metadata:
 	}
 
 	// Mock runCommand to return successful render and validate results
-	runner.runCommand = func(name string, args ...string) ([]byte, error) {
+	runner.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 		if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 			return validRenderYAML, nil
 		}
@@ -3444,7 +3497,7 @@ metadata:
 	}
 
 	// Call runapi.TestCase WITHOUT runTestCaseFunc - this executes the REAL code path
-	result := runner.runTestCase(testCase, testSuiteResult)
+	result := runner.runTestCase(context.Background(), testCase, testSuiteResult)
 	require.NoError(t, result.Error)
 	assert.Equal(t, engine.StatusPass(), result.Status)
 
@@ -3513,7 +3566,7 @@ func TestArtifactsDirectory(t *testing.T) {
 		// Before runTests, artifacts directory should not exist
 		assert.Empty(t, runner.testSuiteArtifactsDir, "artifacts directory should not exist before runTests")
 
-		err := runner.RunTests()
+		err := runner.RunTests(context.Background())
 		require.NoError(t, err)
 
 		// After runTests, artifacts directory should have been created (even though it's cleaned up by defer)
@@ -3631,7 +3684,7 @@ metadata:
 		}
 
 		// Mock runCommand to return successful render and validate results
-		runner.runCommand = func(name string, args ...string) ([]byte, error) {
+		runner.runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
 			if name == config.CrossplaneCmd && len(args) > 0 && args[0] == config.RenderSubcommand {
 				return validRenderYAML, nil
 			}
@@ -3656,7 +3709,7 @@ metadata:
 		}
 
 		// Call runTestCase WITHOUT runTestCaseFunc - this executes the REAL code path
-		result := runner.runTestCase(testCase, testSuiteResult)
+		result := runner.runTestCase(context.Background(), testCase, testSuiteResult)
 		require.NoError(t, result.Error)
 		assert.Equal(t, engine.StatusPass(), result.Status)
 
@@ -3722,7 +3775,7 @@ metadata:
 			return result.Complete()
 		}
 
-		result := runner.runTestCase(testCase, testSuiteResult)
+		result := runner.runTestCase(context.Background(), testCase, testSuiteResult)
 		require.NoError(t, result.Error)
 
 		// Verify copy was NOT called when ID is empty
@@ -3763,7 +3816,7 @@ metadata:
 		}
 
 		// Run first test case
-		result1 := runner.runTestCase(testCase1, testSuiteResult)
+		result1 := runner.runTestCase(context.Background(), testCase1, testSuiteResult)
 		require.NoError(t, result1.Error)
 
 		// Second test case - should have access to first test via GetCompletedTests
@@ -3772,7 +3825,7 @@ metadata:
 			ID:   "test2-id",
 			Hooks: api.Hooks{
 				PreTest: []api.Hook{
-					{Run: fmt.Sprintf("echo '%s.Tests.test1-id.Outputs.XR%s'", testexecutionUtils.PlaceholderOpen, testexecutionUtils.PlaceholderClose)},
+					{Run: "echo '{{ .Tests.test1-id.Outputs.XR }}'"},
 				},
 			},
 		}
@@ -3807,7 +3860,67 @@ metadata:
 		}
 
 		// Run second test case
-		result2 := runner.runTestCase(testCase2, testSuiteResult)
+		result2 := runner.runTestCase(context.Background(), testCase2, testSuiteResult)
 		require.NoError(t, result2.Error)
 	})
 }
+
+func TestIsEnvironmentRenderError(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{
+			name:   "no Docker daemon",
+			output: `crossplane: error: cannot render composite resource: pipeline step "patch" returned a fatal result: cannot start Function "function-patch-and-transform": cannot pull Docker image "xpkg.crossplane.io/crossplane-contrib/function-patch-and-transform:v0.8.2": Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?`,
+			want:   true,
+		},
+		{
+			name:   "no access to the Docker socket",
+			output: `crossplane: error: cannot start Function "function-auto-ready": cannot pull Docker image "xpkg.crossplane.io/crossplane-contrib/function-auto-ready:v0.5.0": permission denied while trying to connect to the Docker daemon socket at unix:///var/run/docker.sock`,
+			want:   true,
+		},
+		{
+			name:   "no Podman socket",
+			output: `crossplane: error: cannot start Function "function-auto-ready": cannot pull Docker image "xpkg.crossplane.io/crossplane-contrib/function-auto-ready:v0.5.0": error during connect: Post "http://%2Frun%2Fuser%2F1000%2Fpodman%2Fpodman.sock/v1.48/images/create": dial unix /run/user/1000/podman/podman.sock: connect: no such file or directory`,
+			want:   true,
+		},
+		{
+			name:   "registry pull failure",
+			output: `crossplane: error: cannot start Function "function-go-templating": cannot pull Docker image "xpkg.crossplane.io/crossplane-contrib/function-go-templating:v9.9.9": Error response from daemon: manifest unknown`,
+			want:   true,
+		},
+		{
+			name:   "missing image with pull policy Never",
+			output: `crossplane: error: cannot start Function "function-go-templating": cannot create Docker container: Error response from daemon: No such image: function-go-templating:dev`,
+			want:   true,
+		},
+		{
+			name:   "invalid Docker environment",
+			output: `crossplane: error: cannot start Function "function-go-templating": cannot create Docker client using environment variables: unable to parse docker host "tcp://"`,
+			want:   true,
+		},
+		{
+			name:   "function not running with the Development runtime",
+			output: `crossplane: error: cannot dial Function "function-go-templating" at address "localhost:9443": context deadline exceeded`,
+			want:   true,
+		},
+		{
+			name:   "fatal result of a function",
+			output: `crossplane: error: cannot render composite resource: pipeline step "patch" returned a fatal result: cannot apply patch "docker daemon" to resource "bucket"`,
+			want:   false,
+		},
+		{
+			name:   "invalid composition",
+			output: `crossplane: error: cannot load composition from "comp.yaml": cannot unmarshal composition`,
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isEnvironmentRenderError([]byte(tt.output)))
+		})
+	}
+}
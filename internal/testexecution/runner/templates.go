@@ -0,0 +1,311 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+)
+
+// newTemplate creates a template with the options and functions used for all testsuite templates.
+func newTemplate(name string, funcs template.FuncMap) *template.Template {
+	return template.New(name).Option("missingkey=error").Funcs(funcs)
+}
+
+// CheckTemplate parses a template of a testsuite file and checks that it only uses the template variables
+// available in the phase in which it is rendered.
+func CheckTemplate(name, content string, phase testexecutionUtils.TemplatePhase) error {
+	tmpl, err := newTemplate(name, (&Runner{}).templateFuncs()).Parse(testexecutionUtils.EscapeLiteralBraces(content))
+	if err != nil {
+		return err
+	}
+
+	available := phase.Variables()
+
+	for _, variable := range usedTemplateVariables(tmpl.Root) {
+		if slices.Contains(testexecutionUtils.TemplateVariables(), variable) && !slices.Contains(available, variable) {
+			return fmt.Errorf(".%s is not available in %s templates (available: .%s)", variable, phase, strings.Join(available, ", ."))
+		}
+	}
+
+	return nil
+}
+
+// usedTemplateVariables returns the top-level template variables used in a template, e.g. Outputs for .Outputs.XR.
+// References relative to a dot changed by range or with are ignored.
+func usedTemplateVariables(node parse.Node) []string {
+	var variables []string
+
+	for _, fields := range usedTemplateFields(node) {
+		variables = append(variables, fields[0])
+	}
+
+	return variables
+}
+
+// usedTemplateFields returns the field chains used in a template from the template variables, e.g. [Outputs XR]
+// for .Outputs.XR. References relative to a dot changed by range or with are ignored.
+func usedTemplateFields(node parse.Node) [][]string {
+	var fields [][]string
+
+	var walk func(node parse.Node, rootDot bool)
+
+	walk = func(node parse.Node, rootDot bool) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+
+			for _, child := range n.Nodes {
+				walk(child, rootDot)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe, rootDot)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+
+			for _, cmd := range n.Cmds {
+				walk(cmd, rootDot)
+			}
+		case *parse.CommandNode:
+			if keys, ok := indexedTemplateField(n, rootDot); ok {
+				fields = append(fields, keys)
+			}
+
+			for _, arg := range n.Args {
+				walk(arg, rootDot)
+			}
+		case *parse.FieldNode:
+			if rootDot {
+				fields = append(fields, n.Ident)
+			}
+		case *parse.VariableNode:
+			if len(n.Ident) > 1 && n.Ident[0] == "$" {
+				fields = append(fields, n.Ident[1:])
+			}
+		case *parse.IfNode:
+			walk(n.Pipe, rootDot)
+			walk(n.List, rootDot)
+			walk(n.ElseList, rootDot)
+		case *parse.RangeNode:
+			walk(n.Pipe, rootDot)
+			walk(n.List, false)
+			walk(n.ElseList, rootDot)
+		case *parse.WithNode:
+			walk(n.Pipe, rootDot)
+			walk(n.List, false)
+			walk(n.ElseList, rootDot)
+		}
+	}
+
+	walk(node, true)
+
+	return fields
+}
+
+// indexedTemplateField returns the field chain of an index command with constant keys on a template variable,
+// e.g. [Env HOME] for index .Env "HOME".
+func indexedTemplateField(cmd *parse.CommandNode, rootDot bool) ([]string, bool) {
+	if len(cmd.Args) < 3 {
+		return nil, false
+	}
+
+	if identifier, ok := cmd.Args[0].(*parse.IdentifierNode); !ok || identifier.Ident != "index" {
+		return nil, false
+	}
+
+	var fields []string
+
+	switch n := cmd.Args[1].(type) {
+	case *parse.FieldNode:
+		if !rootDot {
+			return nil, false
+		}
+
+		fields = slices.Clone(n.Ident)
+	case *parse.VariableNode:
+		if len(n.Ident) < 2 || n.Ident[0] != "$" {
+			return nil, false
+		}
+
+		fields = slices.Clone(n.Ident[1:])
+	default:
+		return nil, false
+	}
+
+	for _, arg := range cmd.Args[2:] {
+		key, ok := arg.(*parse.StringNode)
+		if !ok {
+			return nil, false
+		}
+
+		fields = append(fields, key.Text)
+	}
+
+	return fields, true
+}
+
+// renderValues renders the templates in the string values of v in place. path is the location of v in the test case,
+// used as template name. A value of type any that is a single template action is parsed as YAML after rendering,
+// so that e.g. a number stays a number.
+func (r *Runner) renderValues(v reflect.Value, path string, templateContext *templateContext) error {
+	switch v.Kind() { //nolint:exhaustive // other kinds cannot contain templates
+	case reflect.String:
+		if !testexecutionUtils.HasTemplate(v.String()) {
+			return nil
+		}
+
+		rendered, err := r.renderTemplate(v.String(), templateContext, path)
+		if err != nil {
+			return err
+		}
+
+		v.SetString(rendered)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			// Fields not in the testsuite file, like the inline inputs, are rendered under the path of their parent
+			fieldPath := path
+
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			switch name {
+			case "-":
+			case "":
+				fieldPath = joinTemplatePath(path, field.Name)
+			default:
+				fieldPath = joinTemplatePath(path, name)
+			}
+
+			if err := r.renderValues(v.Field(i), fieldPath, templateContext); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := r.renderValues(v.Index(i), fmt.Sprintf("%s[%d]", path, i), templateContext); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(fmt.Sprint(a), fmt.Sprint(b)) })
+
+		for _, key := range keys {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+
+			if err := r.renderValues(elem, joinTemplatePath(path, fmt.Sprint(key)), templateContext); err != nil {
+				return err
+			}
+
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+
+		if s, ok := v.Interface().(string); ok {
+			return r.renderAny(v, s, path, templateContext)
+		}
+
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+
+		if err := r.renderValues(elem, path, templateContext); err != nil {
+			return err
+		}
+
+		v.Set(elem)
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return r.renderValues(v.Elem(), path, templateContext)
+		}
+	}
+
+	return nil
+}
+
+// renderAny renders a string held by a value of type any. If the string is a single template action,
+// the rendered value is parsed as YAML.
+func (r *Runner) renderAny(v reflect.Value, s, path string, templateContext *templateContext) error {
+	if !testexecutionUtils.HasTemplate(s) {
+		return nil
+	}
+
+	rendered, err := r.renderTemplate(s, templateContext, path)
+	if err != nil {
+		return err
+	}
+
+	trimmed := strings.TrimSpace(s)
+	if strings.HasPrefix(trimmed, "{{") && strings.HasSuffix(trimmed, "}}") && strings.Count(trimmed, "{{") == 1 {
+		var parsed any
+		if err := yaml.Unmarshal([]byte(rendered), &parsed); err == nil && parsed != nil {
+			v.Set(reflect.ValueOf(parsed))
+			return nil
+		}
+	}
+
+	v.Set(reflect.ValueOf(rendered))
+
+	return nil
+}
+
+// joinTemplatePath joins the location of a value in a test case with the key of one of its children.
+func joinTemplatePath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// templateError prefixes a template error with the location of the template in the testsuite file, when it is found there.
+func (r *Runner) templateError(content string, err error) error {
+	if r.fs == nil || r.testSuiteFile == "" {
+		return err
+	}
+
+	data, readErr := afero.ReadFile(r.fs, r.testSuiteFile)
+	if readErr != nil {
+		return err
+	}
+
+	line := testexecutionUtils.FindScalarLine(data, content)
+	if line == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%s:%d: %w", filepath.Base(r.testSuiteFile), line, err)
+}
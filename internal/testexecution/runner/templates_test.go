@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestCheckTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		phase     testexecutionUtils.TemplatePhase
+		expectErr string
+	}{
+		{
+			name:    "variable available in phase",
+			content: "{{ .Repositories.myrepo }}/functions",
+			phase:   testexecutionUtils.PhasePreRender,
+		},
+		{
+			name:      "outputs before the test case ran",
+			content:   "cat {{ .Outputs.XR }}",
+			phase:     testexecutionUtils.PhasePreRender,
+			expectErr: ".Outputs is not available in pre-render templates",
+		},
+		{
+			name:    "outputs after the test case ran",
+			content: "cat {{ .Outputs.XR }}",
+			phase:   testexecutionUtils.PhasePostTest,
+		},
+		{
+			name:      "vars in testsuite vars",
+			content:   "{{ .Vars.other }}",
+			phase:     testexecutionUtils.PhaseLoad,
+			expectErr: ".Vars is not available in load templates (available: .Repositories, .Env)",
+		},
+		{
+			name:      "root variable",
+			content:   "{{ $.Inputs.XR }}",
+			phase:     testexecutionUtils.PhaseLoad,
+			expectErr: ".Inputs is not available in load templates",
+		},
+		{
+			name:    "dot changed by range",
+			content: "{{ range .Inputs.CRDs }}{{ .Outputs }}{{ end }}",
+			phase:   testexecutionUtils.PhasePreRender,
+		},
+		{
+			name:    "escaped braces",
+			content: `echo \{{ .Outputs.XR }}`,
+			phase:   testexecutionUtils.PhasePreRender,
+		},
+		{
+			name:      "invalid syntax",
+			content:   "{{ .Inputs.XR }",
+			phase:     testexecutionUtils.PhasePreRender,
+			expectErr: "unexpected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTemplate("test", tt.content, tt.phase)
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProcessTemplateVariables_Values(t *testing.T) {
+	runner := &Runner{
+		Options: &testexecutionUtils.Options{},
+		vars:    map[string]string{"replicas": "3", "region": "eu-west-1", "enabled": "true"},
+	}
+
+	testCase := api.TestCase{
+		Name: "values",
+		Inputs: api.Inputs{
+			XR:          "xr.yaml",
+			Composition: `echo \{{ literal }} {{ .Vars.region }}`,
+			Inline: api.InlineInputs{
+				Claim: map[string]any{"spec": map[string]any{"region": "{{ .Vars.region }}"}},
+			},
+		},
+		Patches: api.Patches{
+			Set: []api.FieldSet{
+				{Path: "spec.replicas", Value: "{{ .Vars.replicas }}"},
+				{Path: "spec.name", Value: "name-{{ .Vars.replicas }}"},
+				{Path: "spec.enabled", Value: "{{ .Vars.enabled }}"},
+			},
+			Merge: []map[string]any{
+				{"spec": map[string]any{"regions": []any{"{{ .Vars.region }}"}}},
+			},
+		},
+	}
+
+	err := runner.processTemplateVariables(&testCase, engine.NewTestSuiteResult("test-suite.yaml", false))
+	require.NoError(t, err)
+
+	assert.Equal(t, "echo {{ literal }} eu-west-1", testCase.Inputs.Composition)
+	assert.Equal(t, map[string]any{"spec": map[string]any{"region": "eu-west-1"}}, testCase.Inputs.Inline.Claim)
+	assert.InDelta(t, float64(3), testCase.Patches.Set[0].Value, 0)
+	assert.Equal(t, "name-3", testCase.Patches.Set[1].Value)
+	assert.Equal(t, true, testCase.Patches.Set[2].Value)
+	assert.Equal(t, map[string]any{"spec": map[string]any{"regions": []any{"eu-west-1"}}}, testCase.Patches.Merge[0])
+}
+
+func TestProcessTemplateVariables_SharedValuesNotModified(t *testing.T) {
+	runner := &Runner{
+		Options: &testexecutionUtils.Options{},
+		vars:    map[string]string{"dir": "crds"},
+	}
+
+	crds := []string{"{{ .Vars.dir }}/a.yaml"}
+	merge := []map[string]any{{"spec": map[string]any{"dir": "{{ .Vars.dir }}"}}}
+
+	testCase := api.TestCase{
+		Name:    "shared",
+		Inputs:  api.Inputs{XR: "xr.yaml", CRDs: crds},
+		Patches: api.Patches{Merge: merge},
+	}
+
+	err := runner.processTemplateVariables(&testCase, engine.NewTestSuiteResult("test-suite.yaml", false))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"crds/a.yaml"}, testCase.Inputs.CRDs)
+	assert.Equal(t, "{{ .Vars.dir }}/a.yaml", crds[0])
+	assert.Equal(t, map[string]any{"dir": "{{ .Vars.dir }}"}, merge[0]["spec"])
+}
+
+func TestProcessTemplateVariables_Errors(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	testSuiteFile := "/suite/suite_xprin.yaml"
+	require.NoError(t, afero.WriteFile(fs, testSuiteFile, []byte(`tests:
+- name: test1
+  inputs:
+    xr: xr.yaml
+    composition: "{{ .Vars.missing }}/composition.yaml"
+`), 0o644))
+
+	runner := &Runner{
+		Options:       &testexecutionUtils.Options{},
+		fs:            fs,
+		testSuiteFile: testSuiteFile,
+		vars:          map[string]string{},
+	}
+
+	testCase := api.TestCase{
+		Name:   "test1",
+		Inputs: api.Inputs{XR: "xr.yaml", Composition: "{{ .Vars.missing }}/composition.yaml"},
+	}
+
+	err := runner.processTemplateVariables(&testCase, engine.NewTestSuiteResult(testSuiteFile, false))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "suite_xprin.yaml:5: failed to execute template")
+	assert.Contains(t, err.Error(), "inputs.composition")
+	assert.Contains(t, err.Error(), `map has no entry for key "missing"`)
+}
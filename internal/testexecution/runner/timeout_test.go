@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/config"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestRunTestCase_Timeout(t *testing.T) {
+	validRenderYAML := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n")
+
+	tests := []struct {
+		name           string
+		hangOn         string
+		interrupted    bool
+		expectedStatus engine.Status
+		expectedErr    string
+	}{
+		{
+			name:           "render exceeds the test case timeout",
+			hangOn:         config.RenderSubcommand,
+			expectedStatus: engine.StatusFail(),
+			expectedErr:    "render timed out: test case timeout of 50ms exceeded",
+		},
+		{
+			name:           "validate exceeds the test case timeout",
+			hangOn:         config.ValidateSubcommand,
+			expectedStatus: engine.StatusFail(),
+			expectedErr:    "validate timed out: test case timeout of 50ms exceeded",
+		},
+		{
+			name:           "render is interrupted",
+			hangOn:         config.RenderSubcommand,
+			interrupted:    true,
+			expectedStatus: engine.StatusError(),
+			expectedErr:    "render was canceled: interrupted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := &testexecutionUtils.Options{
+				Dependencies: map[string]string{"crossplane": config.CrossplaneCmd},
+				Render:       []string{config.RenderSubcommand},
+				Validate:     []string{config.ValidateSubcommand},
+			}
+
+			runner := newMockRunner(options)
+			runner.testSuiteSpec = &api.TestSuiteSpec{}
+			runner.runCommand = func(ctx context.Context, _ string, args ...string) ([]byte, error) {
+				if args[0] == tt.hangOn {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				}
+
+				return validRenderYAML, nil
+			}
+
+			testCase := api.TestCase{
+				Name:    "slow",
+				Timeout: "50ms",
+				Inputs: api.Inputs{
+					XR:          "xr.yaml",
+					Composition: "comp.yaml",
+					Functions:   "functions.yaml",
+					CRDs:        []string{"crds.yaml"},
+				},
+			}
+
+			ctx, cancel := context.WithCancelCause(context.Background())
+			defer cancel(nil)
+
+			if tt.interrupted {
+				cancel(testexecutionUtils.ErrInterrupted)
+			}
+
+			result := runner.runTestCase(ctx, testCase, engine.NewTestSuiteResult(testSuiteFile, false))
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			require.Error(t, result.Error)
+			assert.Equal(t, tt.expectedErr, result.Error.Error())
+		})
+	}
+}
+
+func TestRunTests_Timeout(t *testing.T) {
+	var buf bytes.Buffer
+
+	suite := &api.TestSuiteSpec{
+		Timeout: "50ms",
+		Tests: []api.TestCase{
+			{Name: "slow"},
+			{Name: "not run"},
+			{Name: "skipped", Skip: "not ready"},
+		},
+	}
+
+	runner := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, suite)
+	runner.output = &buf
+
+	var ran []string
+
+	runner.runTestCaseFunc = func(tc api.TestCase) *engine.TestCaseResult {
+		ran = append(ran, tc.Name)
+
+		time.Sleep(100 * time.Millisecond)
+
+		return engine.NewTestCaseResult(tc.Name, tc.ID, false, false, false, false, false).Complete()
+	}
+
+	err := runner.RunTests(context.Background())
+	require.Error(t, err)
+	require.ErrorIs(t, err, testexecutionUtils.ErrTestsFailed)
+
+	assert.Equal(t, []string{"slow"}, ran)
+
+	out := buf.String()
+	assert.Contains(t, out, "--- FAIL: not run")
+	assert.Contains(t, out, "not run: testsuite timeout of 50ms exceeded")
+	assert.Contains(t, out, "--- SKIP: skipped")
+}
+
+func TestRunTests_Canceled(t *testing.T) {
+	var buf bytes.Buffer
+
+	suite := &api.TestSuiteSpec{Tests: []api.TestCase{{Name: "first"}, {Name: "second"}}}
+
+	runner := NewRunner(&testexecutionUtils.Options{}, testSuiteFile, suite)
+	runner.output = &buf
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	var ran []string
+
+	runner.runTestCaseFunc = func(tc api.TestCase) *engine.TestCaseResult {
+		ran = append(ran, tc.Name)
+
+		cancel(testexecutionUtils.ErrInterrupted)
+
+		return engine.NewTestCaseResult(tc.Name, tc.ID, false, false, false, false, false).Fail(nil)
+	}
+
+	err := runner.RunTests(ctx)
+	require.Error(t, err)
+	assert.Equal(t, "testsuite suite_xprin.yaml was canceled: interrupted", err.Error())
+	assert.Equal(t, []string{"first"}, ran)
+	assert.NotContains(t, buf.String(), "second")
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"text/template/parse"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+)
+
+// environ returns the environment variables with the given names that are set, as a map.
+func environ(names []string) map[string]string {
+	env := make(map[string]string)
+
+	for _, name := range names {
+		if value, ok := os.LookupEnv(name); ok {
+			env[name] = value
+		}
+	}
+
+	return env
+}
+
+// allowedEnv returns the names of the environment variables that the templates of the testsuite can reference.
+func (r *Runner) allowedEnv() []string {
+	if r.testSuiteSpec == nil {
+		return nil
+	}
+
+	return r.testSuiteSpec.Env
+}
+
+// checkEnvReferences checks that a template only references the environment variables listed in the env list
+// of the testsuite file.
+func (r *Runner) checkEnvReferences(root parse.Node) error {
+	for _, fields := range usedTemplateFields(root) {
+		if len(fields) > 1 && fields[0] == "Env" && !slices.Contains(r.allowedEnv(), fields[1]) {
+			return fmt.Errorf("environment variable '%s' is not in the env list of the testsuite file", fields[1])
+		}
+	}
+
+	return nil
+}
+
+// resolveVars returns the template variables of the testsuite: its vars, with their templates rendered
+// (only .Repositories and .Env are available), overridden by the vars set with --var.
+func (r *Runner) resolveVars() (map[string]string, error) {
+	vars := make(map[string]string)
+	context := newTemplateContext(r.Repositories, nil, r.env, api.Inputs{}, nil, nil)
+
+	for _, name := range slices.Sorted(maps.Keys(r.testSuiteSpec.Vars)) {
+		value := r.testSuiteSpec.Vars[name]
+
+		if testexecutionUtils.HasTemplate(value) {
+			rendered, err := r.renderTemplate(value, context, "vars."+name)
+			if err != nil {
+				return nil, fmt.Errorf("var '%s': %w", name, err)
+			}
+
+			value = rendered
+		}
+
+		vars[name] = value
+	}
+
+	maps.Copy(vars, r.Vars)
+
+	return vars, nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"os"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+func TestResolveVars(t *testing.T) {
+	tests := []struct {
+		name         string
+		suiteVars    map[string]string
+		cliVars      map[string]string
+		env          map[string]string
+		expectedVars map[string]string
+		expectErr    string
+	}{
+		{
+			name:         "no vars",
+			expectedVars: map[string]string{},
+		},
+		{
+			name:         "testsuite vars",
+			suiteVars:    map[string]string{"region": "us-east-1", "size": "small"},
+			expectedVars: map[string]string{"region": "us-east-1", "size": "small"},
+		},
+		{
+			name:         "cli vars override testsuite vars",
+			suiteVars:    map[string]string{"region": "us-east-1", "size": "small"},
+			cliVars:      map[string]string{"region": "eu-west-1", "extra": "yes"},
+			expectedVars: map[string]string{"region": "eu-west-1", "size": "small", "extra": "yes"},
+		},
+		{
+			name:         "testsuite vars can use repositories and environment variables",
+			suiteVars:    map[string]string{"functions": "{{ .Repositories.myrepo }}/functions-{{ .Env.CI_JOB }}"},
+			env:          map[string]string{"CI_JOB": "e2e"},
+			expectedVars: map[string]string{"functions": "/path/to/myrepo/functions-e2e"},
+		},
+		{
+			name:      "unset environment variable",
+			suiteVars: map[string]string{"job": "{{ .Env.CI_JOB }}"},
+			expectErr: "var 'job'",
+		},
+		{
+			name:      "environment variable not in the env list",
+			suiteVars: map[string]string{"token": "{{ .Env.TOKEN }}"},
+			env:       map[string]string{"TOKEN": "secret"},
+			expectErr: "environment variable 'TOKEN' is not in the env list of the testsuite file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := NewRunner(&testexecutionUtils.Options{
+				Repositories: map[string]string{"myrepo": "/path/to/myrepo"},
+				Vars:         tt.cliVars,
+			}, testSuiteFile, &api.TestSuiteSpec{Vars: tt.suiteVars, Env: []string{"CI_JOB"}})
+			runner.env = tt.env
+
+			vars, err := runner.resolveVars()
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedVars, vars)
+		})
+	}
+}
+
+func TestProcessTemplateVariables_VarsAndEnv(t *testing.T) {
+	testCase := api.TestCase{
+		Name: "vars",
+		Inputs: api.Inputs{
+			XR:          "{{ .Vars.region }}/xr.yaml",
+			Composition: "{{ .Env.COMPOSITION }}",
+		},
+	}
+
+	runner := &Runner{
+		Options:       &testexecutionUtils.Options{},
+		testSuiteSpec: &api.TestSuiteSpec{Env: []string{"COMPOSITION"}},
+		vars:          map[string]string{"region": "eu-west-1"},
+		env:           map[string]string{"COMPOSITION": "composition.yaml"},
+	}
+
+	err := runner.processTemplateVariables(&testCase, engine.NewTestSuiteResult("test-suite.yaml", false))
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1/xr.yaml", testCase.Inputs.XR)
+	assert.Equal(t, "composition.yaml", testCase.Inputs.Composition)
+}
+
+func TestEnviron(t *testing.T) {
+	t.Setenv("XPRIN_TEST_LISTED", "listed")
+	t.Setenv("XPRIN_TEST_UNLISTED", "unlisted")
+	require.NoError(t, os.Unsetenv("XPRIN_TEST_UNSET"))
+
+	env := environ([]string{"XPRIN_TEST_LISTED", "XPRIN_TEST_UNSET"})
+	assert.Equal(t, map[string]string{"XPRIN_TEST_LISTED": "listed"}, env)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/crossplane-contrib/xprin/internal/utils"
+	"github.com/crossplane-contrib/xprin/internal/xrgen"
+	"github.com/gertd/go-pluralize"
+	"github.com/spf13/afero"
+)
+
+// expandXRFromXRD replaces each test case whose XRs are generated from an XRD (inputs.xr-from-xrd, in the test case
+// or in common) with one test case per generated XR, given inline and named after it, e.g. "Render [minimal]". The
+// generated test cases share the other inputs, patches, hooks and assertions of the test case.
+func (r *Runner) expandXRFromXRD(tests []api.TestCase) ([]api.TestCase, error) {
+	expanded := make([]api.TestCase, 0, len(tests))
+
+	for _, testCase := range tests {
+		xrFromXRD := testCase.XRGeneration(r.testSuiteSpec.Common)
+		if xrFromXRD == nil || testCase.IsSkipped() {
+			expanded = append(expanded, testCase)
+			continue
+		}
+
+		examples, err := r.generateXRs(xrFromXRD)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate the XRs of test case '%s': %w", testCase.Name, err)
+		}
+
+		if r.Debug {
+			plural := pluralize.NewClient()
+			utils.DebugPrintf("Generated %s from XRD %s for test case '%s'\n", plural.Pluralize("XR", len(examples), true), xrFromXRD.XRD, testCase.Name)
+		}
+
+		for _, example := range examples {
+			generated := testCase
+			generated.Name = fmt.Sprintf("%s [%s]", testCase.Name, example.Name)
+			generated.Inputs.XRFromXRD = nil
+			generated.Inputs.XR = ""
+			generated.Inputs.Inline.XR = example.XR.Object
+
+			expanded = append(expanded, generated)
+		}
+	}
+
+	return expanded, nil
+}
+
+// generateXRs generates the XRs of an xr-from-xrd from its XRD, whose path is relative to the testsuite file and can
+// use .Repositories, .Vars and .Env.
+func (r *Runner) generateXRs(xrFromXRD *api.XRFromXRD) ([]xrgen.Example, error) {
+	path := xrFromXRD.XRD
+
+	if testexecutionUtils.HasTemplate(path) {
+		rendered, ok := r.renderStaticPath(path, newTemplateContext(r.Repositories, r.vars, r.env, api.Inputs{}, nil, nil))
+		if !ok {
+			return nil, fmt.Errorf("failed to render XRD path %s: only .Repositories, .Vars and .Env can be used", path)
+		}
+
+		path = rendered
+	}
+
+	expanded, err := r.expandPathRelativeToTestSuiteFile(r.testSuiteFile, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand XRD path %s: %w", path, err)
+	}
+
+	data, err := afero.ReadFile(r.fs, expanded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read XRD: %w", err)
+	}
+
+	xrd, err := xrgen.ParseXRD(data)
+	if err != nil {
+		return nil, err
+	}
+
+	modes := make([]xrgen.Mode, 0, len(xrFromXRD.Modes))
+	for _, mode := range xrFromXRD.Modes {
+		modes = append(modes, xrgen.Mode(mode))
+	}
+
+	return xrgen.Generate(xrd, xrgen.Options{Modes: modes, Count: xrFromXRD.Count, Seed: xrFromXRD.Seed})
+}
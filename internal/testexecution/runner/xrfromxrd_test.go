@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/api"
+	"github.com/crossplane-contrib/xprin/internal/config"
+	testexecutionUtils "github.com/crossplane-contrib/xprin/internal/testexecution/utils"
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+const testXRFromXRD = `apiVersion: apiextensions.crossplane.io/v2
+kind: CompositeResourceDefinition
+metadata:
+  name: xbuckets.example.com
+spec:
+  group: example.com
+  names:
+    kind: XBucket
+    plural: xbuckets
+  versions:
+  - name: v1
+    served: true
+    referenceable: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [tier]
+            properties:
+              tier:
+                type: string
+                enum: [small, large]
+`
+
+func TestExpandXRFromXRD(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "apis"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "apis", "xrd.yaml"), []byte(testXRFromXRD), 0o600))
+
+	spec := &api.TestSuiteSpec{
+		Vars: map[string]string{"dir": "apis"},
+		Common: api.Common{
+			Inputs: api.Inputs{
+				XRFromXRD: &api.XRFromXRD{
+					XRD:   "{{ .Vars.dir }}/xrd.yaml",
+					Modes: []api.XRGenerationMode{api.XRGenerationModeMinimal, api.XRGenerationModeBoundary},
+				},
+			},
+		},
+		Tests: []api.TestCase{
+			{Name: "generated", Assertions: api.Assertions{Xprin: []api.AssertionXprin{{Name: "count", Type: "Count", Value: 1}}}},
+			{Name: "given", ID: "given", Inputs: api.Inputs{XR: "xr.yaml"}},
+			{Name: "skipped", Skip: "not yet"},
+		},
+	}
+
+	r := NewRunner(&testexecutionUtils.Options{}, filepath.Join(dir, "bucket_xprin.yaml"), spec)
+
+	var err error
+
+	r.vars, err = r.resolveVars()
+	require.NoError(t, err)
+
+	tests, err := r.expandXRFromXRD(spec.Tests)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(tests))
+	for _, test := range tests {
+		names = append(names, test.Name)
+	}
+
+	assert.Equal(t, []string{
+		"generated [minimal]",
+		"generated [boundary spec.tier=small]",
+		"generated [boundary spec.tier=large]",
+		"given",
+		"skipped",
+	}, names)
+
+	generated := tests[2]
+	assert.Nil(t, generated.Inputs.XRFromXRD)
+	assert.Empty(t, generated.Inputs.XR)
+	assert.Equal(t, map[string]any{
+		"apiVersion": "example.com/v1",
+		"kind":       "XBucket",
+		"metadata":   map[string]any{"name": "example-xbucket", "namespace": "default"},
+		"spec":       map[string]any{"tier": "large"},
+	}, generated.Inputs.Inline.XR)
+	assert.Equal(t, spec.Tests[0].Assertions, generated.Assertions, "generated test cases share the assertions")
+
+	generated.MergeCommon(spec.Common)
+	assert.Nil(t, generated.Inputs.XRFromXRD, "generated test cases do not inherit xr-from-xrd from common")
+	assert.True(t, generated.HasXR())
+
+	spec.Common.Inputs.XRFromXRD.XRD = "missing.yaml"
+
+	_, err = r.expandXRFromXRD(spec.Tests)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to generate the XRs of test case 'generated': failed to read XRD")
+}
+
+func TestRunTests_XRFromXRDSharedInputs(t *testing.T) {
+	dir := t.TempDir()
+	for file, content := range map[string]string{
+		"apis/xrd.yaml":    testXRFromXRD,
+		"apis/crd.yaml":    "kind: CustomResourceDefinition\n",
+		"composition.yaml": "kind: Composition\n",
+		"functions.yaml":   "kind: Function\n",
+		"context.json":     "{}\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o600))
+	}
+
+	spec := &api.TestSuiteSpec{
+		Tests: []api.TestCase{
+			{
+				Name: "generated",
+				Inputs: api.Inputs{
+					XRFromXRD:    &api.XRFromXRD{XRD: "apis/xrd.yaml", Modes: []api.XRGenerationMode{api.XRGenerationModeMinimal, api.XRGenerationModeBoundary}},
+					Composition:  "composition.yaml",
+					Functions:    "functions.yaml",
+					CRDs:         []string{"apis/crd.yaml"},
+					ContextFiles: map[string]string{"apiextensions.crossplane.io/environment": "context.json"},
+				},
+			},
+		},
+	}
+
+	r := NewRunner(makeOptions(&config.Config{}, []string{config.RenderSubcommand}, []string{"beta", "validate"}), filepath.Join(dir, "bucket_xprin.yaml"), spec)
+
+	var output bytes.Buffer
+	r.output = &output
+
+	// Every generated test case must read its own copy of the inputs, which exists while it runs
+	r.runCommand = func(_ context.Context, _ string, args ...string) ([]byte, error) {
+		for _, arg := range args {
+			if _, path, ok := strings.Cut(arg, "="); ok && filepath.IsAbs(path) {
+				arg = path
+			}
+
+			if filepath.IsAbs(arg) {
+				if _, err := os.Stat(arg); err != nil {
+					return nil, fmt.Errorf("input of the command: %w", err)
+				}
+			}
+		}
+
+		if args[0] == config.RenderSubcommand {
+			return []byte("apiVersion: example.com/v1\nkind: XBucket\nmetadata:\n  name: example-xbucket\n"), nil
+		}
+
+		return nil, nil
+	}
+
+	require.NoError(t, r.RunTests(context.Background()), output.String())
+	assert.Equal(t, []string{"apis/crd.yaml"}, spec.Tests[0].Inputs.CRDs, "the test case keeps its paths")
+	assert.Equal(t, "context.json", spec.Tests[0].Inputs.ContextFiles["apiextensions.crossplane.io/environment"])
+}
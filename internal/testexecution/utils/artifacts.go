@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/spf13/afero"
+)
+
+// ManifestFile is the name of the file that indexes the artifacts of a run.
+const ManifestFile = "manifest.json"
+
+// unsafeNameChars matches the characters that are replaced in the directory name of a test case.
+var unsafeNameChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// Artifacts persists the inputs and outputs of the test cases of a run in a directory (--artifacts-dir), so that they
+// are still available after the run, e.g. to be uploaded by CI. A nil Artifacts persists nothing.
+type Artifacts struct {
+	Dir string
+
+	mu   sync.Mutex
+	used map[string]bool
+}
+
+// NewArtifacts returns an Artifacts that persists the test cases in dir, or nil if dir is empty.
+func NewArtifacts(dir string) *Artifacts {
+	if dir == "" {
+		return nil
+	}
+
+	return &Artifacts{Dir: dir, used: map[string]bool{}}
+}
+
+// TestCaseDir returns the directory for the artifacts of a test case: <testsuite file>/<test case> under the artifacts
+// directory, with the testsuite file relative to the working directory and without extension, and the test case ID
+// (or its name if it has no ID). A number is appended to keep the directories of the run unique.
+func (a *Artifacts) TestCaseDir(testSuiteFile, testCaseName, testCaseID string) string {
+	name := testCaseID
+	if name == "" {
+		name = testCaseName
+	}
+
+	name = strings.Trim(unsafeNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+	if name == "" {
+		name = "testcase"
+	}
+
+	base := filepath.Join(a.Dir, testSuiteDirName(testSuiteFile), name)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	dir := base
+	for i := 2; a.used[dir]; i++ {
+		dir = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	a.used[dir] = true
+
+	return dir
+}
+
+// testSuiteDirName returns the path of a testsuite file relative to the working directory, without extension.
+// Testsuite files outside the working directory use only their base name.
+func testSuiteDirName(testSuiteFile string) string {
+	path := filepath.Base(testSuiteFile)
+
+	if pwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(pwd, testSuiteFile); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+
+	return strings.TrimSuffix(path, filepath.Ext(path))
+}
+
+// manifest indexes the artifacts of a run.
+type manifest struct {
+	Duration   float64             `json:"duration"` // In seconds
+	TestSuites []manifestTestSuite `json:"testsuites"`
+	Errors     []manifestError     `json:"errors,omitempty"`
+}
+
+// manifestTestSuite is a testsuite file that was run.
+type manifestTestSuite struct {
+	File      string             `json:"file"`
+	Status    string             `json:"status"`
+	Duration  float64            `json:"duration"` // In seconds
+	TestCases []manifestTestCase `json:"test-cases"`
+}
+
+// manifestTestCase is a test case with its artifacts. Dir and Files are relative to the artifacts directory, and
+// are empty if the test case was not run.
+type manifestTestCase struct {
+	Name         string   `json:"name"`
+	ID           string   `json:"id,omitempty"`
+	Status       string   `json:"status"`
+	Duration     float64  `json:"duration"` // In seconds
+	Error        string   `json:"error,omitempty"`
+	SkipReason   string   `json:"skip-reason,omitempty"`
+	XFailReason  string   `json:"xfail-reason,omitempty"`
+	XFailOutcome string   `json:"xfail-outcome,omitempty"` // expected-failure or unexpected-pass, for test cases with xfail that ran
+	Dir          string   `json:"dir,omitempty"`
+	Files        []string `json:"files,omitempty"`
+}
+
+// Outcomes of the test cases that are expected to fail.
+const (
+	xfailOutcomeExpectedFailure = "expected-failure"
+	xfailOutcomeUnexpectedPass  = "unexpected-pass"
+)
+
+// manifestError is a testsuite file that could not be run.
+type manifestError struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+// WriteManifest writes the manifest that indexes the artifacts of the test cases of the run summary to the artifacts
+// directory.
+func (a *Artifacts) WriteManifest(fs afero.Fs, summary *engine.RunSummary) error {
+	if a == nil || summary == nil {
+		return nil
+	}
+
+	m := manifest{Duration: summary.Duration.Seconds(), TestSuites: []manifestTestSuite{}}
+
+	for _, suite := range summary.TestSuites {
+		manifestSuite := manifestTestSuite{
+			File:      filepath.ToSlash(suite.FilePath),
+			Status:    suite.Status.String(),
+			Duration:  suite.Duration.Seconds(),
+			TestCases: []manifestTestCase{},
+		}
+
+		for _, result := range suite.Results {
+			testCase := manifestTestCase{
+				Name:        result.Name,
+				ID:          result.ID,
+				Status:      result.Status.String(),
+				Duration:    result.Duration.Seconds(),
+				SkipReason:  result.SkipReason,
+				XFailReason: result.XFailReason,
+			}
+
+			switch {
+			case result.ExpectedFailure:
+				testCase.XFailOutcome = xfailOutcomeExpectedFailure
+			case result.UnexpectedPass:
+				testCase.XFailOutcome = xfailOutcomeUnexpectedPass
+			}
+
+			if result.Error != nil {
+				testCase.Error = result.Error.Error()
+			}
+
+			if result.ArtifactsDir != "" {
+				var err error
+
+				testCase.Dir, testCase.Files, err = a.files(fs, result.ArtifactsDir)
+				if err != nil {
+					return err
+				}
+			}
+
+			manifestSuite.TestCases = append(manifestSuite.TestCases, testCase)
+		}
+
+		m.TestSuites = append(m.TestSuites, manifestSuite)
+	}
+
+	for _, suiteErr := range summary.Errors {
+		m.Errors = append(m.Errors, manifestError{File: filepath.ToSlash(suiteErr.FilePath), Error: suiteErr.Err.Error()})
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal artifacts manifest: %w", err)
+	}
+
+	if err := fs.MkdirAll(a.Dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create artifacts directory: %w", err)
+	}
+
+	if err := afero.WriteFile(fs, filepath.Join(a.Dir, ManifestFile), append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write artifacts manifest: %w", err)
+	}
+
+	return nil
+}
+
+// files returns the directory of a test case and the files in it, relative to the artifacts directory.
+func (a *Artifacts) files(fs afero.Fs, dir string) (string, []string, error) {
+	relDir, err := filepath.Rel(a.Dir, dir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve artifacts directory %s: %w", dir, err)
+	}
+
+	var files []string
+
+	err = afero.Walk(fs, dir, func(path string, info iofs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(a.Dir, path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, filepath.ToSlash(rel))
+
+		return nil
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list artifacts in %s: %w", dir, err)
+	}
+
+	return filepath.ToSlash(relDir), files, nil
+}
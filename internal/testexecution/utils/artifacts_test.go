@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/crossplane-contrib/xprin/internal/engine"
+	"github.com/spf13/afero"
+)
+
+func TestNewArtifacts(t *testing.T) {
+	if artifacts := NewArtifacts(""); artifacts != nil {
+		t.Errorf("NewArtifacts(\"\") = %v, want nil", artifacts)
+	}
+
+	var artifacts *Artifacts
+	if err := artifacts.WriteManifest(afero.NewMemMapFs(), engine.NewRunSummary()); err != nil {
+		t.Errorf("WriteManifest() on nil Artifacts = %v, want nil", err)
+	}
+}
+
+func TestArtifacts_TestCaseDir(t *testing.T) {
+	artifacts := NewArtifacts("/artifacts")
+
+	tests := []struct {
+		name          string
+		testSuiteFile string
+		testCaseName  string
+		testCaseID    string
+		want          string
+	}{
+		{
+			name:          "uses the test case ID",
+			testSuiteFile: "/elsewhere/aws_xprin.yaml",
+			testCaseName:  "Basic test",
+			testCaseID:    "basic",
+			want:          "/artifacts/aws_xprin/basic",
+		},
+		{
+			name:          "uses the test case name without ID",
+			testSuiteFile: "/elsewhere/aws_xprin.yaml",
+			testCaseName:  "With Tags: v1/v2",
+			want:          "/artifacts/aws_xprin/with-tags-v1-v2",
+		},
+		{
+			name:          "makes duplicates unique",
+			testSuiteFile: "/elsewhere/aws_xprin.yaml",
+			testCaseName:  "Other",
+			testCaseID:    "basic",
+			want:          "/artifacts/aws_xprin/basic-2",
+		},
+		{
+			name:          "falls back for names without safe characters",
+			testSuiteFile: "/elsewhere/xprin.yaml",
+			testCaseName:  "???",
+			want:          "/artifacts/xprin/testcase",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := artifacts.TestCaseDir(tt.testSuiteFile, tt.testCaseName, tt.testCaseID)
+			if got != filepath.FromSlash(tt.want) {
+				t.Errorf("TestCaseDir() = %q, want %q", got, filepath.FromSlash(tt.want))
+			}
+		})
+	}
+}
+
+func TestArtifacts_WriteManifest(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	artifacts := NewArtifacts("/artifacts")
+
+	passed := engine.NewTestCaseResult("passed", "one", false, false, false, false, false).Complete()
+	passed.ArtifactsDir = artifacts.TestCaseDir("/elsewhere/aws_xprin.yaml", passed.Name, passed.ID)
+
+	for _, file := range []string{"outputs/rendered.yaml", "inputs/xr/xr.yaml"} {
+		if err := afero.WriteFile(fs, filepath.Join(passed.ArtifactsDir, file), []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	skipped := engine.NewTestCaseResult("skipped", "", false, false, false, false, false).SkipWithReason("not now")
+
+	suite := engine.NewTestSuiteResult("/elsewhere/aws_xprin.yaml", false)
+	suite.AddResult(passed)
+	suite.AddResult(skipped)
+
+	expectedFailure := engine.NewTestCaseResult("expected failure", "", false, false, false, false, false).Fail(errors.New("broken"))
+	suite.AddResult(expectedFailure.ExpectFailure("known bug"))
+
+	unexpectedPass := engine.NewTestCaseResult("unexpected pass", "", false, false, false, false, false).Complete()
+	suite.AddResult(unexpectedPass.ExpectFailure("known bug"))
+
+	summary := engine.NewRunSummary()
+	summary.AddTestSuite(suite.Complete())
+	summary.AddTestSuiteError("/elsewhere/bad_xprin.yaml", errors.New("invalid YAML"))
+
+	if err := artifacts.WriteManifest(fs, summary.Complete()); err != nil {
+		t.Fatalf("WriteManifest() = %v", err)
+	}
+
+	data, err := afero.ReadFile(fs, filepath.Join("/artifacts", ManifestFile))
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+
+	var got manifest
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("failed to unmarshal manifest: %v", err)
+	}
+
+	if len(got.TestSuites) != 1 || len(got.TestSuites[0].TestCases) != 4 {
+		t.Fatalf("manifest testsuites = %+v, want 1 testsuite with 4 test cases", got.TestSuites)
+	}
+
+	testCase := got.TestSuites[0].TestCases[0]
+	if testCase.Status != "PASS" || testCase.Dir != "aws_xprin/one" {
+		t.Errorf("test case = %+v, want status PASS in aws_xprin/one", testCase)
+	}
+
+	wantFiles := []string{"aws_xprin/one/inputs/xr/xr.yaml", "aws_xprin/one/outputs/rendered.yaml"}
+	if !slices.Equal(testCase.Files, wantFiles) {
+		t.Errorf("test case files = %v, want %v", testCase.Files, wantFiles)
+	}
+
+	testCase = got.TestSuites[0].TestCases[1]
+	if testCase.Status != "SKIP" || testCase.SkipReason != "not now" || testCase.Dir != "" || len(testCase.Files) != 0 {
+		t.Errorf("test case = %+v, want skipped without artifacts", testCase)
+	}
+
+	testCase = got.TestSuites[0].TestCases[2]
+	if testCase.Status != "PASS" || testCase.XFailReason != "known bug" || testCase.XFailOutcome != "expected-failure" {
+		t.Errorf("test case = %+v, want an expected failure", testCase)
+	}
+
+	testCase = got.TestSuites[0].TestCases[3]
+	if testCase.Status != "FAIL" || testCase.XFailReason != "known bug" || testCase.XFailOutcome != "unexpected-pass" {
+		t.Errorf("test case = %+v, want an unexpected pass", testCase)
+	}
+
+	if len(got.Errors) != 1 || got.Errors[0].File != "/elsewhere/bad_xprin.yaml" || got.Errors[0].Error != "invalid YAML" {
+		t.Errorf("manifest errors = %+v, want the invalid testsuite file", got.Errors)
+	}
+}
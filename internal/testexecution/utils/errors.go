@@ -0,0 +1,31 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import "errors"
+
+var (
+	// ErrTestsFailed is returned when test cases failed, but all the tests could be run.
+	ErrTestsFailed = errors.New("tests failed")
+
+	// ErrTestsErrored is returned when test cases or testsuite files could not be run, e.g. because an input file is
+	// missing or crossplane could not be executed.
+	ErrTestsErrored = errors.New("tests could not be run")
+
+	// ErrLintFailed is returned when problems were found in testsuite files without running them.
+	ErrLintFailed = errors.New("lint failed")
+)
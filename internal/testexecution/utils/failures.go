@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/gertd/go-pluralize"
+)
+
+// FailureBudget counts the failed test cases across all testsuite files, so that the run stops once the maximum
+// number of failures is reached (--failfast, --max-failures). A nil FailureBudget never stops the run.
+type FailureBudget struct {
+	max      int
+	failures atomic.Int64
+}
+
+// NewFailureBudget returns a FailureBudget that is exhausted after maxFailures failed test cases,
+// or nil if maxFailures is 0 or less.
+func NewFailureBudget(maxFailures int) *FailureBudget {
+	if maxFailures <= 0 {
+		return nil
+	}
+
+	return &FailureBudget{max: maxFailures}
+}
+
+// AddFailure records a failed test case.
+func (b *FailureBudget) AddFailure() {
+	if b == nil {
+		return
+	}
+
+	b.failures.Add(1)
+}
+
+// Exhausted returns true if the maximum number of failed test cases was reached.
+func (b *FailureBudget) Exhausted() bool {
+	return b != nil && b.failures.Load() >= int64(b.max)
+}
+
+// SkipReason returns the reason reported for the test cases that are not run because the budget is exhausted.
+// It must only be called when Exhausted returns true.
+func (b *FailureBudget) SkipReason() string {
+	return fmt.Sprintf("not run: stopped after %s", b.failuresString())
+}
+
+// AbortMessage returns the message printed at the end of a run that was aborted early, or "" if the budget is not exhausted.
+func (b *FailureBudget) AbortMessage() string {
+	if !b.Exhausted() {
+		return ""
+	}
+
+	return fmt.Sprintf("execution aborted early after %s (max failures: %d)", b.failuresString(), b.max)
+}
+
+func (b *FailureBudget) failuresString() string {
+	return pluralize.NewClient().Pluralize("failed test case", int(b.failures.Load()), true)
+}
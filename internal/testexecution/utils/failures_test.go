@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import "testing"
+
+func TestFailureBudget(t *testing.T) {
+	t.Run("no limit", func(t *testing.T) {
+		budget := NewFailureBudget(0)
+		if budget != nil {
+			t.Fatalf("NewFailureBudget(0) = %v, want nil", budget)
+		}
+
+		budget.AddFailure()
+
+		if budget.Exhausted() {
+			t.Error("nil budget should never be exhausted")
+		}
+
+		if msg := budget.AbortMessage(); msg != "" {
+			t.Errorf("AbortMessage() = %q, want empty", msg)
+		}
+	})
+
+	t.Run("failfast", func(t *testing.T) {
+		budget := NewFailureBudget(1)
+		if budget.Exhausted() {
+			t.Fatal("budget should not be exhausted before any failure")
+		}
+
+		budget.AddFailure()
+
+		if !budget.Exhausted() {
+			t.Fatal("budget should be exhausted after the first failure")
+		}
+
+		if got, want := budget.SkipReason(), "not run: stopped after 1 failed test case"; got != want {
+			t.Errorf("SkipReason() = %q, want %q", got, want)
+		}
+
+		if got, want := budget.AbortMessage(), "execution aborted early after 1 failed test case (max failures: 1)"; got != want {
+			t.Errorf("AbortMessage() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("max failures", func(t *testing.T) {
+		budget := NewFailureBudget(3)
+		budget.AddFailure()
+		budget.AddFailure()
+
+		if budget.Exhausted() {
+			t.Fatal("budget should not be exhausted after 2 of 3 failures")
+		}
+
+		if msg := budget.AbortMessage(); msg != "" {
+			t.Errorf("AbortMessage() = %q, want empty", msg)
+		}
+
+		budget.AddFailure()
+
+		if got, want := budget.SkipReason(), "not run: stopped after 3 failed test cases"; got != want {
+			t.Errorf("SkipReason() = %q, want %q", got, want)
+		}
+	})
+}
@@ -17,10 +17,17 @@ limitations under the License.
 // Package utils provides shared utilities for test execution including options, path expansion, and template processing.
 package utils
 
+import (
+	"time"
+
+	"github.com/crossplane-contrib/xprin/internal/engine"
+)
+
 // Options groups all test runner options for easier passing to ProcessTargets and related functions.
 type Options struct {
 	Dependencies   map[string]string
 	Repositories   map[string]string
+	Vars           map[string]string // Template variables set with --var, overriding the testsuite vars
 	ShowRender     bool
 	ShowValidate   bool
 	ShowHooks      bool
@@ -30,4 +37,10 @@ type Options struct {
 	Color          bool // When true, diff output is colorized (resolved from --color on|off|auto in the CLI).
 	Render         []string
 	Validate       []string
+	Timeout        time.Duration      // Timeout for the whole run, 0 for no timeout
+	FailureBudget  *FailureBudget     // Stops the run after --max-failures failed test cases, nil for no limit
+	Summary        *engine.RunSummary // Collects the results of all testsuite files for the final summary, nil for no summary
+	Artifacts      *Artifacts         // Persists the inputs and outputs of the test cases (--artifacts-dir), nil to persist nothing
+	KeepTmp        bool               // When true, the temporary directories are not removed (--keep-tmp)
+	TestCases      map[string][]int   // Indexes of the test cases to run of testsuite files (--watch), all of them for the other files
 }
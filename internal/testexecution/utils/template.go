@@ -17,40 +17,108 @@ limitations under the License.
 package utils
 
 import (
-	"fmt"
-	"regexp"
+	"slices"
 	"strings"
+
+	"go.yaml.in/yaml/v3"
 )
 
-// Constants for template variable placeholders.
+// EscapedOpen is the escape sequence for a literal "{{" in a template.
+const EscapedOpen = `\{{`
+
+// TemplatePhase is the phase of a test run in which a template is rendered.
+// It determines the template variables that are available.
+type TemplatePhase string
+
+// Template phases, in the order in which they happen.
 const (
-	PlaceholderOpen  = "__OPEN__"
-	PlaceholderClose = "__CLOSE__"
+	// PhaseLoad is the phase of the testsuite vars, rendered before any test case runs.
+	PhaseLoad TemplatePhase = "load"
+	// PhasePreRender is the phase of the test case fields and pre-test hooks, rendered before the test case is rendered.
+	PhasePreRender TemplatePhase = "pre-render"
+	// PhasePostTest is the phase of the post-test hooks, rendered after the test case ran.
+	PhasePostTest TemplatePhase = "post-test"
 )
 
-// CreatePlaceholder creates a template variable placeholder for testing.
-func CreatePlaceholder(templateVar string) string {
-	return fmt.Sprintf("%s%s%s", PlaceholderOpen, templateVar, PlaceholderClose)
+// TemplateVariables returns the names of all template variables.
+func TemplateVariables() []string {
+	return PhasePostTest.Variables()
 }
 
-// ReplaceTemplateVarsWithPlaceholders replaces template variables with placeholders.
-func ReplaceTemplateVarsWithPlaceholders(content string) string {
-	re := regexp.MustCompile(`\{\{\s*(.*?)\s*\}\}`)
+// Variables returns the names of the template variables available in the phase.
+func (p TemplatePhase) Variables() []string {
+	switch p {
+	case PhaseLoad:
+		return []string{"Repositories", "Env"}
+	case PhasePostTest:
+		return []string{"Repositories", "Vars", "Env", "Inputs", "Tests", "Outputs"}
+	case PhasePreRender:
+		fallthrough
+	default:
+		return []string{"Repositories", "Vars", "Env", "Inputs", "Tests"}
+	}
+}
 
-	return re.ReplaceAllStringFunc(content, func(match string) string {
-		// Extract the content inside the curly brackets
-		innerContent := re.FindStringSubmatch(match)[1]
-		// Remove any remaining whitespace
-		cleanContent := strings.TrimSpace(innerContent)
+// PhaseOf returns the phase in which a string value of a testsuite file is rendered, given the keys leading to it.
+func PhaseOf(keys []string) TemplatePhase {
+	switch {
+	case len(keys) > 0 && keys[0] == "vars":
+		return PhaseLoad
+	case slices.Contains(keys, "post-test"):
+		return PhasePostTest
+	default:
+		return PhasePreRender
+	}
+}
+
+// HasTemplate returns true if a string value contains a template action or an escaped literal "{{".
+func HasTemplate(s string) bool {
+	return strings.Contains(s, "{{")
+}
 
-		return fmt.Sprintf("%s%s%s", PlaceholderOpen, cleanContent, PlaceholderClose)
+// EscapeLiteralBraces replaces the escaped literal "{{" of a template with an action that prints "{{".
+func EscapeLiteralBraces(s string) string {
+	return strings.ReplaceAll(s, EscapedOpen, `{{"{{"}}`)
+}
+
+// FindScalarLine returns the line of the first string value equal to value in a YAML document, or 0 if there is none.
+func FindScalarLine(content []byte, value string) int {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return 0
+	}
+
+	line := 0
+
+	WalkScalars(&root, func(node *yaml.Node, _ []string) {
+		if line == 0 && node.Value == value {
+			line = node.Line
+		}
 	})
+
+	return line
 }
 
-// RestoreTemplateVars restores template variables from placeholders.
-func RestoreTemplateVars(content string) string {
-	content = strings.ReplaceAll(content, PlaceholderOpen, "{{")
-	content = strings.ReplaceAll(content, PlaceholderClose, "}}")
+// WalkScalars calls fn for every string value (not map key) of a YAML node tree, with the keys leading to it.
+// List items do not add a key.
+func WalkScalars(node *yaml.Node, fn func(node *yaml.Node, keys []string)) {
+	walkScalars(node, nil, fn)
+}
 
-	return content
+func walkScalars(node *yaml.Node, keys []string, fn func(node *yaml.Node, keys []string)) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			walkScalars(child, keys, fn)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			walkScalars(node.Content[i+1], append(slices.Clip(keys), node.Content[i].Value), fn)
+		}
+	case yaml.ScalarNode:
+		if node.Tag == "!!str" {
+			fn(node, keys)
+		}
+	case yaml.AliasNode:
+	}
 }
@@ -17,248 +17,215 @@ limitations under the License.
 package utils
 
 import (
+	"slices"
 	"strings"
 	"testing"
+
+	"go.yaml.in/yaml/v3"
 )
 
-func TestCreatePlaceholder(t *testing.T) {
+func TestHasTemplate(t *testing.T) {
 	tests := []struct {
-		name        string
-		templateVar string
-		want        string
+		name  string
+		input string
+		want  bool
 	}{
 		{
-			name:        "simple variable",
-			templateVar: ".Inputs.XR",
-			want:        PlaceholderOpen + ".Inputs.XR" + PlaceholderClose,
+			name:  "template action",
+			input: "{{ .Inputs.XR }}",
+			want:  true,
+		},
+		{
+			name:  "template in text",
+			input: "echo {{ .Inputs.XR }} > out",
+			want:  true,
 		},
 		{
-			name:        "repository variable",
-			templateVar: ".Repositories.myrepo",
-			want:        PlaceholderOpen + ".Repositories.myrepo" + PlaceholderClose,
+			name:  "escaped braces",
+			input: `echo \{{ literal }}`,
+			want:  true,
 		},
 		{
-			name:        "empty variable",
-			templateVar: "",
-			want:        PlaceholderOpen + PlaceholderClose,
+			name:  "no template",
+			input: "echo hello",
+			want:  false,
 		},
 		{
-			name:        "variable with spaces",
-			templateVar: "  .Inputs.XR  ",
-			want:        PlaceholderOpen + "  .Inputs.XR  " + PlaceholderClose,
+			name:  "single brace",
+			input: "echo '{ a: b }'",
+			want:  false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := CreatePlaceholder(tt.templateVar)
-			if got != tt.want {
-				t.Errorf("CreatePlaceholder(%q) = %q, want %q", tt.templateVar, got, tt.want)
+			if got := HasTemplate(tt.input); got != tt.want {
+				t.Errorf("HasTemplate(%q) = %v, want %v", tt.input, got, tt.want)
 			}
 		})
 	}
 }
 
-func TestReplaceTemplateVarsWithPlaceholders(t *testing.T) {
+func TestEscapeLiteralBraces(t *testing.T) {
 	tests := []struct {
-		name    string
-		content string
-		want    string
+		name  string
+		input string
+		want  string
 	}{
 		{
-			name:    "single template variable",
-			content: "path: {{ .Inputs.XR }}",
-			want:    "path: " + PlaceholderOpen + ".Inputs.XR" + PlaceholderClose,
-		},
-		{
-			name:    "multiple template variables",
-			content: "{{ .Repositories.myrepo }}/functions and {{ .Inputs.XR }}",
-			want:    PlaceholderOpen + ".Repositories.myrepo" + PlaceholderClose + "/functions and " + PlaceholderOpen + ".Inputs.XR" + PlaceholderClose,
+			name:  "escaped braces",
+			input: `echo \{{ .Values.x }}`,
+			want:  `echo {{"{{"}} .Values.x }}`,
 		},
 		{
-			name:    "template variable with spaces",
-			content: "path: {{  .Inputs.XR  }}",
-			want:    "path: " + PlaceholderOpen + ".Inputs.XR" + PlaceholderClose,
+			name:  "escaped braces next to template",
+			input: `\{{ {{ .Inputs.XR }}`,
+			want:  `{{"{{"}} {{ .Inputs.XR }}`,
 		},
 		{
-			name:    "no template variables",
-			content: "path: /some/static/path",
-			want:    "path: /some/static/path",
-		},
-		{
-			name:    "mixed content",
-			content: "static: /path and dynamic: {{ .Repositories.myrepo }}/functions",
-			want:    "static: /path and dynamic: " + PlaceholderOpen + ".Repositories.myrepo" + PlaceholderClose + "/functions",
-		},
-		{
-			name:    "nested template variables",
-			content: "{{ .Inputs.XR }} and {{ .Outputs.XR }}",
-			want:    PlaceholderOpen + ".Inputs.XR" + PlaceholderClose + " and " + PlaceholderOpen + ".Outputs.XR" + PlaceholderClose,
-		},
-		{
-			name:    "template variable in YAML",
-			content: "functions: {{ .Repositories.myrepo }}/functions\ncrds:\n  - {{ .Repositories.otherrepo }}/crds",
-			want:    "functions: " + PlaceholderOpen + ".Repositories.myrepo" + PlaceholderClose + "/functions\ncrds:\n  - " + PlaceholderOpen + ".Repositories.otherrepo" + PlaceholderClose + "/crds",
-		},
-		{
-			name:    "empty template variable",
-			content: "path: {{ }}",
-			want:    "path: " + PlaceholderOpen + PlaceholderClose,
+			name:  "no escape",
+			input: "{{ .Inputs.XR }}",
+			want:  "{{ .Inputs.XR }}",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ReplaceTemplateVarsWithPlaceholders(tt.content)
-			if got != tt.want {
-				t.Errorf("ReplaceTemplateVarsWithPlaceholders() = %q, want %q", got, tt.want)
+			if got := EscapeLiteralBraces(tt.input); got != tt.want {
+				t.Errorf("EscapeLiteralBraces(%q) = %q, want %q", tt.input, got, tt.want)
 			}
 		})
 	}
 }
 
-func TestRestoreTemplateVars(t *testing.T) {
+func TestPhaseOf(t *testing.T) {
 	tests := []struct {
-		name    string
-		content string
-		want    string
+		name string
+		keys []string
+		want TemplatePhase
 	}{
 		{
-			name:    "single placeholder",
-			content: "path: " + PlaceholderOpen + ".Inputs.XR" + PlaceholderClose,
-			want:    "path: {{.Inputs.XR}}",
+			name: "testsuite var",
+			keys: []string{"vars", "region"},
+			want: PhaseLoad,
 		},
 		{
-			name:    "multiple placeholders",
-			content: PlaceholderOpen + ".Repositories.myrepo" + PlaceholderClose + "/functions and " + PlaceholderOpen + ".Inputs.XR" + PlaceholderClose,
-			want:    "{{.Repositories.myrepo}}/functions and {{.Inputs.XR}}",
+			name: "test case input",
+			keys: []string{"tests", "inputs", "xr"},
+			want: PhasePreRender,
 		},
 		{
-			name:    "no placeholders",
-			content: "path: /some/static/path",
-			want:    "path: /some/static/path",
+			name: "pre-test hook",
+			keys: []string{"tests", "hooks", "pre-test", "run"},
+			want: PhasePreRender,
 		},
 		{
-			name:    "mixed content",
-			content: "static: /path and dynamic: " + PlaceholderOpen + ".Repositories.myrepo" + PlaceholderClose + "/functions",
-			want:    "static: /path and dynamic: {{.Repositories.myrepo}}/functions",
+			name: "post-test hook",
+			keys: []string{"tests", "hooks", "post-test", "run"},
+			want: PhasePostTest,
 		},
 		{
-			name:    "empty placeholder",
-			content: "path: " + PlaceholderOpen + PlaceholderClose,
-			want:    "path: {{}}",
+			name: "common post-test hook",
+			keys: []string{"common", "hooks", "post-test", "run"},
+			want: PhasePostTest,
 		},
 		{
-			name:    "placeholder in YAML",
-			content: "functions: " + PlaceholderOpen + ".Repositories.myrepo" + PlaceholderClose + "/functions\ncrds:\n  - " + PlaceholderOpen + ".Repositories.otherrepo" + PlaceholderClose + "/crds",
-			want:    "functions: {{.Repositories.myrepo}}/functions\ncrds:\n  - {{.Repositories.otherrepo}}/crds",
+			name: "no keys",
+			keys: nil,
+			want: PhasePreRender,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := RestoreTemplateVars(tt.content)
-			if got != tt.want {
-				t.Errorf("RestoreTemplateVars() = %q, want %q", got, tt.want)
+			if got := PhaseOf(tt.keys); got != tt.want {
+				t.Errorf("PhaseOf(%v) = %q, want %q", tt.keys, got, tt.want)
 			}
 		})
 	}
 }
 
-func TestReplaceAndRestoreRoundTrip(t *testing.T) {
+func TestTemplatePhaseVariables(t *testing.T) {
+	if slices.Contains(PhaseLoad.Variables(), "Vars") {
+		t.Errorf("PhaseLoad.Variables() contains Vars")
+	}
+
+	if slices.Contains(PhasePreRender.Variables(), "Outputs") {
+		t.Errorf("PhasePreRender.Variables() contains Outputs")
+	}
+
+	if !slices.Contains(PhasePostTest.Variables(), "Outputs") {
+		t.Errorf("PhasePostTest.Variables() does not contain Outputs")
+	}
+
+	if !slices.Equal(TemplateVariables(), PhasePostTest.Variables()) {
+		t.Errorf("TemplateVariables() = %v, want %v", TemplateVariables(), PhasePostTest.Variables())
+	}
+}
+
+func TestFindScalarLine(t *testing.T) {
+	content := []byte(`vars:
+  region: "{{ .Env.REGION }}"
+tests:
+- name: test
+  inputs:
+    xr: "{{ .Vars.region }}/xr.yaml"
+  hooks:
+    post-test:
+    - run: |
+        echo {{ .Outputs.XR }}
+`)
+
 	tests := []struct {
-		name    string
-		content string
+		name  string
+		value string
+		want  int
 	}{
 		{
-			name:    "single variable",
-			content: "path: {{ .Inputs.XR }}",
+			name:  "quoted value",
+			value: "{{ .Vars.region }}/xr.yaml",
+			want:  6,
 		},
 		{
-			name:    "multiple variables",
-			content: "{{ .Repositories.myrepo }}/functions and {{ .Inputs.XR }}",
+			name:  "block value",
+			value: "echo {{ .Outputs.XR }}\n",
+			want:  9,
 		},
 		{
-			name:    "variables with spaces",
-			content: "path: {{  .Inputs.XR  }}",
+			name:  "map key is not a value",
+			value: "region",
+			want:  0,
 		},
 		{
-			name:    "mixed content",
-			content: "static: /path and dynamic: {{ .Repositories.myrepo }}/functions",
-		},
-		{
-			name:    "YAML with variables",
-			content: "functions: {{ .Repositories.myrepo }}/functions\ncrds:\n  - {{ .Repositories.otherrepo }}/crds",
+			name:  "missing value",
+			value: "missing",
+			want:  0,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Replace template variables with placeholders
-			replaced := ReplaceTemplateVarsWithPlaceholders(tt.content)
-
-			// Verify that placeholders were created (content should be different)
-			if replaced == tt.content && strings.Contains(tt.content, "{{") {
-				t.Errorf("ReplaceTemplateVarsWithPlaceholders() did not replace template variables")
-			}
-
-			// Restore template variables from placeholders
-			restored := RestoreTemplateVars(replaced)
-
-			// Verify that template variables were restored (should contain {{ and }})
-			if !strings.Contains(restored, "{{") || !strings.Contains(restored, "}}") {
-				t.Errorf("RestoreTemplateVars() did not restore template variables: %q", restored)
-			}
-
-			// Verify that the variable names are preserved (even if whitespace differs)
-			// Extract variable names from original and restored
-			originalVars := extractTemplateVarNames(tt.content)
-			restoredVars := extractTemplateVarNames(restored)
-
-			if len(originalVars) != len(restoredVars) {
-				t.Errorf("Variable count mismatch: original has %d, restored has %d", len(originalVars), len(restoredVars))
-			}
-
-			for i, origVar := range originalVars {
-				if i >= len(restoredVars) {
-					t.Errorf("Missing variable in restored: %q", origVar)
-					continue
-				}
-				// Compare normalized variable names (trimmed)
-				normalizedOrig := strings.TrimSpace(origVar)
-
-				normalizedRestored := strings.TrimSpace(restoredVars[i])
-				if normalizedOrig != normalizedRestored {
-					t.Errorf("Variable mismatch at index %d: original %q, restored %q", i, normalizedOrig, normalizedRestored)
-				}
+			if got := FindScalarLine(content, tt.value); got != tt.want {
+				t.Errorf("FindScalarLine(%q) = %d, want %d", tt.value, got, tt.want)
 			}
 		})
 	}
 }
 
-// extractTemplateVarNames extracts template variable names from content.
-func extractTemplateVarNames(content string) []string {
-	var vars []string
-	// Simple extraction: find content between {{ and }}
-	start := 0
-	for {
-		openIdx := strings.Index(content[start:], "{{")
-		if openIdx == -1 {
-			break
-		}
+func TestWalkScalars(t *testing.T) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte("a:\n  b: x\n  c:\n  - y\n  - 1\n"), &root); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
 
-		openIdx += start
+	var got []string
 
-		closeIdx := strings.Index(content[openIdx:], "}}")
-		if closeIdx == -1 {
-			break
-		}
+	WalkScalars(&root, func(node *yaml.Node, keys []string) {
+		got = append(got, strings.Join(keys, ".")+"="+node.Value)
+	})
 
-		closeIdx += openIdx
-		varName := strings.TrimSpace(content[openIdx+2 : closeIdx])
-		vars = append(vars, varName)
-		start = closeIdx + 2
+	want := []string{"a.b=x", "a.c=y"}
+	if !slices.Equal(got, want) {
+		t.Errorf("WalkScalars() visited %v, want %v", got, want)
 	}
-
-	return vars
 }
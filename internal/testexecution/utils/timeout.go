@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInterrupted is the cause of a context canceled because the run was interrupted (e.g. with Ctrl-C).
+var ErrInterrupted = errors.New("interrupted")
+
+// TimeoutError is the cause of a context canceled because a timeout expired.
+type TimeoutError struct {
+	Scope   string        // What the timeout applies to: "global", "testsuite", "test case" or "hook"
+	Timeout time.Duration // The timeout that expired
+}
+
+// Error implements error.
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s timeout of %s exceeded", e.Scope, e.Timeout)
+}
+
+// WithTimeout returns a copy of ctx that is canceled with a TimeoutError for scope when timeout expires.
+// A timeout of 0 means no timeout.
+func WithTimeout(ctx context.Context, timeout time.Duration, scope string) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeoutCause(ctx, timeout, &TimeoutError{Scope: scope, Timeout: timeout})
+}
+
+// StageError returns the error of a stage (e.g. "render") that was stopped because ctx is done, or nil if ctx is not done.
+func StageError(ctx context.Context, stage string) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+
+	cause := context.Cause(ctx)
+
+	var timeoutErr *TimeoutError
+	if errors.As(cause, &timeoutErr) {
+		return fmt.Errorf("%s timed out: %w", stage, cause)
+	}
+
+	return fmt.Errorf("%s was canceled: %w", stage, cause)
+}
+
+// IsStopped returns true if err is the error of a stage that was stopped because a timeout expired or the run was
+// interrupted (see StageError).
+func IsStopped(err error) bool {
+	return errors.As(err, new(*TimeoutError)) || errors.Is(err, ErrInterrupted) || errors.Is(err, context.Canceled)
+}
+
+// IsTimeout returns true if err is the error of a stage that was stopped because a timeout expired (see StageError).
+func IsTimeout(err error) bool {
+	return errors.As(err, new(*TimeoutError))
+}
+
+// IsCanceled returns true if ctx is done for another reason than an expired timeout, e.g. because the run was interrupted.
+func IsCanceled(ctx context.Context) bool {
+	return ctx.Err() != nil && !IsTimeout(context.Cause(ctx))
+}
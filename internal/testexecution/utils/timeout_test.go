@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStageError(t *testing.T) {
+	expired, cancelExpired := WithTimeout(context.Background(), time.Nanosecond, "test case")
+	defer cancelExpired()
+
+	<-expired.Done()
+
+	nested, cancelNested := WithTimeout(expired, time.Minute, "hook")
+	defer cancelNested()
+
+	interrupted, interrupt := context.WithCancelCause(context.Background())
+	interrupt(ErrInterrupted)
+
+	running, cancelRunning := WithTimeout(context.Background(), 0, "hook")
+	defer cancelRunning()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "timeout expired",
+			ctx:  expired,
+			want: "render timed out: test case timeout of 1ns exceeded",
+		},
+		{
+			name: "timeout of the parent expired",
+			ctx:  nested,
+			want: "render timed out: test case timeout of 1ns exceeded",
+		},
+		{
+			name: "interrupted",
+			ctx:  interrupted,
+			want: "render was canceled: interrupted",
+		},
+		{
+			name: "not done",
+			ctx:  running,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := StageError(tt.ctx, "render")
+
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+
+			if got != tt.want {
+				t.Errorf("StageError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCanceled(t *testing.T) {
+	expired, cancel := WithTimeout(context.Background(), time.Nanosecond, "testsuite")
+	defer cancel()
+
+	<-expired.Done()
+
+	if IsCanceled(expired) {
+		t.Errorf("IsCanceled() = true for an expired timeout, want false")
+	}
+
+	interrupted, interrupt := context.WithCancelCause(context.Background())
+	interrupt(ErrInterrupted)
+
+	if !IsCanceled(interrupted) {
+		t.Errorf("IsCanceled() = false for an interrupted context, want true")
+	}
+
+	if IsCanceled(context.Background()) {
+		t.Errorf("IsCanceled() = true for a running context, want false")
+	}
+}
+
+func TestIsStopped(t *testing.T) {
+	expired, cancel := WithTimeout(context.Background(), time.Nanosecond, "hook")
+	defer cancel()
+
+	<-expired.Done()
+
+	if !IsStopped(StageError(expired, "hook")) {
+		t.Errorf("IsStopped() = false for a timed out stage, want true")
+	}
+
+	interrupted, interrupt := context.WithCancelCause(context.Background())
+	interrupt(ErrInterrupted)
+
+	if !IsStopped(StageError(interrupted, "hook")) {
+		t.Errorf("IsStopped() = false for an interrupted stage, want true")
+	}
+
+	if IsStopped(errors.New("exit status 1")) {
+		t.Errorf("IsStopped() = true for a failed stage, want false")
+	}
+}
+
+func TestIsTimeout(t *testing.T) {
+	expired, cancel := WithTimeout(context.Background(), time.Nanosecond, "hook")
+	defer cancel()
+
+	<-expired.Done()
+
+	if !IsTimeout(StageError(expired, "hook")) {
+		t.Errorf("IsTimeout() = false for a timed out stage, want true")
+	}
+
+	interrupted, interrupt := context.WithCancelCause(context.Background())
+	interrupt(ErrInterrupted)
+
+	if IsTimeout(StageError(interrupted, "hook")) {
+		t.Errorf("IsTimeout() = true for an interrupted stage, want false")
+	}
+}
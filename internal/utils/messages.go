@@ -19,6 +19,7 @@ package utils
 
 import (
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -37,5 +38,10 @@ func WarningPrintf(format string, args ...interface{}) {
 // OutputPrintf prints a formatted message to stdout.
 // It uses the same formatting as fmt.Printf but provides a consistent interface.
 func OutputPrintf(format string, args ...interface{}) {
-	_, _ = fmt.Fprintf(os.Stdout, format, args...)
+	_, _ = fmt.Fprintf(Output(), format, args...)
+}
+
+// Output returns the writer of OutputPrintf, for output that is written by a Print(io.Writer) method.
+func Output() io.Writer {
+	return os.Stdout
 }
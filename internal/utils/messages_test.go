@@ -48,3 +48,13 @@ func TestDebugPrintf(t *testing.T) {
 		t.Errorf("WarningPrintf output did not start with 'DEBUG: ', got: %q", out)
 	}
 }
+
+func TestOutput(t *testing.T) {
+	out := unittestsUtils.CaptureStdout(func() {
+		OutputPrintf("printf: %d\n", 7)
+		_, _ = Output().Write([]byte("write\n"))
+	})
+	if out != "printf: 7\nwrite\n" {
+		t.Errorf("OutputPrintf and Output did not write to the same output, got: %q", out)
+	}
+}
@@ -0,0 +1,461 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xrgen
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Mode is a kind of XRs that Generate builds from the schema of an XRD.
+type Mode string
+
+const (
+	// ModeMinimal builds one XR with only the required fields set, like ExampleXR.
+	ModeMinimal Mode = "minimal"
+	// ModeMaximal builds one XR with every field set, including the optional ones.
+	ModeMaximal Mode = "maximal"
+	// ModeBoundary builds one XR per boundary value of a field, on top of the minimal XR: each enum value, the
+	// minimum and maximum of numbers, the minimum and maximum length of strings and arrays, and a pattern match.
+	ModeBoundary Mode = "boundary"
+	// ModeRandom builds Count XRs with random values within the schema, reproducible from Seed.
+	ModeRandom Mode = "random"
+)
+
+// DefaultCount is the number of random XRs when Options.Count is not set.
+const DefaultCount = 5
+
+// randomSpan is how far random values and repetitions go beyond their lower bound when they have no upper bound.
+const randomSpan = 3
+
+// AllModes returns all the modes, in the order in which Generate builds their XRs.
+func AllModes() []Mode {
+	return []Mode{ModeMinimal, ModeMaximal, ModeBoundary, ModeRandom}
+}
+
+// Options configures the XRs that Generate builds.
+type Options struct {
+	Modes []Mode // Kinds of XRs to build, all of them if empty
+	Count int    // Number of random XRs, DefaultCount if not set
+	Seed  uint64 // Seed of the random XRs
+}
+
+// Example is an XR built by Generate, with a name that tells how it was built.
+type Example struct {
+	Name string // e.g. "minimal", "boundary spec.size maximum" or "random 1"
+	XR   *unstructured.Unstructured
+}
+
+// Generate builds XRs of the referenceable version of an XRD (or else its first served version) from its OpenAPI
+// schema, for each mode of the options, with the defaults of the XRD schema applied. The same XRD and options always
+// build the same XRs.
+func Generate(xrd *apiextensionsv1.CompositeResourceDefinition, options Options) ([]Example, error) {
+	g, err := newGenerator(xrd)
+	if err != nil {
+		return nil, err
+	}
+
+	modes := options.Modes
+	if len(modes) == 0 {
+		modes = AllModes()
+	}
+
+	count := options.Count
+	if count <= 0 {
+		count = DefaultCount
+	}
+
+	type spec struct {
+		name  string
+		value map[string]any
+	}
+
+	var specs []spec
+
+	for _, mode := range modes {
+		switch mode {
+		case ModeMinimal:
+			specs = append(specs, spec{string(ModeMinimal), requiredObject(g.spec)})
+		case ModeMaximal:
+			specs = append(specs, spec{string(ModeMaximal), maximalObject(g.spec)})
+		case ModeBoundary:
+			for _, boundary := range g.boundaries() {
+				specs = append(specs, spec{fmt.Sprintf("%s %s", ModeBoundary, boundary.name()), boundary.spec})
+			}
+		case ModeRandom:
+			rng := rand.New(rand.NewPCG(options.Seed, options.Seed)) //nolint:gosec // reproducible test data, not security sensitive
+
+			for i := range count {
+				specs = append(specs, spec{fmt.Sprintf("%s %d", ModeRandom, i+1), randomObject(g.spec, rng)})
+			}
+		default:
+			return nil, fmt.Errorf("unknown XR generation mode '%s' (allowed: %s, %s, %s, %s)", mode, ModeMinimal, ModeMaximal, ModeBoundary, ModeRandom)
+		}
+	}
+
+	examples := make([]Example, 0, len(specs))
+
+	for _, s := range specs {
+		xr, err := g.xr(s.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s XR: %w", s.name, err)
+		}
+
+		examples = append(examples, Example{Name: s.name, XR: xr})
+	}
+
+	return examples, nil
+}
+
+// maximalObject returns an object with every property of a schema set, or a single example key for a map.
+func maximalObject(schema extv1.JSONSchemaProps) map[string]any {
+	object := map[string]any{}
+
+	for name, property := range schema.Properties {
+		object[name] = maximalValue(property)
+	}
+
+	if len(schema.Properties) == 0 && schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		object[exampleString] = maximalValue(*schema.AdditionalProperties.Schema)
+	}
+
+	return object
+}
+
+// maximalValue returns a value for a property of a schema in a maximal XR: its last enum value, a maximal object,
+// an array with at least one maximal item, true for booleans, or else the value of a required property.
+func maximalValue(schema extv1.JSONSchemaProps) any {
+	if value, ok := enumValue(schema, len(schema.Enum)-1); ok {
+		return value
+	}
+
+	switch schema.Type {
+	case "object":
+		return maximalObject(schema)
+	case "array":
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return []any{}
+		}
+
+		n := int64(1)
+		if schema.MinItems != nil {
+			n = max(n, *schema.MinItems)
+		}
+
+		if schema.MaxItems != nil {
+			n = min(n, *schema.MaxItems)
+		}
+
+		items := []any{}
+		for range n {
+			items = append(items, maximalValue(*schema.Items.Schema))
+		}
+
+		return items
+	case "boolean":
+		return true
+	}
+
+	return requiredValue(schema)
+}
+
+// boundary is a spec of the minimal XR with a field set to one of its boundary values.
+type boundary struct {
+	field string // Path of the field in the spec, e.g. "parameters.size"
+	label string // Which boundary value the field is set to, e.g. "maximum" or "=us-east-1"
+	spec  map[string]any
+}
+
+// name returns the name of a boundary, e.g. "spec.size maximum" or "spec.region=us-east-1".
+func (b boundary) name() string {
+	if strings.HasPrefix(b.label, "=") {
+		return "spec." + b.field + b.label
+	}
+
+	return "spec." + b.field + " " + b.label
+}
+
+// boundaries returns a spec of the minimal XR for each boundary value of each field of the spec, in the order of
+// the field paths. Fields in arrays are not walked.
+func (g *generator) boundaries() []boundary {
+	base := requiredObject(g.spec)
+
+	var boundaries []boundary
+
+	var walk func(schema extv1.JSONSchemaProps, path []string)
+
+	walk = func(schema extv1.JSONSchemaProps, path []string) {
+		for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+			property := schema.Properties[name]
+			fieldPath := append(slices.Clone(path), name)
+
+			for _, value := range boundaryValues(property) {
+				spec := runtime.DeepCopyJSON(base)
+				setField(spec, g.spec, fieldPath, value.value)
+
+				boundaries = append(boundaries, boundary{field: strings.Join(fieldPath, "."), label: value.label, spec: spec})
+			}
+
+			if property.Type == "object" {
+				walk(property, fieldPath)
+			}
+		}
+	}
+
+	walk(g.spec, nil)
+
+	return boundaries
+}
+
+// boundaryValue is a boundary value of a field, with a label that tells which one it is.
+type boundaryValue struct {
+	label string
+	value any
+}
+
+// boundaryValues returns the boundary values of a property of a schema: each of its enum values, or else the minimum
+// and maximum of numbers, a value matching the pattern or else the minimum and maximum length of strings, and the
+// minimum and maximum number of items of arrays.
+func boundaryValues(schema extv1.JSONSchemaProps) []boundaryValue {
+	var values []boundaryValue
+
+	if len(schema.Enum) > 0 {
+		for i := range schema.Enum {
+			if value, ok := enumValue(schema, i); ok {
+				values = append(values, boundaryValue{fmt.Sprintf("=%v", value), value})
+			}
+		}
+
+		return values
+	}
+
+	switch schema.Type {
+	case "integer", "number":
+		integer := schema.Type == "integer"
+
+		if schema.Minimum != nil {
+			values = append(values, boundaryValue{"minimum", numberValue(lowerBound(schema, integer), integer)})
+		}
+
+		if schema.Maximum != nil {
+			values = append(values, boundaryValue{"maximum", numberValue(upperBound(schema, integer), integer)})
+		}
+	case "string":
+		if value, ok := patternString(schema.Pattern, nil); ok {
+			return append(values, boundaryValue{"pattern", value})
+		}
+
+		if schema.MinLength != nil {
+			values = append(values, boundaryValue{"minLength", strings.Repeat("x", int(*schema.MinLength))})
+		}
+
+		if schema.MaxLength != nil {
+			values = append(values, boundaryValue{"maxLength", strings.Repeat("x", int(*schema.MaxLength))})
+		}
+	case "array":
+		if schema.Items == nil || schema.Items.Schema == nil {
+			break
+		}
+
+		for _, bound := range []struct {
+			label string
+			n     *int64
+		}{{"minItems", schema.MinItems}, {"maxItems", schema.MaxItems}} {
+			if bound.n == nil {
+				continue
+			}
+
+			items := []any{}
+			for range *bound.n {
+				items = append(items, requiredValue(*schema.Items.Schema))
+			}
+
+			values = append(values, boundaryValue{bound.label, items})
+		}
+	}
+
+	return values
+}
+
+// setField sets a field of a spec, creating its missing parent objects with their required properties set.
+func setField(spec map[string]any, schema extv1.JSONSchemaProps, path []string, value any) {
+	object := spec
+
+	for _, name := range path[:len(path)-1] {
+		schema = schema.Properties[name]
+
+		child, ok := object[name].(map[string]any)
+		if !ok {
+			child = requiredObject(schema)
+			object[name] = child
+		}
+
+		object = child
+	}
+
+	object[path[len(path)-1]] = value
+}
+
+// randomObject returns an object with the required properties of a schema and about half of its optional ones set
+// to random values, or a few random keys for a map.
+func randomObject(schema extv1.JSONSchemaProps, rng *rand.Rand) map[string]any {
+	object := map[string]any{}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		if slices.Contains(schema.Required, name) || rng.IntN(2) == 0 {
+			object[name] = randomValue(schema.Properties[name], rng)
+		}
+	}
+
+	if len(schema.Properties) == 0 && schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		for i := range rng.IntN(randomSpan) {
+			object[fmt.Sprintf("key-%d", i+1)] = randomValue(*schema.AdditionalProperties.Schema, rng)
+		}
+	}
+
+	return object
+}
+
+// randomValue returns a random value for a property of a schema, within its enum, bounds and pattern.
+func randomValue(schema extv1.JSONSchemaProps, rng *rand.Rand) any {
+	if value, ok := enumValue(schema, rng.IntN(max(len(schema.Enum), 1))); ok {
+		return value
+	}
+
+	switch schema.Type {
+	case "object":
+		return randomObject(schema, rng)
+	case "array":
+		items := []any{}
+
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return items
+		}
+
+		for range randomLength(schema.MinItems, schema.MaxItems, 0, rng) {
+			items = append(items, randomValue(*schema.Items.Schema, rng))
+		}
+
+		return items
+	case "integer":
+		lower, upper := int64(lowerBound(schema, true)), int64(upperBound(schema, true))
+		return lower + rng.Int64N(max(upper-lower, 0)+1)
+	case "number":
+		lower, upper := lowerBound(schema, false), upperBound(schema, false)
+		return lower + rng.Float64()*max(upper-lower, 0)
+	case "boolean":
+		return rng.IntN(2) == 0
+	case "string":
+		if value, ok := patternString(schema.Pattern, rng); ok {
+			return value
+		}
+
+		letters := make([]byte, randomLength(schema.MinLength, schema.MaxLength, 1, rng))
+		for i := range letters {
+			letters[i] = byte('a' + rng.IntN(26))
+		}
+
+		return string(letters)
+	}
+
+	if schema.XIntOrString {
+		return int64(rng.IntN(100))
+	}
+
+	return map[string]any{}
+}
+
+// randomLength returns a random length between a minimum (or a default) and a maximum (or randomSpan more).
+func randomLength(minimum, maximum *int64, defaultMinimum int64, rng *rand.Rand) int64 {
+	lower := defaultMinimum
+	if minimum != nil {
+		lower = *minimum
+	}
+
+	upper := lower + randomSpan
+	if maximum != nil {
+		upper = max(*maximum, lower)
+	}
+
+	return lower + rng.Int64N(upper-lower+1)
+}
+
+// lowerBound returns the lowest valid number of a schema: its minimum, or else 0 when it allows it.
+func lowerBound(schema extv1.JSONSchemaProps, integer bool) float64 {
+	if schema.Minimum == nil {
+		if schema.Maximum != nil && *schema.Maximum < randomSpan {
+			return upperBound(schema, integer) - randomSpan
+		}
+
+		return 0
+	}
+
+	value := *schema.Minimum
+	if integer {
+		value = math.Ceil(value)
+	}
+
+	if schema.ExclusiveMinimum && value == *schema.Minimum {
+		if integer {
+			return value + 1
+		}
+
+		return math.Nextafter(value, math.Inf(1))
+	}
+
+	return value
+}
+
+// upperBound returns the highest valid number of a schema: its maximum, or else randomSpan above its lower bound.
+func upperBound(schema extv1.JSONSchemaProps, integer bool) float64 {
+	if schema.Maximum == nil {
+		return lowerBound(schema, integer) + randomSpan
+	}
+
+	value := *schema.Maximum
+	if integer {
+		value = math.Floor(value)
+	}
+
+	if schema.ExclusiveMaximum && value == *schema.Maximum {
+		if integer {
+			return value - 1
+		}
+
+		return math.Nextafter(value, math.Inf(-1))
+	}
+
+	return value
+}
+
+// numberValue returns a number as an int64 for integer schemas.
+func numberValue(value float64, integer bool) any {
+	if integer {
+		return int64(value)
+	}
+
+	return value
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xrgen
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+)
+
+const testGenerateXRD = `apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: xbuckets.example.com
+spec:
+  group: example.com
+  names:
+    kind: XBucket
+    plural: xbuckets
+  versions:
+  - name: v1
+    served: true
+    referenceable: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [name]
+            properties:
+              name:
+                type: string
+                pattern: ^[a-z]{3}-[0-9]+$
+              size:
+                type: integer
+                minimum: 1
+                maximum: 5
+              tier:
+                type: string
+                enum: [small, large]
+              tags:
+                type: array
+                maxItems: 2
+                items:
+                  type: string
+              network:
+                type: object
+                properties:
+                  cidr:
+                    type: string
+                    minLength: 2
+              versioning:
+                type: boolean
+`
+
+func TestGenerate(t *testing.T) {
+	xrd, err := ParseXRD([]byte(testGenerateXRD))
+	require.NoError(t, err)
+
+	examples, err := Generate(xrd, Options{Modes: []Mode{ModeMinimal, ModeMaximal, ModeBoundary}})
+	require.NoError(t, err)
+
+	specs := make(map[string]any, len(examples))
+	names := make([]string, 0, len(examples))
+
+	for _, example := range examples {
+		assert.Equal(t, "example.com/v1", example.XR.GetAPIVersion())
+		assert.Equal(t, "XBucket", example.XR.GetKind())
+		assert.Equal(t, "example-xbucket", example.XR.GetName())
+		assert.Empty(t, example.XR.GetNamespace())
+
+		names = append(names, example.Name)
+		specs[example.Name] = example.XR.Object["spec"]
+	}
+
+	assert.Equal(t, []string{
+		"minimal",
+		"maximal",
+		"boundary spec.name pattern",
+		"boundary spec.network.cidr minLength",
+		"boundary spec.size minimum",
+		"boundary spec.size maximum",
+		"boundary spec.tags maxItems",
+		"boundary spec.tier=small",
+		"boundary spec.tier=large",
+	}, names)
+
+	assert.Equal(t, map[string]any{"name": "aaa-0"}, specs["minimal"])
+	assert.Equal(t, map[string]any{
+		"name":       "aaa-0",
+		"size":       int64(1),
+		"tier":       "large",
+		"tags":       []any{"example"},
+		"network":    map[string]any{"cidr": "example"},
+		"versioning": true,
+	}, specs["maximal"])
+	assert.Equal(t, map[string]any{"name": "aaa-0", "network": map[string]any{"cidr": "xx"}}, specs["boundary spec.network.cidr minLength"])
+	assert.Equal(t, map[string]any{"name": "aaa-0", "size": int64(5)}, specs["boundary spec.size maximum"])
+	assert.Equal(t, map[string]any{"name": "aaa-0", "tags": []any{"example", "example"}}, specs["boundary spec.tags maxItems"])
+	assert.Equal(t, map[string]any{"name": "aaa-0", "tier": "large"}, specs["boundary spec.tier=large"])
+}
+
+func TestGenerateRandom(t *testing.T) {
+	xrd, err := ParseXRD([]byte(testGenerateXRD))
+	require.NoError(t, err)
+
+	examples, err := Generate(xrd, Options{Modes: []Mode{ModeRandom}, Count: 20, Seed: 42})
+	require.NoError(t, err)
+	require.Len(t, examples, 20)
+
+	name := regexp.MustCompile(`^[a-z]{3}-[0-9]+$`)
+
+	for i, example := range examples {
+		assert.Equal(t, fmt.Sprintf("random %d", i+1), example.Name)
+
+		spec, ok := example.XR.Object["spec"].(map[string]any)
+		require.True(t, ok)
+
+		assert.Regexp(t, name, spec["name"])
+
+		if size, ok := spec["size"]; ok {
+			assert.GreaterOrEqual(t, size, int64(1))
+			assert.LessOrEqual(t, size, int64(5))
+		}
+
+		if tier, ok := spec["tier"]; ok {
+			assert.Contains(t, []any{"small", "large"}, tier)
+		}
+
+		if tags, ok := spec["tags"]; ok {
+			assert.LessOrEqual(t, len(tags.([]any)), 2)
+		}
+	}
+
+	again, err := Generate(xrd, Options{Modes: []Mode{ModeRandom}, Count: 20, Seed: 42})
+	require.NoError(t, err)
+	assert.Equal(t, examples, again, "the same seed builds the same XRs")
+
+	other, err := Generate(xrd, Options{Modes: []Mode{ModeRandom}, Count: 20, Seed: 7})
+	require.NoError(t, err)
+	assert.NotEqual(t, examples, other, "another seed builds other XRs")
+
+	defaults, err := Generate(xrd, Options{Modes: []Mode{ModeRandom}})
+	require.NoError(t, err)
+	assert.Len(t, defaults, DefaultCount)
+}
+
+func TestGenerateUnknownMode(t *testing.T) {
+	xrd, err := ParseXRD([]byte(testGenerateXRD))
+	require.NoError(t, err)
+
+	_, err = Generate(xrd, Options{Modes: []Mode{"exhaustive"}})
+	require.EqualError(t, err, "unknown XR generation mode 'exhaustive' (allowed: minimal, maximal, boundary, random)")
+}
+
+func TestPatternString(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+		ok      bool
+	}{
+		{pattern: "", ok: false},
+		{pattern: "^[a-z]{3}-[0-9]+$", want: "aaa-0", ok: true},
+		{pattern: "^(prod|staging)$", want: "prod", ok: true},
+		{pattern: `^arn:aws:iam::\d{12}:role/.*$`, want: "arn:aws:iam::000000000000:role/", ok: true},
+		{pattern: "^[^/]+$", want: "a", ok: true},
+		{pattern: "^[A-Z]?$", want: "", ok: true},
+		{pattern: "(", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got, ok := patternString(tt.pattern, nil)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xrgen
+
+import (
+	"math/rand/v2"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// preferredRunes are the runes picked from a character class, in order, before any other printable ASCII rune.
+const preferredRunes = "a0A"
+
+// patternString returns a string that matches a regular expression pattern: the shortest one with the first
+// alternatives when rng is nil, or else a random one. It returns false if the pattern is empty, uses an unsupported
+// construct, or the string does not match it.
+func patternString(pattern string, rng *rand.Rand) (string, bool) {
+	if pattern == "" {
+		return "", false
+	}
+
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+
+	var b strings.Builder
+	if !writePattern(&b, re.Simplify(), rng) {
+		return "", false
+	}
+
+	if matched, err := regexp.MatchString(pattern, b.String()); err != nil || !matched {
+		return "", false
+	}
+
+	return b.String(), true
+}
+
+// writePattern writes a string that matches a parsed regular expression.
+func writePattern(b *strings.Builder, re *syntax.Regexp, rng *rand.Rand) bool {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return true
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+		return true
+	case syntax.OpCharClass:
+		r, ok := classRune(re.Rune, rng)
+		if ok {
+			b.WriteRune(r)
+		}
+
+		return ok
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		r, _ := classRune([]rune{'a', 'z'}, rng)
+		b.WriteRune(r)
+
+		return true
+	case syntax.OpCapture:
+		return writePattern(b, re.Sub[0], rng)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		lower, upper := repeatBounds(re)
+
+		n := lower
+		if rng != nil {
+			n += rng.IntN(upper - lower + 1)
+		}
+
+		for range n {
+			if !writePattern(b, re.Sub[0], rng) {
+				return false
+			}
+		}
+
+		return true
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !writePattern(b, sub, rng) {
+				return false
+			}
+		}
+
+		return true
+	case syntax.OpAlternate:
+		sub := re.Sub[0]
+		if rng != nil {
+			sub = re.Sub[rng.IntN(len(re.Sub))]
+		}
+
+		return writePattern(b, sub, rng)
+	default:
+		return false
+	}
+}
+
+// repeatBounds returns the minimum and maximum number of repetitions of a repeat operator, with unbounded ones
+// capped to randomSpan more than their minimum.
+func repeatBounds(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpStar:
+		return 0, randomSpan
+	case syntax.OpPlus:
+		return 1, 1 + randomSpan
+	case syntax.OpQuest:
+		return 0, 1
+	default:
+		if re.Max < 0 {
+			return re.Min, re.Min + randomSpan
+		}
+
+		return re.Min, re.Max
+	}
+}
+
+// classRune returns a printable ASCII rune of a character class, given as ranges of runes: the first preferred rune
+// in it when rng is nil, or else a random one. It falls back to the first rune of the class.
+func classRune(ranges []rune, rng *rand.Rand) (rune, bool) {
+	if len(ranges) < 2 {
+		return 0, false
+	}
+
+	inClass := func(r rune) bool {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if ranges[i] <= r && r <= ranges[i+1] {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	var candidates []rune
+
+	for _, r := range preferredRunes {
+		if inClass(r) {
+			candidates = append(candidates, r)
+		}
+	}
+
+	for r := rune('!'); r <= '~'; r++ {
+		if inClass(r) && !strings.ContainsRune(preferredRunes, r) {
+			candidates = append(candidates, r)
+		}
+	}
+
+	switch {
+	case len(candidates) == 0:
+		return ranges[0], true
+	case rng == nil:
+		return candidates[0], true
+	default:
+		return candidates[rng.IntN(len(candidates))], true
+	}
+}
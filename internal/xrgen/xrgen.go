@@ -0,0 +1,261 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package xrgen builds example XRs from the OpenAPI schema of an XRD.
+package xrgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/crossplane-contrib/xprin/cmd/xprin-helpers/patchxr"
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// exampleString is the value of the required string fields without enum.
+const exampleString = "example"
+
+// ParseXRD parses an XRD from YAML.
+func ParseXRD(data []byte) (*apiextensionsv1.CompositeResourceDefinition, error) {
+	xrd := &apiextensionsv1.CompositeResourceDefinition{}
+	if err := yaml.Unmarshal(data, xrd); err != nil {
+		return nil, fmt.Errorf("failed to parse XRD YAML: %w", err)
+	}
+
+	if xrd.Spec.Group == "" || xrd.Spec.Names.Kind == "" || len(xrd.Spec.Versions) == 0 {
+		return nil, fmt.Errorf("invalid XRD %s: spec.group, spec.names.kind and spec.versions are required", xrd.GetName())
+	}
+
+	return xrd, nil
+}
+
+// ExampleXR returns an XR of the referenceable version of an XRD (or else its first served version) with only its
+// required spec fields set, and the defaults of the XRD schema applied. Required fields get their first enum value,
+// a value matching their pattern, or else a value of their type within their bounds, e.g. "example" for strings.
+func ExampleXR(xrd *apiextensionsv1.CompositeResourceDefinition) (*unstructured.Unstructured, error) {
+	g, err := newGenerator(xrd)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.xr(requiredObject(g.spec))
+}
+
+// generator builds the XRs of the example version of an XRD.
+type generator struct {
+	xrd        *apiextensionsv1.CompositeResourceDefinition
+	apiVersion string
+	spec       extv1.JSONSchemaProps // Schema of the spec of the XRs, empty if the XRD has none
+}
+
+// newGenerator returns a generator of the XRs of the example version of an XRD (see exampleVersion).
+func newGenerator(xrd *apiextensionsv1.CompositeResourceDefinition) (*generator, error) {
+	version := exampleVersion(xrd)
+
+	schema, err := versionSchema(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &generator{
+		xrd:        xrd,
+		apiVersion: xrd.Spec.Group + "/" + version.Name,
+		spec:       schema.Properties["spec"],
+	}, nil
+}
+
+// xr returns an XR with a spec, and the defaults of the XRD schema applied.
+func (g *generator) xr(spec map[string]any) (*unstructured.Unstructured, error) {
+	metadata := map[string]any{"name": "example-" + strings.ToLower(g.xrd.Spec.Names.Kind)}
+	if isNamespaced(g.xrd) {
+		metadata["namespace"] = "default"
+	}
+
+	xr := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": g.apiVersion,
+		"kind":       g.xrd.Spec.Names.Kind,
+		"metadata":   metadata,
+		"spec":       spec,
+	}}
+
+	if err := patchxr.DefaultValuesFromXRD(xr.UnstructuredContent(), g.apiVersion, *g.xrd); err != nil {
+		return nil, fmt.Errorf("failed to apply XRD defaults: %w", err)
+	}
+
+	return xr, nil
+}
+
+// exampleVersion returns the referenceable version of an XRD, or else its first served version, or else its first one.
+func exampleVersion(xrd *apiextensionsv1.CompositeResourceDefinition) apiextensionsv1.CompositeResourceDefinitionVersion {
+	for _, version := range xrd.Spec.Versions {
+		if version.Referenceable {
+			return version
+		}
+	}
+
+	for _, version := range xrd.Spec.Versions {
+		if version.Served {
+			return version
+		}
+	}
+
+	return xrd.Spec.Versions[0]
+}
+
+// versionSchema returns the OpenAPI schema of an XRD version, or an empty schema if it has none.
+func versionSchema(version apiextensionsv1.CompositeResourceDefinitionVersion) (extv1.JSONSchemaProps, error) {
+	var schema extv1.JSONSchemaProps
+
+	if version.Schema == nil || len(version.Schema.OpenAPIV3Schema.Raw) == 0 {
+		return schema, nil
+	}
+
+	if err := json.Unmarshal(version.Schema.OpenAPIV3Schema.Raw, &schema); err != nil {
+		return schema, fmt.Errorf("failed to parse the OpenAPI schema of version %s: %w", version.Name, err)
+	}
+
+	return schema, nil
+}
+
+// isNamespaced returns true if the XRs of an XRD are namespaced: its scope is Namespaced, which is the default of
+// apiextensions.crossplane.io/v2 XRDs.
+func isNamespaced(xrd *apiextensionsv1.CompositeResourceDefinition) bool {
+	if xrd.Spec.Scope != nil {
+		return *xrd.Spec.Scope == apiextensionsv1.CompositeResourceScopeNamespaced
+	}
+
+	return strings.HasSuffix(xrd.APIVersion, "/v2")
+}
+
+// requiredObject returns an object with the required properties of a schema set. Required properties with a default
+// are left to the defaulting.
+func requiredObject(schema extv1.JSONSchemaProps) map[string]any {
+	object := map[string]any{}
+
+	for _, name := range schema.Required {
+		property, ok := schema.Properties[name]
+		if !ok || property.Default != nil {
+			continue
+		}
+
+		object[name] = requiredValue(property)
+	}
+
+	return object
+}
+
+// requiredValue returns a value for a required property of a schema.
+func requiredValue(schema extv1.JSONSchemaProps) any {
+	if value, ok := enumValue(schema, 0); ok {
+		return value
+	}
+
+	switch schema.Type {
+	case "object":
+		return requiredObject(schema)
+	case "array":
+		items := []any{}
+
+		if schema.Items != nil && schema.Items.Schema != nil && schema.MinItems != nil {
+			for range *schema.MinItems {
+				items = append(items, requiredValue(*schema.Items.Schema))
+			}
+		}
+
+		return items
+	case "integer":
+		return int64(boundedNumber(schema, true))
+	case "number":
+		return boundedNumber(schema, false)
+	case "boolean":
+		return false
+	case "string":
+		if value, ok := patternString(schema.Pattern, nil); ok {
+			return value
+		}
+
+		return boundedString(schema)
+	}
+
+	if schema.XIntOrString {
+		return int64(1)
+	}
+
+	return map[string]any{}
+}
+
+// boundedNumber returns 1, or the closest number to it within the minimum and maximum of a schema.
+func boundedNumber(schema extv1.JSONSchemaProps, integer bool) float64 {
+	value := 1.0
+
+	if schema.Minimum != nil && value <= *schema.Minimum {
+		value = *schema.Minimum
+		if integer {
+			value = math.Ceil(value)
+		}
+
+		if schema.ExclusiveMinimum && value == *schema.Minimum {
+			value++
+		}
+	}
+
+	if schema.Maximum != nil && value >= *schema.Maximum {
+		value = *schema.Maximum
+		if integer {
+			value = math.Floor(value)
+		}
+
+		if schema.ExclusiveMaximum && value == *schema.Maximum {
+			value--
+		}
+	}
+
+	return value
+}
+
+// boundedString returns "example", padded or truncated to the minimum and maximum length of a schema.
+func boundedString(schema extv1.JSONSchemaProps) string {
+	value := exampleString
+
+	if schema.MinLength != nil && int64(len(value)) < *schema.MinLength {
+		value += strings.Repeat("x", int(*schema.MinLength)-len(value))
+	}
+
+	if schema.MaxLength != nil && int64(len(value)) > *schema.MaxLength {
+		value = value[:*schema.MaxLength]
+	}
+
+	return value
+}
+
+// enumValue returns the i-th enum value of a schema, or false if it has none.
+func enumValue(schema extv1.JSONSchemaProps, i int) (any, bool) {
+	if i < 0 || i >= len(schema.Enum) {
+		return nil, false
+	}
+
+	var value any
+	if err := json.Unmarshal(schema.Enum[i].Raw, &value); err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xrgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"  //nolint:depguard // testify is widely used for testing
+	"github.com/stretchr/testify/require" //nolint:depguard // testify is widely used for testing
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+const testXRD = `apiVersion: apiextensions.crossplane.io/v2
+kind: CompositeResourceDefinition
+metadata:
+  name: xnetworks.example.com
+spec:
+  group: example.com
+  names:
+    kind: XNetwork
+    plural: xnetworks
+  versions:
+  - name: v1alpha1
+    served: true
+    referenceable: false
+  - name: v1
+    served: true
+    referenceable: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [region, size, cidr, subnets, parameters, team]
+            properties:
+              region:
+                type: string
+                enum: [eu-west-1, us-east-1]
+              size:
+                type: integer
+                minimum: 3
+                maximum: 10
+              ratio:
+                type: number
+              cidr:
+                type: string
+                maxLength: 4
+              subnets:
+                type: array
+                minItems: 2
+                items:
+                  type: object
+                  required: [zone]
+                  properties:
+                    zone:
+                      type: string
+                      minLength: 10
+              parameters:
+                type: object
+                required: [enabled]
+                properties:
+                  enabled:
+                    type: boolean
+                  replicas:
+                    type: integer
+                    default: 2
+              team:
+                type: string
+                default: platform
+          status:
+            type: object
+            required: [ready]
+            properties:
+              ready:
+                type: boolean
+`
+
+func TestExampleXR(t *testing.T) {
+	xrd, err := ParseXRD([]byte(testXRD))
+	require.NoError(t, err)
+
+	xr, err := ExampleXR(xrd)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"apiVersion": "example.com/v1",
+		"kind":       "XNetwork",
+		"metadata": map[string]any{
+			"name":      "example-xnetwork",
+			"namespace": "default",
+		},
+		"spec": map[string]any{
+			"region": "eu-west-1",
+			"size":   int64(3),
+			"cidr":   "exam",
+			"subnets": []any{
+				map[string]any{"zone": "examplexxx"},
+				map[string]any{"zone": "examplexxx"},
+			},
+			"parameters": map[string]any{"enabled": false, "replicas": int64(2)},
+			"team":       "platform",
+		},
+	}, xr.Object)
+}
+
+func TestParseXRD(t *testing.T) {
+	tests := []struct {
+		name      string
+		xrd       string
+		expectErr string
+	}{
+		{
+			name:      "invalid YAML",
+			xrd:       "spec: [",
+			expectErr: "failed to parse XRD YAML",
+		},
+		{
+			name:      "not an XRD",
+			xrd:       "kind: ConfigMap\nmetadata:\n  name: test\n",
+			expectErr: "invalid XRD test: spec.group, spec.names.kind and spec.versions are required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseXRD([]byte(tt.xrd))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectErr)
+		})
+	}
+}
+
+func TestBoundedNumber(t *testing.T) {
+	minimum, maximum := 1.0, 0.5
+
+	assert.InDelta(t, 2.0, boundedNumber(schemaWithBounds(&minimum, nil, true, false), true), 0)
+	assert.InDelta(t, 0.5, boundedNumber(schemaWithBounds(nil, &maximum, false, false), false), 0)
+	assert.InDelta(t, 0.0, boundedNumber(schemaWithBounds(nil, &maximum, false, false), true), 0)
+	assert.InDelta(t, 1.0, boundedNumber(schemaWithBounds(nil, nil, false, false), true), 0)
+}
+
+func schemaWithBounds(minimum, maximum *float64, exclusiveMinimum, exclusiveMaximum bool) extv1.JSONSchemaProps {
+	return extv1.JSONSchemaProps{Minimum: minimum, Maximum: maximum, ExclusiveMinimum: exclusiveMinimum, ExclusiveMaximum: exclusiveMaximum}
+}
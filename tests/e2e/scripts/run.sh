@@ -120,16 +120,9 @@ for test_var in "${TEST_CASES[@]}"; do
         TEST_FAILED=1
     fi
 
-    if [ "${expected_exit}" -eq 0 ]; then
-        if [ ${EXIT_CODE} -ne 0 ]; then
-            echo "FAIL: expected exit code 0, got ${EXIT_CODE}"
-            TEST_FAILED=1
-        fi
-    else
-        if [ ${EXIT_CODE} -eq 0 ]; then
-            echo "FAIL: expected non-zero exit code, got 0"
-            TEST_FAILED=1
-        fi
+    if [ ${EXIT_CODE} -ne "${expected_exit}" ]; then
+        echo "FAIL: expected exit code ${expected_exit}, got ${EXIT_CODE}"
+        TEST_FAILED=1
     fi
 
     if [ ${TEST_FAILED} -eq 1 ]; then
@@ -4,7 +4,7 @@
 # This file defines all acceptance test cases. Each test case is a simple string variable
 # containing space-separated arguments (test files and flags).
 # The test ID is extracted from the variable name (e.g., testcase_001 -> "001")
-# Use testcase_<ID>_exit to set an expected non-zero exit code.
+# Use testcase_<ID>_exit to set the expected non-zero exit code: 1 when tests failed, 2 when tests could not be run.
 
 # Multiple Successful Files (Non-Verbose)
 testcase_001="examples/mytests/1_simple_tests/example1_using-xr_xprin.yaml examples/mytests/1_simple_tests/example2_using-claim_xprin.yaml examples/mytests/2_multiple_testcases/example2_multiple-reconciliation-loops-using-common_xprin.yaml"
@@ -22,15 +22,15 @@ testcase_004_exit=1
 
 # Multiple Failures - Combined File (Non-Verbose)
 testcase_005="examples/mytests/0_e2e/failures_xprin.yaml examples/mytests/0_e2e/generated_missing_required_inputs_xprin.yaml"
-testcase_005_exit=1
+testcase_005_exit=2
 
 # Multiple Failures - Combined File (Verbose)
 testcase_006="examples/mytests/0_e2e/failures_xprin.yaml examples/mytests/0_e2e/generated_missing_required_inputs_xprin.yaml -v"
-testcase_006_exit=1
+testcase_006_exit=2
 
 # Multiple Failures - Combined File (Verbose, show flags)
 testcase_007="examples/mytests/0_e2e/failures_xprin.yaml examples/mytests/0_e2e/generated_missing_required_inputs_xprin.yaml -v --show-render --show-validate --show-hooks --show-assertions"
-testcase_007_exit=1
+testcase_007_exit=2
 
 # Successful with hooks/validate/assertions (Verbose, show flags)
 testcase_008="examples/mytests/0_e2e/success_xprin.yaml -v --show-render --show-validate --show-hooks --show-assertions"
@@ -43,4 +43,4 @@ testcase_010="examples/mytests/5_chained_tests/example2_cross-composition-chaini
 
 # Invalid testsuite file
 testcase_011="examples/mytests/0_e2e/generated_invalid_xprin.yaml -v --show-render --show-validate --show-hooks --show-assertions"
-testcase_011_exit=1
+testcase_011_exit=2